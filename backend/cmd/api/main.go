@@ -1,15 +1,32 @@
 package main
 
 import (
+	"backend/internal/auth"
+	"backend/internal/changefeed"
+	"backend/internal/config"
+	"backend/internal/graphqlapi"
 	"backend/internal/handler"
+	"backend/internal/idempotency"
 	authmw "backend/internal/middleware"
+	"backend/internal/migration"
+	"backend/internal/ratelimit"
 	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"backend/internal/router"
+	"backend/internal/rpc"
+	"backend/internal/seed"
 	"backend/internal/storage"
+	"backend/internal/validation"
+	"backend/migrations"
+	"context"
+	"errors"
+	"flag"
 	"log"
-
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
-	echoSwagger "github.com/swaggo/echo-swagger"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	_ "backend/docs" // swagger docs
 )
@@ -18,54 +35,224 @@ import (
 // @version 1.0
 // @description レトロなTODOアプリケーションのAPI
 // @host localhost:8080
-// @BasePath /
+// @BasePath /api/v1
 func main() {
-	log.Println("[MAIN] Starting server initialization...")
-	storage.Init()
+	configLoader := config.BindFlags(flag.CommandLine)
+	autoMigrate := flag.Bool("auto-migrate", false, "起動前に未適用のマイグレーションを適用する")
+	printConfig := flag.Bool("print-config", false, "有効な設定（シークレットは伏せ字）を表示して終了する")
+	flag.Parse()
+
+	cfg, err := configLoader.Load()
+	if err != nil {
+		log.Fatalf("[MAIN] Invalid configuration: %v", err)
+	}
+	if *printConfig {
+		if err := cfg.Dump(os.Stdout); err != nil {
+			log.Fatalf("[MAIN] Failed to print configuration: %v", err)
+		}
+		return
+	}
+
+	log.Printf("[MAIN] Starting server initialization (env=%s)...", cfg.AppEnv)
+	var repos *repository.Repositories
+	var uow repository.UnitOfWork
+	var rateStore ratelimit.Store = ratelimit.NewMemoryStore()
+	var idemStore idempotency.Store = idempotency.NewMemoryStore()
+	// TODO・スプリントの変更を購読者（GET /events と Watch*）に配信する。DBを使う場合は変更と同じトランザクションで記録し、Broker が全インスタンスに配信する
+	hub := changefeed.NewHub(0, cfg.Changefeed.History)
+	var publisher changefeed.Publisher = hub
+	if cfg.Database.Driver == config.DriverMemory {
+		// デモモード：DBを使わず、サンプルデータを投入したインメモリのリポジトリで起動する
+		log.Println("[MAIN] Running in in-memory demo mode: all data is lost when the server stops")
+		repos = memory.NewRepositories()
+		uow = memory.NewUnitOfWork(repos)
+		if err := seed.Repositories(context.Background(), repos); err != nil {
+			log.Fatalf("[MAIN] Failed to seed demo data: %v", err)
+		}
+	} else {
+		store, err := storage.New(context.Background(), cfg.Database)
+		if err != nil {
+			log.Fatalf("[MAIN] Failed to connect to database: %v", err)
+		}
+		defer store.Close()
+
+		// 埋め込まれたマイグレーションを適用（複数インスタンスの同時起動は advisory lock で直列化）
+		if *autoMigrate {
+			migrator, err := migration.New(store.DB, store.Driver, migrations.ForDriver(store.Driver))
+			if err != nil {
+				log.Fatalf("[MAIN] Failed to load migrations: %v", err)
+			}
+			if err := migrator.Up(0); err != nil {
+				log.Fatalf("[MAIN] Failed to apply migrations: %v", err)
+			}
+		}
+
+		repos = repository.NewRepositories(store.DB)
+		uow = repository.NewUnitOfWork(store.DB, repository.DefaultUnitOfWorkConfig())
+
+		// レートリミッタのストア（RATE_LIMIT_STORE=postgres で複数インスタンス間で共有）
+		if cfg.RateLimit.Store == "postgres" {
+			rateStore = ratelimit.NewPostgresStore(store.DB)
+		}
+
+		// Idempotency-Key のレスポンスはDBに保存し、複数インスタンス間で共有する
+		idemStore = idempotency.NewSQLStore(store.DB)
+
+		// 変更イベントは change_events に記録されたものに番号を振って配信し、PostgreSQL では LISTEN / NOTIFY で他のインスタンスに知らせる
+		broker := changefeed.NewBroker(store.DB, hub, changefeed.BrokerConfig{
+			Driver:    store.Driver,
+			Retention: cfg.Changefeed.Retention,
+		})
+		if err := broker.Start(context.Background()); err != nil {
+			log.Fatalf("[MAIN] Failed to load change events: %v", err)
+		}
+		var wake <-chan struct{}
+		if store.Driver == config.DriverPostgres {
+			wake = changefeed.Listen(context.Background(), cfg.Database.DSN())
+		}
+		go broker.Run(context.Background(), wake)
+		publisher = broker
+	}
+
+	// リポジトリの変更をイベントとして同じトランザクションで記録し、コミット後に配信する
+	repos = changefeed.NewRepositories(repos, uow, publisher)
+	uow = changefeed.NewUnitOfWork(uow, publisher)
 
 	// リポジトリの初期化
-	todoRepo := repository.NewTodoRepository(storage.DB)
-	sprintRepo := repository.NewSprintRepository(storage.DB)
-	userRepo := repository.NewUserRepository(storage.DB)
+	todoRepo := repos.Todos
+	sprintRepo := repos.Sprints
+	userRepo := repos.Users
+	authAuditRepo := repos.AuthAudit
+	mfaRepo := repos.MFA
+	workspaceRepo := repos.Workspaces
+	statsRepo := repos.Stats
+
+	limiter := ratelimit.NewLimiter(rateStore, ratelimit.DefaultConfig())
+
+	// パスワードポリシー（PASSWORD_MIN_LENGTH / PASSWORD_REJECT_BREACHED / PASSWORD_MAX_USERNAME_SIMILARITY で上書き可能）
+	passwordPolicy := validation.DefaultPasswordPolicy()
+	passwordPolicy.MinLength = cfg.Auth.PasswordMinLength
+	passwordPolicy.RejectBreached = cfg.Auth.PasswordRejectBreached
+	passwordPolicy.MaxUsernameSimilarity = cfg.Auth.PasswordMaxUsernameSimilarity
+
+	// JWT署名鍵の読み込み（デフォルトシークレットでの起動は APP_ENV=development のときのみ許可）
+	keyManager, err := auth.NewKeyManager(auth.Config{
+		KeysDir:    cfg.Auth.JWTKeysDir,
+		ActiveKID:  cfg.Auth.JWTActiveKID,
+		HMACSecret: cfg.Auth.JWTSecret,
+		DevMode:    cfg.IsDevelopment(),
+	})
+	if err != nil {
+		log.Fatalf("[MAIN] Failed to load JWT keys: %v", err)
+	}
+	tokens := auth.NewTokenService(keyManager)
+
+	// SIGHUPで鍵を再読み込み（ローテーション用）
+	reload := make(chan os.Signal, 1)
+	signal.Notify(reload, syscall.SIGHUP)
+	go func() {
+		for range reload {
+			if err := keyManager.Reload(); err != nil {
+				log.Printf("[MAIN] Failed to reload JWT keys: %v", err)
+			}
+		}
+	}()
+
+	// 期限切れの Idempotency-Key を定期的に削除
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for range ticker.C {
+			if _, err := idemStore.DeleteExpired(context.Background(), time.Now()); err != nil {
+				log.Printf("[MAIN] Failed to delete expired idempotency keys: %v", err)
+			}
+		}
+	}()
 
 	// ハンドラーの初期化
 	todoHandler := handler.NewTodoHandler(todoRepo)
+	todoBulkHandler := handler.NewTodoBulkHandler(uow)
 	sprintHandler := handler.NewSprintHandler(sprintRepo)
-	authHandler := handler.NewAuthHandler(userRepo)
-
-	e := echo.New()
-
-	e.Use(middleware.Logger())
-	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
-
-	// 認証不要エンドポイント
-	e.POST("/login", authHandler.Login)
-	e.POST("/register", authHandler.Register)
-
-	// Swagger UI
-	e.GET("/swagger/*", echoSwagger.WrapHandler)
-
-	// 認証必要エンドポイント
-	protected := e.Group("")
-	protected.Use(authmw.AuthMiddleware())
-
-	// todos
-	protected.GET("/todos", todoHandler.GetTodos)
-	protected.POST("/todos", todoHandler.CreateTodo)
-	protected.POST("/todos/search", todoHandler.SearchTodos)
-	protected.PUT("/todos/:id", todoHandler.UpdateTodo)
-	protected.DELETE("/todos/:id", todoHandler.DeleteTodo)
-
-	// sprints
-	protected.GET("/sprints", sprintHandler.GetSprints)
-	protected.POST("/sprints", sprintHandler.CreateSprint)
-	protected.POST("/sprints/search", sprintHandler.SearchSprints)
-	protected.PUT("/sprints/:id", sprintHandler.UpdateSprint)
-	protected.PUT("/sprints/:id/favorite", sprintHandler.UpdateFavorite)
-	protected.DELETE("/sprints/:id", sprintHandler.DeleteSprint)
-
-	log.Println("[MAIN] Server starting on :8080")
-	log.Println("[MAIN] Swagger UI: http://localhost:8080/swagger/index.html")
-	e.Logger.Fatal(e.Start(":8080"))
+	authHandler := handler.NewAuthHandler(userRepo, mfaRepo, workspaceRepo, authAuditRepo, limiter, tokens)
+	mfaHandler := handler.NewMFAHandler(userRepo, mfaRepo, workspaceRepo, authAuditRepo, limiter, tokens)
+	workspaceHandler := handler.NewWorkspaceHandler(workspaceRepo, userRepo, mfaRepo)
+	jwksHandler := handler.NewJWKSHandler(keyManager)
+	adminHandler := handler.NewAdminHandler(userRepo, statsRepo, uow)
+	eventsHandler := handler.NewEventsHandler(hub, userRepo, tokens)
+
+	// リクエストの検証（model の validate タグ。exists=sprint は削除済みでないスプリントの存在を確認する）
+	validator := validation.NewValidator(passwordPolicy)
+	validator.RegisterExists("sprint", sprintRepo.Exists)
+
+	// GraphQL（REST と同じリポジトリと入力検証を使う）
+	graphqlAPI := graphqlapi.New(todoRepo, sprintRepo, userRepo, validator)
+	graphqlHandler := handler.NewGraphQLHandler(graphqlAPI, graphqlapi.Limits{
+		MaxDepth:      cfg.GraphQL.MaxDepth,
+		MaxComplexity: cfg.GraphQL.MaxComplexity,
+	})
+
+	// X-Forwarded-For は設定したプロキシから届いた場合だけ使う（Validate で検証済み）
+	trustedProxies, err := cfg.Server.TrustedProxyNets()
+	if err != nil {
+		log.Fatalf("[MAIN] Invalid trusted proxies: %v", err)
+	}
+
+	e := router.New(router.Config{
+		Handlers: router.Handlers{
+			Todo:      todoHandler,
+			TodoBulk:  todoBulkHandler,
+			Sprint:    sprintHandler,
+			Auth:      authHandler,
+			MFA:       mfaHandler,
+			Workspace: workspaceHandler,
+			JWKS:      jwksHandler,
+			Admin:     adminHandler,
+			GraphQL:   graphqlHandler,
+			Events:    eventsHandler,
+		},
+		Tokens:         tokens,
+		Users:          userRepo,
+		Limiter:        limiter,
+		Idempotency:    authmw.Idempotency(idemStore, cfg.Idempotency.TTL, router.APIPrefix),
+		Validator:      validator,
+		RequestTimeout: cfg.Database.RequestTimeout,
+		LegacyRoutes:   cfg.Server.LegacyRoutes,
+		TrustedProxies: trustedProxies,
+	})
+
+	e.Server.ReadTimeout = cfg.Server.ReadTimeout
+	// 変更イベントの購読（GET /events）は、ハンドラーが書き込みごとに期限を延ばす
+	e.Server.WriteTimeout = cfg.Server.WriteTimeout
+	e.Server.IdleTimeout = cfg.Server.IdleTimeout
+
+	// RPC サーバー（Connect / gRPC / gRPC-Web。REST とは別のポート。ストリームを切らないよう WriteTimeout は設定しない）
+	if cfg.RPC.Port != 0 {
+		rpcServer := rpc.NewServer(rpc.Config{
+			Todos:          todoRepo,
+			Sprints:        sprintRepo,
+			Tokens:         tokens,
+			Users:          userRepo,
+			Hub:            hub,
+			Validator:      validator,
+			RequestTimeout: cfg.Database.RequestTimeout,
+			TrustedProxies: trustedProxies,
+		})
+		rpcServer.Server.ReadTimeout = cfg.Server.ReadTimeout
+		rpcServer.Server.IdleTimeout = cfg.Server.IdleTimeout
+		go func() {
+			log.Printf("[MAIN] Connect RPC server starting on %s", cfg.RPC.Addr())
+			if err := rpcServer.Start(cfg.RPC.Addr()); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatalf("[MAIN] Connect RPC server failed: %v", err)
+			}
+		}()
+	}
+
+	addr := cfg.Server.Addr()
+	log.Printf("[MAIN] Server starting on %s (API: %s)", addr, router.APIPrefix)
+	if cfg.Server.LegacyRoutes {
+		log.Printf("[MAIN] Legacy routes without %s are enabled and deprecated (sunset: %s)",
+			router.APIPrefix, router.LegacyDeprecation.Sunset.Format("2006-01-02"))
+	}
+	log.Printf("[MAIN] Swagger UI: http://localhost%s/swagger/index.html", addr)
+	e.Logger.Fatal(e.Start(addr))
 }
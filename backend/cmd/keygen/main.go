@@ -0,0 +1,27 @@
+package main
+
+import (
+	"backend/internal/auth"
+	"flag"
+	"fmt"
+	"log"
+)
+
+// JWT署名鍵を生成する
+//
+//	go run ./cmd/keygen -dir keys -alg EdDSA
+//
+// 新しい鍵を追加してAPIを再起動（またはSIGHUP）すると新しい鍵で署名を始める。
+// 古い鍵はトークンの有効期限（24時間）が過ぎるまで残しておき、その後削除する。
+func main() {
+	dir := flag.String("dir", "keys", "鍵を保存するディレクトリ")
+	alg := flag.String("alg", "EdDSA", "署名アルゴリズム（EdDSA または RS256）")
+	flag.Parse()
+
+	kid, err := auth.GenerateKeyFile(*dir, *alg)
+	if err != nil {
+		log.Fatalf("[KEYGEN] Failed to generate key: %v", err)
+	}
+
+	fmt.Println(kid)
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"backend/internal/migration"
+)
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// sqliteSubdir は SQLite 用マイグレーションのディレクトリ（dir からの相対パス）
+const sqliteSubdir = "sqlite"
+
+// createMigration は次のバージョン番号で up/down の空ファイルを作成する
+// SQLite 用のディレクトリがあれば、同じバージョンのファイルをそちらにも作成する
+func createMigration(dir, name string) error {
+	name = strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return &usageError{"NAME must contain letters or digits"}
+	}
+
+	migrations, err := migration.Load(os.DirFS(dir))
+	if err != nil {
+		return err
+	}
+	next := 1
+	if len(migrations) > 0 {
+		next = migrations[len(migrations)-1].Version + 1
+	}
+
+	dirs := []string{dir}
+	if info, err := os.Stat(filepath.Join(dir, sqliteSubdir)); err == nil && info.IsDir() {
+		dirs = append(dirs, filepath.Join(dir, sqliteSubdir))
+	}
+
+	for _, d := range dirs {
+		for _, direction := range []string{"up", "down"} {
+			file := filepath.Join(d, fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+			content := fmt.Sprintf("-- %04d %s (%s)\n", next, name, direction)
+			if err := writeNewFile(file, content); err != nil {
+				return err
+			}
+			fmt.Println(file)
+		}
+	}
+
+	return nil
+}
+
+// writeNewFile は file を新規作成する（既に存在する場合はエラー）
+func writeNewFile(file, content string) error {
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", file, err)
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -1,157 +1,238 @@
 package main
 
 import (
-	"database/sql"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
-	"path/filepath"
-	"sort"
 	"strconv"
-	"strings"
 
-	"github.com/joho/godotenv"
-	_ "github.com/lib/pq"
+	"backend/internal/config"
+	"backend/internal/migration"
+	"backend/internal/seed"
+	"backend/internal/storage"
+	"backend/migrations"
 )
 
-func main() {
-	log.Println("[MIGRATION] Starting database migration...")
+// 終了コード
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
 
-	// .envファイルの読み込み
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Error loading .env file")
-	}
+// migrationsDir は create で新しいファイルを作成するディレクトリ
+// （実行するマイグレーションはバイナリに埋め込まれたものを使う）
+const migrationsDir = "migrations"
+
+const usage = `Usage: go run ./cmd/migrate [global flags] <command> [args]
+
+Global flags:
+  -config FILE     設定ファイル（YAML）。DB接続先は -db-host / -db-name などでも上書きできる
+                   （一覧は -h で表示）。SQLite は -db-driver sqlite -db-path FILE
+
+Commands:
+  up [N]           未適用のマイグレーションを適用（N指定時はN件のみ）
+  down [N]         適用済みのマイグレーションをN件ロールバック（デフォルト1件）
+  status           適用済み・未適用のマイグレーション一覧を表示
+  verify           適用済みのファイルが変更されていないか検証（不一致は終了コード1）
+  goto VERSION     指定バージョンまで up / down する
+  force VERSION    SQLを実行せずに、VERSION以下を適用済み・それ以降を未適用として記録する
+                   （VERSION以下の checksum も現在のファイルで記録し直す）
+  create NAME      新しいマイグレーションファイル（up/down）を作成する（sqlite/ にも作成）
+  seed SET [flags] サンプルデータを投入（何度実行しても重複しない）
+                   SET: dev | demo | e2e（demo は PostgreSQL のみ）
+                   -sprints N  demo で生成するスプリント数（デフォルト50）
+                   -todos N    demo でスプリントごとに生成するTODO数（デフォルト200）
+
+コマンドを省略した場合は up を実行します。`
+
+// usageError は引数の誤りを表す（終了コード2）
+type usageError struct {
+	msg string
+}
 
-	// データベース接続
-	db := connectDB()
-	defer db.Close()
+func (e *usageError) Error() string {
+	return e.msg
+}
 
-	// マイグレーション履歴テーブルの確認・作成
-	ensureSchemaVersionTable(db)
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
 
-	// 適用済みのマイグレーションを取得
-	appliedMigrations := getAppliedMigrations(db)
-	appliedMap := make(map[int]bool)
-	for _, version := range appliedMigrations {
-		appliedMap[version] = true
+func run(args []string) int {
+	fs := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	configLoader := config.BindFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, usage)
+			return exitOK
+		}
+		return exitUsage
 	}
+	args = fs.Args()
 
-	// マイグレーションファイルの取得とソート
-	migrationFiles, err := filepath.Glob("migrations/*.up.sql")
-	if err != nil {
-		log.Fatalf("[MIGRATION] Could not find migration files: %v", err)
+	command := "up"
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
 	}
-	sort.Strings(migrationFiles)
-
-	log.Println("[MIGRATION] Found migration files:", migrationFiles)
 
-	// マイグレーションの実行
-	for _, file := range migrationFiles {
-		version, err := getVersionFromFile(file)
-		if err != nil {
-			log.Printf("[MIGRATION] Skipping file with invalid version format: %s", file)
-			continue
-		}
-
-		if _, applied := appliedMap[version]; applied {
-			log.Printf("[MIGRATION] Skipping already applied migration: %s", file)
-			continue
-		}
-
-		log.Printf("[MIGRATION] Applying migration: %s", file)
-		content, err := os.ReadFile(file)
-		if err != nil {
-			log.Fatalf("[MIGRATION] Failed to read migration file %s: %v", file, err)
-		}
-
-		tx, err := db.Begin()
-		if err != nil {
-			log.Fatalf("[MIGRATION] Failed to begin transaction: %v", err)
-		}
+	err := dispatch(configLoader, command, args)
+
+	var uerr *usageError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &uerr), errors.Is(err, migration.ErrUnknownVersion):
+		fmt.Fprintf(os.Stderr, "Error: %v\n\n%s\n", err, usage)
+		return exitUsage
+	default:
+		log.Printf("[MIGRATION] ✗ %v", err)
+		return exitError
+	}
+}
 
-		if _, err := tx.Exec(string(content)); err != nil {
-			tx.Rollback()
-			log.Fatalf("[MIGRATION] Failed to apply migration %s: %v", file, err)
+func dispatch(configLoader *config.Loader, command string, args []string) error {
+	switch command {
+	case "help", "-h", "--help":
+		fmt.Println(usage)
+		return nil
+	case "create":
+		if len(args) != 1 {
+			return &usageError{"create requires exactly one NAME"}
 		}
+		return createMigration(migrationsDir, args[0])
+	case "up", "down", "status", "verify", "goto", "force", "seed":
+	default:
+		return &usageError{fmt.Sprintf("unknown command %q", command)}
+	}
 
-		if err := addMigrationHistory(tx, version); err != nil {
-			tx.Rollback()
-			log.Fatalf("[MIGRATION] Failed to record migration history for version %d: %v", version, err)
+	// 引数はDB接続前に検証する
+	var n int
+	var seedSet string
+	seedOpts := seed.DefaultOptions()
+	var err error
+	switch command {
+	case "seed":
+		seedSet, err = parseSeedArgs(args, &seedOpts)
+	case "up":
+		n, err = optionalCount(args, 0)
+	case "down":
+		n, err = optionalCount(args, 1)
+	case "goto", "force":
+		n, err = requiredVersion(command, args)
+	case "status", "verify":
+		if len(args) != 0 {
+			err = &usageError{command + " takes no arguments"}
 		}
+	}
+	if err != nil {
+		return err
+	}
 
-		if err := tx.Commit(); err != nil {
-			log.Fatalf("[MIGRATION] Failed to commit transaction for migration %s: %v", file, err)
-		}
+	log.Println("[MIGRATION] Starting database migration...")
 
-		log.Printf("[MIGRATION] ✓ Successfully applied migration: %s", file)
+	cfg, err := configLoader.Load()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
 	}
 
-	log.Println("[MIGRATION] Migration completed successfully!")
-}
+	// 本番環境にサンプルデータや既知のパスワードを持つユーザーを作らない
+	if command == "seed" && cfg.AppEnv == config.EnvProduction {
+		return errors.New("refusing to seed when APP_ENV=production")
+	}
 
-func connectDB() *sql.DB {
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbPassword := os.Getenv("DB_PASSWORD")
-	dbName := os.Getenv("DB_NAME")
+	store, err := storage.New(context.Background(), cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+	db := store.DB
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dbHost, dbPort, dbUser, dbPassword, dbName)
+	// シードはマイグレーション履歴とは独立して管理する
+	if command == "seed" {
+		return seed.Run(context.Background(), db, seedSet, seedOpts)
+	}
 
-	db, err := sql.Open("postgres", dsn)
+	m, err := migration.New(db, store.Driver, migrations.ForDriver(store.Driver))
 	if err != nil {
-		log.Fatalf("[MIGRATION] Failed to connect to database: %v", err)
+		return err
 	}
-	if err := db.Ping(); err != nil {
-		log.Fatalf("[MIGRATION] Failed to ping database: %v", err)
+
+	switch command {
+	case "up":
+		return m.Up(n)
+	case "down":
+		return m.Down(n)
+	case "goto":
+		return m.Goto(n)
+	case "force":
+		return m.Force(n)
+	case "verify":
+		return m.Verify()
+	default:
+		return m.Status(os.Stdout)
 	}
-	log.Println("[MIGRATION] Successfully connected to database!")
-	return db
 }
 
-func ensureSchemaVersionTable(db *sql.DB) {
-	log.Println("[MIGRATION] Ensuring schema_migrations table exists...")
-	query := `
-		CREATE TABLE IF NOT EXISTS schema_migrations (
-			version INT PRIMARY KEY
-		);
-	`
-	if _, err := db.Exec(query); err != nil {
-		log.Fatalf("[MIGRATION] Failed to create or verify schema_migrations table: %v", err)
-	}
-	log.Println("[MIGRATION] ✓ schema_migrations table verified.")
+// optionalCount は省略可能な件数引数を読む
+func optionalCount(args []string, def int) (int, error) {
+	switch len(args) {
+	case 0:
+		return def, nil
+	case 1:
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return 0, &usageError{fmt.Sprintf("invalid count %q", args[0])}
+		}
+		return n, nil
+	default:
+		return 0, &usageError{"too many arguments"}
+	}
 }
 
-func getAppliedMigrations(db *sql.DB) []int {
-	rows, err := db.Query("SELECT version FROM schema_migrations ORDER BY version")
-	if err != nil {
-		log.Fatalf("[MIGRATION] Failed to query schema_migrations table: %v", err)
+// parseSeedArgs はシードセット名とオプションを読む（seed SET [-sprints N] [-todos N]）
+func parseSeedArgs(args []string, opts *seed.Options) (string, error) {
+	if len(args) == 0 {
+		return "", &usageError{"seed requires a SET name"}
 	}
-	defer rows.Close()
+	name := args[0]
 
-	var versions []int
-	for rows.Next() {
-		var version int
-		if err := rows.Scan(&version); err != nil {
-			log.Fatalf("[MIGRATION] Failed to scan migration version: %v", err)
+	valid := false
+	for _, s := range seed.Sets() {
+		if s.Name == name {
+			valid = true
+			break
 		}
-		versions = append(versions, version)
 	}
-	return versions
-}
+	if !valid {
+		return "", &usageError{fmt.Sprintf("unknown seed set %q", name)}
+	}
 
-func getVersionFromFile(file string) (int, error) {
-	base := filepath.Base(file)
-	parts := strings.Split(base, "_")
-	if len(parts) < 1 {
-		return 0, fmt.Errorf("invalid migration file name format: %s", base)
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.IntVar(&opts.Sprints, "sprints", opts.Sprints, "")
+	fs.IntVar(&opts.TodosPerSprint, "todos", opts.TodosPerSprint, "")
+	if err := fs.Parse(args[1:]); err != nil {
+		return "", &usageError{err.Error()}
+	}
+	if fs.NArg() != 0 || opts.Sprints < 0 || opts.TodosPerSprint < 0 {
+		return "", &usageError{"invalid seed arguments"}
 	}
-	return strconv.Atoi(parts[0])
+	return name, nil
 }
 
-func addMigrationHistory(tx *sql.Tx, version int) error {
-	query := "INSERT INTO schema_migrations (version) VALUES ($1)"
-	_, err := tx.Exec(query, version)
-	return err
+// requiredVersion は必須のバージョン引数を読む（0 は全て未適用の状態）
+func requiredVersion(command string, args []string) (int, error) {
+	if len(args) != 1 {
+		return 0, &usageError{command + " requires exactly one VERSION"}
+	}
+	v, err := strconv.Atoi(args[0])
+	if err != nil || v < 0 {
+		return 0, &usageError{fmt.Sprintf("invalid version %q", args[0])}
+	}
+	return v, nil
 }
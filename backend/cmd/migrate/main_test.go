@@ -0,0 +1,53 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"backend/internal/seed"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateMigration(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0003_existing.up.sql"), []byte("SELECT 1;"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "sqlite"), 0o755))
+
+	require.NoError(t, createMigration(dir, "Add Todo Tags"))
+
+	for _, d := range []string{dir, filepath.Join(dir, "sqlite")} {
+		assert.FileExists(t, filepath.Join(d, "0004_add_todo_tags.up.sql"))
+		assert.FileExists(t, filepath.Join(d, "0004_add_todo_tags.down.sql"))
+	}
+}
+
+func TestRun_UsageErrors(t *testing.T) {
+	assert.Equal(t, exitUsage, run([]string{"unknown"}))
+	assert.Equal(t, exitUsage, run([]string{"down", "zero"}))
+	assert.Equal(t, exitUsage, run([]string{"goto"}))
+	assert.Equal(t, exitUsage, run([]string{"create"}))
+}
+
+func TestParseSeedArgs(t *testing.T) {
+	opts := seed.DefaultOptions()
+	name, err := parseSeedArgs([]string{"demo", "-sprints", "5", "-todos", "10"}, &opts)
+	require.NoError(t, err)
+	assert.Equal(t, "demo", name)
+	assert.Equal(t, 5, opts.Sprints)
+	assert.Equal(t, 10, opts.TodosPerSprint)
+
+	for _, args := range [][]string{
+		{},
+		{"prod"},
+		{"demo", "-sprints", "-1"},
+		{"demo", "-unknown"},
+		{"demo", "extra"},
+	} {
+		opts := seed.DefaultOptions()
+		_, err := parseSeedArgs(args, &opts)
+		assert.Error(t, err, "args: %v", args)
+	}
+}
@@ -0,0 +1,111 @@
+// Package apperror はリポジトリ・ハンドラー・ミドルウェアで共有するドメインエラーを定義する
+//
+// HTTPのステータスへの変換は middleware.ErrorHandler が行う。
+// Code はクライアントが分岐に使う安定した値、Message はクライアントに返す説明で、
+// 原因のエラー（Err）はログにのみ出力する。
+package apperror
+
+import (
+	"backend/internal/model"
+	"errors"
+)
+
+// エラーの種類。errors.Is(err, apperror.ErrNotFound) のように判定する
+var (
+	ErrBadRequest         = errors.New("bad request")
+	ErrValidation         = errors.New("validation failed")
+	ErrUnauthorized       = errors.New("unauthorized")
+	ErrForbidden          = errors.New("forbidden")
+	ErrNotFound           = errors.New("not found")
+	ErrConflict           = errors.New("conflict")
+	ErrRateLimited        = errors.New("rate limited")
+	ErrPreconditionFailed = errors.New("precondition failed")
+)
+
+// Error は種類・エラーコード・クライアント向けの説明を持つエラー
+type Error struct {
+	Kind    error
+	Code    string
+	Message string
+	Fields  []model.FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	msg := e.Code + ": " + e.Message
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// Is は種類（ErrNotFound など）か、同じ種類・エラーコードの *Error と一致するかを判定する
+// WithCause で原因を付けたエラーも元のエラーと一致する
+func (e *Error) Is(target error) bool {
+	if t, ok := target.(*Error); ok {
+		return t.Kind == e.Kind && t.Code == e.Code
+	}
+	return target == e.Kind
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// WithCause は原因のエラーを付けたコピーを返す（原因はログにのみ出力される）
+func (e *Error) WithCause(err error) *Error {
+	copied := *e
+	copied.Err = err
+	return &copied
+}
+
+func newError(kind error, code, message string) *Error {
+	return &Error{Kind: kind, Code: code, Message: message}
+}
+
+// BadRequest はリクエストの形式が不正なときのエラー
+func BadRequest(code, message string) *Error {
+	return newError(ErrBadRequest, code, message)
+}
+
+// Validation はフィールド単位の検証エラーをまとめたエラー
+func Validation(fields ...model.FieldError) *Error {
+	e := newError(ErrValidation, CodeValidationFailed, "Validation failed")
+	e.Fields = fields
+	return e
+}
+
+// Unprocessable は形式は正しいが処理できないリクエストのエラー（フィールド単位ではない検証エラー）
+func Unprocessable(code, message string) *Error {
+	return newError(ErrValidation, code, message)
+}
+
+// Unauthorized は認証に失敗したときのエラー
+func Unauthorized(code, message string) *Error {
+	return newError(ErrUnauthorized, code, message)
+}
+
+// Forbidden は権限が足りないときのエラー
+func Forbidden(code, message string) *Error {
+	return newError(ErrForbidden, code, message)
+}
+
+// NotFound は対象が存在しないときのエラー
+func NotFound(code, message string) *Error {
+	return newError(ErrNotFound, code, message)
+}
+
+// Conflict は現在の状態と矛盾する操作のエラー（一意制約違反など）
+func Conflict(code, message string) *Error {
+	return newError(ErrConflict, code, message)
+}
+
+// PreconditionFailed は条件付きリクエストの条件（If-Match のバージョンなど）を満たさないときのエラー
+func PreconditionFailed(code, message string) *Error {
+	return newError(ErrPreconditionFailed, code, message)
+}
+
+// RateLimited はリクエスト数の制限を超えたときのエラー
+func RateLimited(code, message string) *Error {
+	return newError(ErrRateLimited, code, message)
+}
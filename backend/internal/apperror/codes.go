@@ -0,0 +1,55 @@
+package apperror
+
+// エラーコード。クライアントが分岐に使うため、一度公開した値は変更しない
+const (
+	// リクエスト
+	CodeInvalidInput     = "invalid_input"
+	CodeInvalidID        = "invalid_id"
+	CodeValidationFailed = "validation_failed"
+	CodeNotFound         = "not_found"
+	CodeMethodNotAllowed = "method_not_allowed"
+	CodeRequestTooLarge  = "request_too_large"
+
+	// Idempotency-Key
+	CodeInvalidIdempotencyKey = "invalid_idempotency_key"
+	CodeIdempotencyKeyReused  = "idempotency_key_reused"
+	CodeIdempotencyInProgress = "idempotency_request_in_progress"
+
+	// 認証・認可
+	CodeAuthorizationRequired   = "authorization_required"
+	CodeInvalidToken            = "invalid_token"
+	CodeInvalidCredentials      = "invalid_credentials"
+	CodeInsufficientPermissions = "insufficient_permissions"
+	CodeRateLimited             = "rate_limited"
+	CodeAccountLocked           = "account_locked"
+
+	// MFA
+	CodeInvalidMFACode          = "invalid_mfa_code"
+	CodeMFAAlreadyEnabled       = "mfa_already_enabled"
+	CodeMFANotEnabled           = "mfa_not_enabled"
+	CodeMFAEnrollmentNotStarted = "mfa_enrollment_not_started"
+	CodeMFARequiredByWorkspace  = "mfa_required_by_workspace"
+	CodeMFASetupRequired        = "mfa_setup_required"
+
+	// リソース
+	CodeTodoNotFound           = "todo_not_found"
+	CodeSprintNotFound         = "sprint_not_found"
+	CodeUserNotFound           = "user_not_found"
+	CodeWorkspaceNotFound      = "workspace_not_found"
+	CodeUsernameTaken          = "username_taken"
+	CodeEmailTaken             = "email_taken"
+	CodeConflict               = "conflict"
+	CodeInvalidRole            = "invalid_role"
+	CodeCannotModifySelf       = "cannot_modify_self"
+	CodeWorkspaceAdminRequired = "workspace_admin_required"
+	CodeWorkspaceOwnerRequired = "workspace_owner_required"
+	CodeWorkspaceOwnerRole     = "workspace_owner_role_immutable"
+	CodeMemberExists           = "workspace_member_exists"
+	CodeMemberNotFound         = "workspace_member_not_found"
+	CodeVersionMismatch        = "version_mismatch"
+
+	// サーバー
+	CodeTimeout         = "timeout"
+	CodeRequestCanceled = "request_canceled"
+	CodeInternal        = "internal_error"
+)
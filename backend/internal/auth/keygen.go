@@ -0,0 +1,61 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// GenerateKeyFile は新しい署名鍵を生成して dir/<kid>.pem に保存し、kidを返す
+// kidは生成時刻から始まるので、ActiveKID未指定の場合は最後に生成した鍵が署名に使われる
+func GenerateKeyFile(dir, alg string) (string, error) {
+	var private interface{}
+	switch alg {
+	case "EdDSA":
+		_, k, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return "", err
+		}
+		private = k
+	case "RS256":
+		k, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			return "", err
+		}
+		private = k
+	default:
+		return "", fmt.Errorf("unsupported algorithm %q (use EdDSA or RS256)", alg)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+
+	kid := time.Now().UTC().Format("20060102T150405.000000000Z") + "-" + strings.ToLower(alg)
+	path := filepath.Join(dir, kid+".pem")
+
+	// 既存の鍵を上書きしない
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if err := pem.Encode(f, &pem.Block{Type: "PRIVATE KEY", Bytes: der}); err != nil {
+		return "", err
+	}
+
+	return kid, f.Close()
+}
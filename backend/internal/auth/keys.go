@@ -0,0 +1,250 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// DefaultSecret は以前のバージョンで使われていたデフォルトのHMACシークレット
+// 開発モード以外でこの値のまま起動することは許可しない
+const DefaultSecret = "your-secret-key-change-this-in-production"
+
+// Config はキーマネージャの設定
+type Config struct {
+	// KeysDir は署名鍵（<kid>.pem）を置くディレクトリ
+	KeysDir string
+	// ActiveKID は署名に使う鍵のID。空の場合は KeysDir 内で名前順が最後の秘密鍵
+	ActiveKID string
+	// HMACSecret はHS256用のシークレット。非対称鍵への移行中は旧トークンの検証にのみ使う
+	HMACSecret string
+	// DevMode が true の場合のみ、鍵もシークレットもなければデフォルトシークレットで起動する
+	DevMode bool
+}
+
+// signingKey は1つの署名鍵（公開鍵のみの場合は検証専用）
+type signingKey struct {
+	kid     string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeyManager はJWTの署名鍵を管理する
+// 複数の鍵を同時に保持できるので、新しい鍵で署名しながら古い鍵で発行済みトークンを検証できる
+type KeyManager struct {
+	cfg Config
+
+	mu         sync.RWMutex
+	keys       map[string]*signingKey
+	activeKID  string
+	hmacSecret []byte
+}
+
+func NewKeyManager(cfg Config) (*KeyManager, error) {
+	km := &KeyManager{cfg: cfg}
+	if err := km.Reload(); err != nil {
+		return nil, err
+	}
+	return km, nil
+}
+
+// Reload は KeysDir から鍵を読み直す（ローテーション時にSIGHUPなどで呼ぶ）
+func (km *KeyManager) Reload() error {
+	keys := map[string]*signingKey{}
+	if km.cfg.KeysDir != "" {
+		var err error
+		keys, err = loadKeys(km.cfg.KeysDir)
+		if err != nil {
+			return err
+		}
+	}
+
+	activeKID, err := selectActiveKID(keys, km.cfg.ActiveKID)
+	if err != nil {
+		return err
+	}
+
+	var hmacSecret []byte
+	switch {
+	case km.cfg.HMACSecret != "" && km.cfg.HMACSecret != DefaultSecret:
+		hmacSecret = []byte(km.cfg.HMACSecret)
+	case activeKID != "":
+		// 非対称鍵があればデフォルトシークレットは使わない
+	case km.cfg.DevMode:
+		log.Println("[AUTH] WARNING: using the default JWT secret (development mode only)")
+		hmacSecret = []byte(DefaultSecret)
+	default:
+		return errors.New("no JWT signing key configured: set JWT_KEYS_DIR or a non-default JWT_SECRET (or APP_ENV=development)")
+	}
+
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	km.keys = keys
+	km.activeKID = activeKID
+	km.hmacSecret = hmacSecret
+
+	if activeKID != "" {
+		log.Printf("[AUTH] Loaded %d JWT key(s), signing with kid=%s", len(keys), activeKID)
+	} else {
+		log.Println("[AUTH] Signing JWTs with HS256 shared secret")
+	}
+	return nil
+}
+
+// Sign はクレームに署名してトークン文字列を返す
+func (km *KeyManager) Sign(claims jwt.Claims) (string, error) {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	if km.activeKID == "" {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(km.hmacSecret)
+	}
+
+	key := km.keys[km.activeKID]
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.kid
+	return token.SignedString(key.private)
+}
+
+// Parse はトークンを検証してクレームに読み込む
+func (km *KeyManager) Parse(tokenString string, claims jwt.Claims) (*jwt.Token, error) {
+	return jwt.ParseWithClaims(tokenString, claims, km.keyFunc,
+		jwt.WithValidMethods([]string{"RS256", "EdDSA", "HS256"}))
+}
+
+func (km *KeyManager) keyFunc(token *jwt.Token) (interface{}, error) {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		if km.hmacSecret == nil {
+			return nil, errors.New("HMAC-signed tokens are not accepted")
+		}
+		return km.hmacSecret, nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := km.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if key.method.Alg() != token.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method %s for key %q", token.Method.Alg(), kid)
+	}
+	return key.public, nil
+}
+
+// ActiveKID は署名に使っている鍵のIDを返す（HS256の場合は空文字）
+func (km *KeyManager) ActiveKID() string {
+	km.mu.RLock()
+	defer km.mu.RUnlock()
+	return km.activeKID
+}
+
+func loadKeys(dir string) (map[string]*signingKey, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*signingKey, len(files))
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", file, err)
+		}
+
+		kid := strings.TrimSuffix(filepath.Base(file), ".pem")
+		key, err := parseKey(kid, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %w", file, err)
+		}
+		keys[kid] = key
+	}
+
+	return keys, nil
+}
+
+// parseKey はPEM形式の秘密鍵（PKCS#8/PKCS#1）または公開鍵（PKIX）を読み込む
+func parseKey(kid string, data []byte) (*signingKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &signingKey{kid: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", parsed)
+	}
+
+	if rsaKey, ok := key.public.(*rsa.PublicKey); ok && rsaKey.Size() < 256 {
+		return nil, errors.New("RSA keys must be at least 2048 bits")
+	}
+
+	return key, nil
+}
+
+// selectActiveKID は署名に使う鍵を選ぶ（指定がなければ名前順で最後の秘密鍵）
+func selectActiveKID(keys map[string]*signingKey, requested string) (string, error) {
+	if requested != "" {
+		key, ok := keys[requested]
+		if !ok {
+			return "", fmt.Errorf("active key %q not found", requested)
+		}
+		if key.private == nil {
+			return "", fmt.Errorf("active key %q has no private key", requested)
+		}
+		return requested, nil
+	}
+
+	kids := make([]string, 0, len(keys))
+	for kid, key := range keys {
+		if key.private != nil {
+			kids = append(kids, kid)
+		}
+	}
+	if len(kids) == 0 {
+		return "", nil
+	}
+
+	sort.Strings(kids)
+	return kids[len(kids)-1], nil
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"backend/internal/model"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyManager_RefusesDefaultSecret(t *testing.T) {
+	_, err := NewKeyManager(Config{})
+	assert.Error(t, err)
+
+	_, err = NewKeyManager(Config{HMACSecret: DefaultSecret})
+	assert.Error(t, err)
+
+	// 開発モードなら起動できる
+	km, err := NewKeyManager(Config{HMACSecret: DefaultSecret, DevMode: true})
+	require.NoError(t, err)
+	assert.Empty(t, km.ActiveKID())
+}
+
+func TestTokenService_AsymmetricKeys(t *testing.T) {
+	for _, alg := range []string{"EdDSA", "RS256"} {
+		t.Run(alg, func(t *testing.T) {
+			dir := t.TempDir()
+			kid, err := GenerateKeyFile(dir, alg)
+			require.NoError(t, err)
+
+			km, err := NewKeyManager(Config{KeysDir: dir})
+			require.NoError(t, err)
+			assert.Equal(t, kid, km.ActiveKID())
+
+			tokens := NewTokenService(km)
+			token, err := tokens.GenerateJWT(&model.User{ID: 1, Username: "alice"})
+			require.NoError(t, err)
+
+			claims, err := tokens.ValidateJWT(token)
+			require.NoError(t, err)
+			assert.Equal(t, 1, claims.UserID)
+			assert.Equal(t, "alice", claims.Username)
+
+			jwks := km.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, kid, jwks.Keys[0].Kid)
+			assert.Equal(t, alg, jwks.Keys[0].Alg)
+		})
+	}
+}
+
+func TestKeyManager_Rotation(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("ignored"), 0o600))
+
+	oldKID, err := GenerateKeyFile(dir, "EdDSA")
+	require.NoError(t, err)
+	km, err := NewKeyManager(Config{KeysDir: dir})
+	require.NoError(t, err)
+	tokens := NewTokenService(km)
+
+	oldToken, err := tokens.GenerateJWT(&model.User{ID: 1, Username: "alice"})
+	require.NoError(t, err)
+
+	// 新しい鍵を追加して再読み込みすると新しい鍵で署名し、旧鍵のトークンも引き続き検証できる
+	newKID, err := GenerateKeyFile(dir, "RS256")
+	require.NoError(t, err)
+	require.NoError(t, km.Reload())
+	assert.Equal(t, newKID, km.ActiveKID())
+	assert.Len(t, km.JWKS().Keys, 2)
+
+	newToken, err := tokens.GenerateJWT(&model.User{ID: 1, Username: "alice"})
+	require.NoError(t, err)
+	_, err = tokens.ValidateJWT(newToken)
+	assert.NoError(t, err)
+	_, err = tokens.ValidateJWT(oldToken)
+	assert.NoError(t, err)
+
+	// 旧鍵を削除すると、その鍵で署名されたトークンは無効になる
+	require.NoError(t, os.Remove(filepath.Join(dir, oldKID+".pem")))
+	require.NoError(t, km.Reload())
+	assert.Len(t, km.JWKS().Keys, 1)
+	_, err = tokens.ValidateJWT(oldToken)
+	assert.Error(t, err)
+}
+
+func TestKeyManager_HMACTokensRejectedWithoutSecret(t *testing.T) {
+	devKM, err := NewKeyManager(Config{DevMode: true})
+	require.NoError(t, err)
+	hmacToken, err := NewTokenService(devKM).GenerateJWT(&model.User{ID: 1, Username: "alice"})
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	_, err = GenerateKeyFile(dir, "EdDSA")
+	require.NoError(t, err)
+
+	// 非対称鍵のみの場合、デフォルトシークレットで署名されたトークンは受け付けない
+	km, err := NewKeyManager(Config{KeysDir: dir, DevMode: true})
+	require.NoError(t, err)
+	_, err = NewTokenService(km).ValidateJWT(hmacToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package auth
+
+import "backend/internal/model"
+
+// Permission はルートが要求する権限
+type Permission string
+
+const (
+	PermissionUsersRead  Permission = "admin:users:read"
+	PermissionUsersWrite Permission = "admin:users:write"
+	PermissionStatsRead  Permission = "admin:stats:read"
+)
+
+// rolePermissions はロールごとに付与される権限
+var rolePermissions = map[string][]Permission{
+	model.RoleUser: {},
+	model.RoleAdmin: {
+		PermissionUsersRead,
+		PermissionUsersWrite,
+		PermissionStatsRead,
+	},
+}
+
+// IsValidRole は定義済みのロールかどうかを返す
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// HasPermission はロールが権限を持つかどうかを返す
+func HasPermission(role string, permission Permission) bool {
+	for _, p := range rolePermissions[role] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package auth
+
+import (
+	"backend/internal/model"
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// トークンの用途。空文字は通常のアクセストークン
+const (
+	TokenPurposeMFAChallenge = "mfa_challenge"
+	TokenPurposeMFAEnroll    = "mfa_enroll"
+	// TokenPurposeEventStream は変更イベントの購読（SSE / WebSocket）の接続にだけ使えるチケット
+	TokenPurposeEventStream = "event_stream"
+)
+
+const (
+	issuer         = "retro-todo-api"
+	accessTokenTTL = 24 * time.Hour
+	// mfaTokenTTL はMFA用トークンの有効期限
+	mfaTokenTTL = 5 * time.Minute
+)
+
+// EventStreamTicketTTL はイベント購読用チケットの有効期限（接続するときだけ確認する）
+const EventStreamTicketTTL = time.Minute
+
+type JWTClaims struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Purpose  string `json:"purpose,omitempty"`
+	// TokenVersion がユーザーの現在の値と異なるトークンは失効扱い（強制ログアウト）
+	TokenVersion int `json:"token_version,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// TokenService はアクセストークンとMFA用トークンの発行・検証を行う
+type TokenService struct {
+	keys *KeyManager
+}
+
+func NewTokenService(keys *KeyManager) *TokenService {
+	return &TokenService{keys: keys}
+}
+
+func (s *TokenService) GenerateJWT(user *model.User) (string, error) {
+	return s.generate(user, "", accessTokenTTL)
+}
+
+// GenerateMFAToken はMFAの確認・登録用の短命トークンを生成する
+func (s *TokenService) GenerateMFAToken(user *model.User, purpose string) (string, error) {
+	return s.generate(user, purpose, mfaTokenTTL)
+}
+
+// GenerateEventStreamTicket はイベント購読の接続用の短命トークンを生成する
+// EventSource / WebSocket はヘッダーを設定できず URL に含めるため、アクセストークンの代わりに使う
+func (s *TokenService) GenerateEventStreamTicket(user *model.User) (string, error) {
+	return s.generate(user, TokenPurposeEventStream, EventStreamTicketTTL)
+}
+
+func (s *TokenService) generate(user *model.User, purpose string, ttl time.Duration) (string, error) {
+	claims := JWTClaims{
+		UserID:       user.ID,
+		Username:     user.Username,
+		Purpose:      purpose,
+		TokenVersion: user.TokenVersion,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    issuer,
+		},
+	}
+
+	return s.keys.Sign(claims)
+}
+
+func (s *TokenService) ValidateJWT(tokenString string) (*JWTClaims, error) {
+	token, err := s.keys.Parse(tokenString, &JWTClaims{})
+	if err != nil {
+		return nil, err
+	}
+
+	if claims, ok := token.Claims.(*JWTClaims); ok && token.Valid {
+		return claims, nil
+	}
+
+	return nil, errors.New("invalid token")
+}
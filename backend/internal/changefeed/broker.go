@@ -0,0 +1,303 @@
+package changefeed
+
+import (
+	"backend/internal/config"
+	"backend/internal/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// NotifyChannel はイベントに番号を振ったことを他のインスタンスに知らせる PostgreSQL の NOTIFY のチャネル
+const NotifyChannel = "change_events"
+
+// relayLockKey は番号を振る処理（relay）を直列化する pg_advisory_xact_lock のキー
+// コミットの順序と seq の順序を揃え、seq の大きいイベントが先に見えて小さいものを取りこぼすことを防ぐ
+// TODO・スプリントの書き込み（イベントの記録）はこのロックを取らない
+const relayLockKey int64 = 7245390184
+
+const (
+	// relayTimeout は1回の番号の割り当てと配信のタイムアウト
+	relayTimeout = 5 * time.Second
+	// syncBatchSize は1回のクエリで読み込むイベント数
+	syncBatchSize = 500
+	// pollInterval は NOTIFY を取りこぼした場合や、記録したインスタンスが番号を振る前に停止した場合に備えて
+	// 新しいイベントを確認する間隔
+	pollInterval = 30 * time.Second
+	// pruneInterval は保持期間を過ぎたイベントを削除する間隔
+	pruneInterval = time.Hour
+)
+
+// BrokerConfig は Broker の設定
+type BrokerConfig struct {
+	// Driver は config.DriverPostgres または config.DriverSQLite（PostgreSQL では NOTIFY で他のインスタンスに知らせる）
+	Driver string
+	// Retention を過ぎたイベントは削除する（再接続時に再送できる期間）
+	Retention time.Duration
+}
+
+// Broker は change_events テーブルに記録されたイベントに番号（seq）を振り、Hub に配信する Publisher
+// イベントは TODO・スプリントの変更と同じトランザクションで記録する（NewRepositories / NewUnitOfWork）。
+// 全てのインスタンスが同じテーブルから同じ番号のイベントを読むため、
+// どのインスタンスに再接続しても Last-Event-ID から再開できる
+type Broker struct {
+	db  *sql.DB
+	hub *Hub
+	cfg BrokerConfig
+	// mu は Hub への配信（sync）を直列化する
+	mu sync.Mutex
+	// kick は Publish から Run に番号の割り当てを依頼する
+	kick chan struct{}
+}
+
+func NewBroker(db *sql.DB, hub *Hub, cfg BrokerConfig) *Broker {
+	return &Broker{db: db, hub: hub, cfg: cfg, kick: make(chan struct{}, 1)}
+}
+
+// Start は記録済みの直近のイベントを Hub の再送用の履歴に読み込む（購読を受け付ける前に呼ぶ）
+func (b *Broker) Start(ctx context.Context) error {
+	rows, err := b.db.QueryContext(ctx,
+		"SELECT seq, kind, action, entity_id, payload FROM change_events WHERE seq IS NOT NULL ORDER BY seq DESC LIMIT $1",
+		b.hub.historySize,
+	)
+	if err != nil {
+		return err
+	}
+	events, err := scanEvents(rows)
+	if err != nil {
+		return err
+	}
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+
+	var after int64
+	if len(events) > 0 {
+		after = events[0].Seq - 1
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.hub.Restore(after, events)
+	return nil
+}
+
+// Publish はコミットしたイベントの配信を Run に依頼する（書き込みを待たせない）
+// events は変更と同じトランザクションで change_events に記録済みのため使わない
+func (b *Broker) Publish(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+	select {
+	case b.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Run は ctx が終わるまで、Publish・wake を受け取るたび（と pollInterval ごと）に記録されたイベントに番号を振って配信し、
+// 保持期間を過ぎたイベントを定期的に削除する。wake は PostgreSQL の LISTEN（Listen）で、SQLite では nil
+func (b *Broker) Run(ctx context.Context, wake <-chan struct{}) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	prune := time.NewTicker(pruneInterval)
+	defer prune.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-b.kick:
+		case <-wake:
+		case <-poll.C:
+		case <-prune.C:
+			if n, err := b.Prune(ctx, time.Now().Add(-b.cfg.Retention)); err != nil {
+				log.Printf("[CHANGEFEED] Failed to delete old change events: %v", err)
+			} else if n > 0 {
+				log.Printf("[CHANGEFEED] Deleted %d change events older than %s", n, b.cfg.Retention)
+			}
+			continue
+		}
+		if err := b.deliver(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("[CHANGEFEED] Failed to deliver change events: %v", err)
+		}
+	}
+}
+
+// deliver は記録されたイベントに番号を振り、このインスタンスの Hub に配信する
+func (b *Broker) deliver(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, relayTimeout)
+	defer cancel()
+	if err := b.relay(ctx); err != nil {
+		return err
+	}
+	return b.sync(ctx)
+}
+
+// Prune は before より前に記録し配信したイベントを削除し、削除した件数を返す
+func (b *Broker) Prune(ctx context.Context, before time.Time) (int64, error) {
+	res, err := b.db.ExecContext(ctx, "DELETE FROM change_events WHERE created_at < $1 AND seq IS NOT NULL", before.UTC())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+// relay はコミット済みで番号のないイベントに、記録した順（id の順）に番号を振る
+// PostgreSQL では他のインスタンスの relay とロックで直列化し、コミットした後に NOTIFY が届く
+func (b *Broker) relay(ctx context.Context) error {
+	tx, err := b.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// SQLite は書き込みが直列化され id の順にコミットされるため、id をそのまま使う
+	query := "UPDATE change_events SET seq = id WHERE seq IS NULL"
+	if b.cfg.Driver == config.DriverPostgres {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock($1)", relayLockKey); err != nil {
+			return err
+		}
+		query = `
+			WITH pending AS MATERIALIZED (
+				SELECT id FROM change_events WHERE seq IS NULL ORDER BY id
+			), numbered AS MATERIALIZED (
+				SELECT id, nextval('change_events_seq') AS seq FROM pending
+			)
+			UPDATE change_events SET seq = numbered.seq FROM numbered WHERE change_events.id = numbered.id
+		`
+	}
+	res, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return nil
+	}
+	if b.cfg.Driver == config.DriverPostgres {
+		if _, err := tx.ExecContext(ctx, "SELECT pg_notify($1, '')", NotifyChannel); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// sync は Hub に配信した後に記録されたイベントを読み込んで配信する
+func (b *Broker) sync(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for {
+		rows, err := b.db.QueryContext(ctx,
+			"SELECT seq, kind, action, entity_id, payload FROM change_events WHERE seq > $1 ORDER BY seq LIMIT $2",
+			b.hub.LastSeq(), syncBatchSize,
+		)
+		if err != nil {
+			return err
+		}
+		events, err := scanEvents(rows)
+		if err != nil {
+			return err
+		}
+		b.hub.Publish(events...)
+		if len(events) < syncBatchSize {
+			return nil
+		}
+	}
+}
+
+func scanEvents(rows *sql.Rows) ([]Event, error) {
+	defer rows.Close()
+	var events []Event
+	for rows.Next() {
+		var ev Event
+		var payload sql.NullString
+		if err := rows.Scan(&ev.Seq, &ev.Kind, &ev.Action, &ev.ID, &payload); err != nil {
+			return nil, err
+		}
+		if err := unmarshalPayload(&ev, payload); err != nil {
+			return nil, fmt.Errorf("change event %d: %w", ev.Seq, err)
+		}
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
+
+// marshalPayload は変更後の TODO・スプリントを JSON にする（削除では nil）
+func marshalPayload(ev Event) (*string, error) {
+	var v interface{}
+	switch {
+	case ev.Todo != nil:
+		v = ev.Todo
+	case ev.Sprint != nil:
+		v = ev.Sprint
+	default:
+		return nil, nil
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	payload := string(b)
+	return &payload, nil
+}
+
+func unmarshalPayload(ev *Event, payload sql.NullString) error {
+	if !payload.Valid {
+		return nil
+	}
+	switch ev.Kind {
+	case KindTodo:
+		ev.Todo = &model.Todo{}
+		return json.Unmarshal([]byte(payload.String), ev.Todo)
+	case KindSprint:
+		ev.Sprint = &model.Sprint{}
+		return json.Unmarshal([]byte(payload.String), ev.Sprint)
+	}
+	return nil
+}
+
+// Listen は PostgreSQL の NotifyChannel を LISTEN し、通知と再接続のたびに値を送るチャネルを返す（Run の wake に渡す）
+// 接続が切れた間の通知は届かないため、再接続したときも読み直す
+func Listen(ctx context.Context, dsn string) <-chan struct{} {
+	wake := make(chan struct{}, 1)
+	notify := func() {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+
+	listener := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventConnectionAttemptFailed, pq.ListenerEventDisconnected:
+			log.Printf("[CHANGEFEED] LISTEN connection lost: %v", err)
+		case pq.ListenerEventReconnected:
+			log.Println("[CHANGEFEED] LISTEN connection re-established")
+		}
+	})
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+	go func() {
+		if err := listener.Listen(NotifyChannel); err != nil {
+			if ctx.Err() == nil {
+				log.Printf("[CHANGEFEED] Failed to LISTEN %s: %v", NotifyChannel, err)
+			}
+			return
+		}
+		// 再接続すると nil が届く。Close するとチャネルが閉じる
+		for range listener.Notify {
+			notify()
+		}
+	}()
+	return wake
+}
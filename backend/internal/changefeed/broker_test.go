@@ -0,0 +1,149 @@
+package changefeed
+
+import (
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/storage/storagetest"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func receive(t *testing.T, sub *Subscription) Event {
+	t.Helper()
+	select {
+	case ev := <-sub.Events():
+		return ev
+	case <-time.After(time.Second):
+		t.Fatal("no event")
+		return Event{}
+	}
+}
+
+// record は変更と同じトランザクションで記録されたイベントとして change_events に書き込む（番号は振らない）
+func record(t *testing.T, db *sql.DB, events ...Event) {
+	t.Helper()
+	repos := repository.NewRepositories(db)
+	for _, ev := range events {
+		payload, err := marshalPayload(ev)
+		require.NoError(t, err)
+		require.NoError(t, repos.ChangeEvents.Record(context.Background(), ev.Kind, ev.Action, ev.ID, payload))
+	}
+}
+
+// 同じDBを使う2つのインスタンス（Broker と Hub）の間で、同じ番号のイベントが配信される
+func TestBroker_DeliversAcrossInstances(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			storagetest.Truncate(t, b.DB, "change_events")
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			cfg := BrokerConfig{Driver: b.Name, Retention: time.Hour}
+
+			hubA, hubB := NewHub(0, 0), NewHub(0, 0)
+			brokerA, brokerB := NewBroker(b.DB, hubA, cfg), NewBroker(b.DB, hubB, cfg)
+			require.NoError(t, brokerA.Start(ctx))
+			require.NoError(t, brokerB.Start(ctx))
+			wakeB := make(chan struct{})
+			go brokerA.Run(ctx, nil)
+			go brokerB.Run(ctx, wakeB)
+
+			subA, subB := hubA.Subscribe(), hubB.Subscribe()
+			defer subA.Close()
+			defer subB.Close()
+
+			sprintID := 3
+			events := []Event{
+				{Kind: KindTodo, Action: ActionCreated, ID: 7, Todo: &model.Todo{ID: 7, Title: "Todo", SprintID: &sprintID}},
+				{Kind: KindSprint, Action: ActionDeleted, ID: 3},
+			}
+			record(t, b.DB, events...)
+			brokerA.Publish(events...)
+
+			// コミットを知らせたインスタンスが番号を振って配信する（記録した順）
+			created := receive(t, subA)
+			assert.Equal(t, KindTodo, created.Kind)
+			assert.Equal(t, "Todo", created.Todo.Title)
+			assert.Equal(t, &sprintID, created.Todo.SprintID)
+			deleted := receive(t, subA)
+			assert.Equal(t, created.Seq+1, deleted.Seq)
+			assert.Nil(t, deleted.Sprint)
+
+			// 他のインスタンスには通知（LISTEN）を受けて届く
+			wakeB <- struct{}{}
+			assert.Equal(t, created, receive(t, subB))
+			assert.Equal(t, deleted, receive(t, subB))
+
+			// 再起動したインスタンスも記録から再送できる
+			hubC := NewHub(0, 0)
+			require.NoError(t, NewBroker(b.DB, hubC, cfg).Start(ctx))
+			sub, missed, ok := hubC.SubscribeFrom(created.Seq - 1)
+			defer sub.Close()
+			require.True(t, ok)
+			assert.Equal(t, []Event{created, deleted}, missed)
+		})
+	}
+}
+
+// 記録したインスタンスが番号を振る前に停止しても、他のインスタンスが配信する
+func TestBroker_DeliversUnrelayedEvents(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			storagetest.Truncate(t, b.DB, "change_events")
+			ctx := context.Background()
+			hub := NewHub(0, 0)
+			broker := NewBroker(b.DB, hub, BrokerConfig{Driver: b.Name, Retention: time.Hour})
+			require.NoError(t, broker.Start(ctx))
+			sub := hub.Subscribe()
+			defer sub.Close()
+
+			record(t, b.DB, Event{Kind: KindTodo, Action: ActionDeleted, ID: 1})
+			// Run が pollInterval ごと（または他のインスタンスの通知で）行う処理
+			require.NoError(t, broker.deliver(ctx))
+			ev := receive(t, sub)
+			assert.Equal(t, 1, ev.ID)
+
+			// 番号は一度だけ振る
+			require.NoError(t, broker.deliver(ctx))
+			total, pending := changeEventCounts(t, b.DB)
+			assert.Equal(t, 1, total)
+			assert.Zero(t, pending)
+		})
+	}
+}
+
+func TestBroker_Prune(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			storagetest.Truncate(t, b.DB, "change_events")
+			ctx := context.Background()
+			hub := NewHub(0, 0)
+			broker := NewBroker(b.DB, hub, BrokerConfig{Driver: b.Name, Retention: time.Hour})
+			require.NoError(t, broker.Start(ctx))
+
+			record(t, b.DB, Event{Kind: KindTodo, Action: ActionDeleted, ID: 1})
+			require.NoError(t, broker.deliver(ctx))
+			n, err := broker.Prune(ctx, time.Now().Add(-time.Minute))
+			require.NoError(t, err)
+			assert.Zero(t, n)
+
+			// 番号を振っていないイベントは配信するまで残す
+			record(t, b.DB, Event{Kind: KindTodo, Action: ActionDeleted, ID: 2})
+			n, err = broker.Prune(ctx, time.Now().Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), n)
+
+			// 削除した番号は再利用しない
+			sub := hub.Subscribe()
+			defer sub.Close()
+			require.NoError(t, broker.deliver(ctx))
+			ev := receive(t, sub)
+			assert.Equal(t, 2, ev.ID)
+			assert.Greater(t, ev.Seq, int64(1))
+		})
+	}
+}
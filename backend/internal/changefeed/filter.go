@@ -0,0 +1,39 @@
+package changefeed
+
+// Filter は購読者が受け取るイベントの条件（ゼロ値は全てのイベント）
+type Filter struct {
+	// Kinds を指定すると、その対象（KindTodo / KindSprint）のイベントだけを受け取る
+	Kinds []string
+	// SprintIDs を指定すると、そのスプリントと、変更後にそのスプリントに所属するTODOのイベントだけを受け取る
+	// TODOの削除は所属していたスプリントが分からないため全て受け取る
+	SprintIDs []int
+}
+
+// Match は ev が条件に一致するかを返す
+func (f Filter) Match(ev Event) bool {
+	if len(f.Kinds) > 0 && !contains(f.Kinds, ev.Kind) {
+		return false
+	}
+	if len(f.SprintIDs) == 0 {
+		return true
+	}
+	switch ev.Kind {
+	case KindSprint:
+		return contains(f.SprintIDs, ev.ID)
+	case KindTodo:
+		if ev.Todo == nil {
+			return true
+		}
+		return ev.Todo.SprintID != nil && contains(f.SprintIDs, *ev.Todo.SprintID)
+	}
+	return false
+}
+
+func contains[T comparable](values []T, v T) bool {
+	for _, x := range values {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,41 @@
+package changefeed
+
+import (
+	"backend/internal/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Match(t *testing.T) {
+	sprintID, otherID := 1, 2
+	todoInSprint := Event{Kind: KindTodo, Action: ActionUpdated, ID: 10, Todo: &model.Todo{ID: 10, SprintID: &sprintID}}
+	todoInOther := Event{Kind: KindTodo, Action: ActionUpdated, ID: 11, Todo: &model.Todo{ID: 11, SprintID: &otherID}}
+	todoWithoutSprint := Event{Kind: KindTodo, Action: ActionCreated, ID: 12, Todo: &model.Todo{ID: 12}}
+	todoDeleted := Event{Kind: KindTodo, Action: ActionDeleted, ID: 13}
+	sprint := Event{Kind: KindSprint, Action: ActionUpdated, ID: sprintID, Sprint: &model.Sprint{ID: sprintID}}
+	otherSprint := Event{Kind: KindSprint, Action: ActionDeleted, ID: otherID}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []Event
+	}{
+		{"zero value matches everything", Filter{}, []Event{todoInSprint, todoInOther, todoWithoutSprint, todoDeleted, sprint, otherSprint}},
+		{"kinds", Filter{Kinds: []string{KindSprint}}, []Event{sprint, otherSprint}},
+		{"sprints", Filter{SprintIDs: []int{sprintID}}, []Event{todoInSprint, todoDeleted, sprint}},
+		{"kinds and sprints", Filter{Kinds: []string{KindTodo}, SprintIDs: []int{otherID}}, []Event{todoInOther, todoDeleted}},
+	}
+	all := []Event{todoInSprint, todoInOther, todoWithoutSprint, todoDeleted, sprint, otherSprint}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []Event
+			for _, ev := range all {
+				if tt.filter.Match(ev) {
+					got = append(got, ev)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,229 @@
+// Package changefeed はTODO・スプリントの変更をイベントとして購読者に配信する
+//
+// 変更はリポジトリのデコレーター（NewRepositories / NewUnitOfWork）が成功した書き込みごとに
+// 同じトランザクションで change_events テーブルに記録し（アウトボックス）、コミットした後に Publisher に送る。
+//
+// Publisher は単一プロセスなら Hub、DBを使う場合は Broker（記録されたイベントに番号を振り、
+// PostgreSQL では LISTEN/NOTIFY で他のインスタンスの Hub にも配信する）。
+// イベントには通し番号（Seq）を振り、Hub が直近のイベントを保持して再接続時の再送に使う。
+package changefeed
+
+import (
+	"backend/internal/model"
+	"errors"
+	"sync"
+)
+
+// イベントの対象
+const (
+	KindTodo   = "todo"
+	KindSprint = "sprint"
+)
+
+// イベントの種類
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionDeleted = "deleted"
+)
+
+// Event は1件の変更
+type Event struct {
+	// Seq は変更の通し番号（イベントID）。Hub または Broker が振る
+	Seq    int64
+	Kind   string
+	Action string
+	// ID は変更されたTODO・スプリントのID
+	ID int
+	// Todo / Sprint は作成・更新後の値（削除では nil）
+	Todo   *model.Todo
+	Sprint *model.Sprint
+}
+
+// Publisher は変更イベントの送り先
+type Publisher interface {
+	Publish(events ...Event)
+}
+
+// DefaultBufferSize は購読者ごとに溜めておけるイベント数
+const DefaultBufferSize = 256
+
+// DefaultHistorySize は再送のために保持する直近のイベント数
+const DefaultHistorySize = 1000
+
+// ErrSlowSubscriber はイベントを受け取りきれずに購読を打ち切られたことを表す
+var ErrSlowSubscriber = errors.New("changefeed: subscriber is too slow")
+
+// Hub はイベントを全ての購読者に配信する（プロセス内）
+type Hub struct {
+	mu          sync.Mutex
+	subs        map[*Subscription]struct{}
+	bufferSize  int
+	historySize int
+	// history は直近のイベント（Seq の昇順）。floor 以前のイベントは保持していない
+	history []Event
+	floor   int64
+	lastSeq int64
+}
+
+// NewHub は購読者ごとに bufferSize 件まで溜め、直近 historySize 件を再送用に保持する Hub を返す
+// （0 以下ならそれぞれ DefaultBufferSize / DefaultHistorySize）
+func NewHub(bufferSize, historySize int) *Hub {
+	if bufferSize <= 0 {
+		bufferSize = DefaultBufferSize
+	}
+	if historySize <= 0 {
+		historySize = DefaultHistorySize
+	}
+	return &Hub{subs: map[*Subscription]struct{}{}, bufferSize: bufferSize, historySize: historySize}
+}
+
+// Publish はイベントを購読者に送る。溜まったイベントが上限に達した購読者は打ち切る（送信側を待たせない）
+// Seq が 0 のイベントには続きの番号を振る。配信済みの Seq 以下のイベントは重複として捨てる
+func (h *Hub) Publish(events ...Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	accepted := make([]Event, 0, len(events))
+	for _, ev := range events {
+		switch {
+		case ev.Seq == 0:
+			h.lastSeq++
+			ev.Seq = h.lastSeq
+		case ev.Seq <= h.lastSeq:
+			continue
+		default:
+			h.lastSeq = ev.Seq
+		}
+		accepted = append(accepted, ev)
+	}
+	if len(accepted) == 0 {
+		return
+	}
+
+	h.history = append(h.history, accepted...)
+	if over := len(h.history) - h.historySize; over > 0 {
+		h.floor = h.history[over-1].Seq
+		h.history = append(h.history[:0:0], h.history[over:]...)
+	}
+
+	for sub := range h.subs {
+		for _, ev := range accepted {
+			select {
+			case sub.events <- ev:
+			default:
+				sub.err = ErrSlowSubscriber
+				h.remove(sub)
+			}
+			if sub.err != nil {
+				break
+			}
+		}
+	}
+}
+
+// Restore は after より後のイベントを再送用の履歴として設定する（Broker が起動時に呼ぶ。購読者には送らない）
+func (h *Hub) Restore(after int64, events []Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if over := len(events) - h.historySize; over > 0 {
+		after = events[over-1].Seq
+		events = events[over:]
+	}
+	h.history = append([]Event(nil), events...)
+	h.floor = after
+	h.lastSeq = after
+	if len(events) > 0 {
+		h.lastSeq = events[len(events)-1].Seq
+	}
+}
+
+// Subscribe は以降のイベントを受け取る購読を開始する。使い終わったら Close を呼ぶ
+func (h *Hub) Subscribe() *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.subscribe()
+}
+
+// SubscribeFrom は Seq が after より後のイベントを受け取る購読を開始する
+// 保持しているイベントは missed で返し、以降のイベントは Events で受け取る（重複・欠落なし）
+// after のイベントが既に履歴から消えている（またはこのプロセスが知らない番号の）場合、ok は false で、
+// 購読は以降のイベントについて開始する。呼び出し側は状態を読み直す
+func (h *Hub) SubscribeFrom(after int64) (sub *Subscription, missed []Event, ok bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	sub = h.subscribe()
+	if after < h.floor || after > h.lastSeq {
+		return sub, nil, false
+	}
+	for i, ev := range h.history {
+		if ev.Seq > after {
+			missed = append(missed, h.history[i:]...)
+			break
+		}
+	}
+	return sub, missed, true
+}
+
+// LastSeq は最後に配信したイベントの Seq
+func (h *Hub) LastSeq() int64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.lastSeq
+}
+
+// subscribe は購読を追加する（h.mu を保持して呼ぶ）
+func (h *Hub) subscribe() *Subscription {
+	sub := &Subscription{hub: h, events: make(chan Event, h.bufferSize), since: h.lastSeq}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// Subscribers は購読者の数
+func (h *Hub) Subscribers() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs)
+}
+
+// remove は購読を外してチャネルを閉じる（h.mu を保持して呼ぶ）
+func (h *Hub) remove(sub *Subscription) {
+	if _, ok := h.subs[sub]; !ok {
+		return
+	}
+	delete(h.subs, sub)
+	close(sub.events)
+}
+
+// Subscription は1つの購読
+type Subscription struct {
+	hub    *Hub
+	events chan Event
+	since  int64
+	// err は Hub が購読を打ち切った理由（h.mu で保護）
+	err error
+}
+
+// Events はイベントを受け取るチャネル。購読が終わると閉じる
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Since は購読を開始した時点で最後に配信されていたイベントの Seq（Events はこれより後のイベント）
+func (s *Subscription) Since() int64 {
+	return s.since
+}
+
+// Err は Events が閉じた理由を返す（Close した場合は nil）
+func (s *Subscription) Err() error {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.err
+}
+
+// Close は購読を終了する
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
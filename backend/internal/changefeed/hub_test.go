@@ -0,0 +1,130 @@
+package changefeed
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHub_PublishesToAllSubscribers(t *testing.T) {
+	hub := NewHub(0, 0)
+	a := hub.Subscribe()
+	defer a.Close()
+	b := hub.Subscribe()
+	defer b.Close()
+
+	hub.Publish(Event{Kind: KindTodo, Action: ActionCreated, ID: 1}, Event{Kind: KindTodo, Action: ActionDeleted, ID: 1})
+
+	for _, sub := range []*Subscription{a, b} {
+		assert.Equal(t, ActionCreated, (<-sub.Events()).Action)
+		assert.Equal(t, ActionDeleted, (<-sub.Events()).Action)
+	}
+}
+
+func TestHub_Close(t *testing.T) {
+	hub := NewHub(0, 0)
+	sub := hub.Subscribe()
+	sub.Close()
+	sub.Close()
+
+	hub.Publish(Event{Kind: KindTodo, Action: ActionCreated, ID: 1})
+	_, ok := <-sub.Events()
+	assert.False(t, ok)
+	assert.NoError(t, sub.Err())
+}
+
+// 受け取りきれない購読者は打ち切り、他の購読者には配信を続ける
+func TestHub_DropsSlowSubscriber(t *testing.T) {
+	hub := NewHub(2, 0)
+	slow := hub.Subscribe()
+	fast := hub.Subscribe()
+	defer fast.Close()
+
+	for id := 1; id <= 3; id++ {
+		hub.Publish(Event{Kind: KindTodo, Action: ActionCreated, ID: id})
+		require.Equal(t, id, (<-fast.Events()).ID)
+	}
+
+	var received []int
+	for ev := range slow.Events() {
+		received = append(received, ev.ID)
+	}
+	assert.Equal(t, []int{1, 2}, received)
+	assert.ErrorIs(t, slow.Err(), ErrSlowSubscriber)
+}
+
+func TestHub_AssignsSequenceNumbers(t *testing.T) {
+	hub := NewHub(0, 0)
+	sub := hub.Subscribe()
+	defer sub.Close()
+
+	hub.Publish(Event{Kind: KindTodo, ID: 1}, Event{Kind: KindTodo, ID: 2})
+	assert.Equal(t, int64(1), (<-sub.Events()).Seq)
+	assert.Equal(t, int64(2), (<-sub.Events()).Seq)
+	assert.Equal(t, int64(2), hub.LastSeq())
+
+	// 番号付きのイベント（Broker が読み込んだもの）は配信済みの番号以下を重複として捨てる
+	hub.Publish(Event{Seq: 2, Kind: KindTodo, ID: 2}, Event{Seq: 5, Kind: KindTodo, ID: 3})
+	ev := <-sub.Events()
+	assert.Equal(t, int64(5), ev.Seq)
+	assert.Equal(t, 3, ev.ID)
+	assert.Empty(t, sub.Events())
+}
+
+func TestHub_SubscribeFrom(t *testing.T) {
+	hub := NewHub(0, 3)
+	for id := 1; id <= 5; id++ {
+		hub.Publish(Event{Kind: KindTodo, Action: ActionCreated, ID: id})
+	}
+
+	seqs := func(events []Event) []int64 {
+		var s []int64
+		for _, ev := range events {
+			s = append(s, ev.Seq)
+		}
+		return s
+	}
+
+	tests := []struct {
+		name       string
+		after      int64
+		wantOK     bool
+		wantMissed []int64
+	}{
+		{"within history", 3, true, []int64{4, 5}},
+		{"oldest retained", 2, true, []int64{3, 4, 5}},
+		{"up to date", 5, true, nil},
+		{"dropped from history", 1, false, nil},
+		{"unknown to this process", 9, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub, missed, ok := hub.SubscribeFrom(tt.after)
+			defer sub.Close()
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.wantMissed, seqs(missed))
+		})
+	}
+
+	// 再開後のイベントは Events で受け取る
+	sub, _, _ := hub.SubscribeFrom(5)
+	defer sub.Close()
+	hub.Publish(Event{Kind: KindTodo, Action: ActionCreated, ID: 6})
+	assert.Equal(t, int64(6), (<-sub.Events()).Seq)
+}
+
+func TestHub_Restore(t *testing.T) {
+	hub := NewHub(0, 2)
+	hub.Restore(10, []Event{{Seq: 11}, {Seq: 13}, {Seq: 14}})
+	assert.Equal(t, int64(14), hub.LastSeq())
+
+	sub, missed, ok := hub.SubscribeFrom(11)
+	defer sub.Close()
+	require.True(t, ok)
+	require.Len(t, missed, 2)
+	assert.Equal(t, int64(13), missed[0].Seq)
+
+	_, _, ok = hub.SubscribeFrom(10)
+	assert.False(t, ok, "event 11 no longer fits in the history")
+}
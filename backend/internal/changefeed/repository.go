@@ -0,0 +1,202 @@
+package changefeed
+
+import (
+	"backend/internal/model"
+	"backend/internal/repository"
+	"context"
+)
+
+// NewRepositories は TODO・スプリントへの書き込みごとに、変更と同じトランザクションでイベントを記録し
+// （repos.ChangeEvents）、コミットした後に p に送るリポジトリを返す
+// トランザクションは uow（repos と同じDBの UnitOfWork）で開始する。repos 自体は変更しない
+func NewRepositories(repos *repository.Repositories, uow repository.UnitOfWork, p Publisher) *repository.Repositories {
+	return wrap(repos, &uowWriter{uow: uow, p: p})
+}
+
+// NewUnitOfWork はトランザクション内の TODO・スプリントの変更をイベントとして同じトランザクションで記録し、
+// コミットした後にまとめて p に送る
+func NewUnitOfWork(uow repository.UnitOfWork, p Publisher) repository.UnitOfWork {
+	return &unitOfWork{uow: uow, p: p}
+}
+
+type unitOfWork struct {
+	uow repository.UnitOfWork
+	p   Publisher
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+	var pending []Event
+	err := u.uow.Do(ctx, func(repos *repository.Repositories) error {
+		// 再実行された場合は前回の試行の変更を捨てる
+		pending = nil
+		return fn(wrap(repos, &txWriter{repos: repos, events: &pending}))
+	})
+	if err != nil {
+		return err
+	}
+	u.p.Publish(pending...)
+	return nil
+}
+
+// wrap は repos の TODO・スプリントへの書き込みを w で行うリポジトリを返す
+func wrap(repos *repository.Repositories, w writer) *repository.Repositories {
+	wrapped := *repos
+	wrapped.Todos = &todoRepository{TodoRepository: repos.Todos, w: w}
+	wrapped.Sprints = &sprintRepository{SprintRepository: repos.Sprints, w: w}
+	return &wrapped
+}
+
+// writer は書き込みとイベントの記録を同じトランザクションで行う
+type writer interface {
+	// write は fn をトランザクション内で実行し、fn が返したイベントを記録する
+	write(ctx context.Context, fn func(repos *repository.Repositories) ([]Event, error)) error
+}
+
+// txWriter は既に開始したトランザクション（repos）で書き込み、記録したイベントを events に溜める
+type txWriter struct {
+	repos  *repository.Repositories
+	events *[]Event
+}
+
+func (w *txWriter) write(ctx context.Context, fn func(repos *repository.Repositories) ([]Event, error)) error {
+	events, err := fn(w.repos)
+	if err != nil {
+		return err
+	}
+	for _, ev := range events {
+		payload, err := marshalPayload(ev)
+		if err != nil {
+			return err
+		}
+		if err := w.repos.ChangeEvents.Record(ctx, ev.Kind, ev.Action, ev.ID, payload); err != nil {
+			return err
+		}
+	}
+	*w.events = append(*w.events, events...)
+	return nil
+}
+
+// uowWriter は書き込みごとにトランザクションを開始し、コミットした後にイベントを p に送る
+type uowWriter struct {
+	uow repository.UnitOfWork
+	p   Publisher
+}
+
+func (w *uowWriter) write(ctx context.Context, fn func(repos *repository.Repositories) ([]Event, error)) error {
+	var events []Event
+	err := w.uow.Do(ctx, func(repos *repository.Repositories) error {
+		events = nil
+		return (&txWriter{repos: repos, events: &events}).write(ctx, fn)
+	})
+	if err != nil {
+		return err
+	}
+	w.p.Publish(events...)
+	return nil
+}
+
+type todoRepository struct {
+	repository.TodoRepository
+	w writer
+}
+
+func (r *todoRepository) Create(ctx context.Context, title string, description string, sprintID *int) (*model.Todo, error) {
+	return r.write(ctx, ActionCreated, func(todos repository.TodoRepository) (*model.Todo, error) {
+		return todos.Create(ctx, title, description, sprintID)
+	})
+}
+
+func (r *todoRepository) Update(ctx context.Context, title string, completed bool, id int, version int) (*model.Todo, error) {
+	return r.write(ctx, ActionUpdated, func(todos repository.TodoRepository) (*model.Todo, error) {
+		return todos.Update(ctx, title, completed, id, version)
+	})
+}
+
+func (r *todoRepository) SetCompleted(ctx context.Context, id int, completed bool, version int) (*model.Todo, error) {
+	return r.write(ctx, ActionUpdated, func(todos repository.TodoRepository) (*model.Todo, error) {
+		return todos.SetCompleted(ctx, id, completed, version)
+	})
+}
+
+func (r *todoRepository) MoveToSprint(ctx context.Context, id int, sprintID *int, version int) (*model.Todo, error) {
+	return r.write(ctx, ActionUpdated, func(todos repository.TodoRepository) (*model.Todo, error) {
+		return todos.MoveToSprint(ctx, id, sprintID, version)
+	})
+}
+
+func (r *todoRepository) Delete(ctx context.Context, id int, version int) error {
+	return r.w.write(ctx, func(repos *repository.Repositories) ([]Event, error) {
+		if err := repos.Todos.Delete(ctx, id, version); err != nil {
+			return nil, err
+		}
+		return []Event{{Kind: KindTodo, Action: ActionDeleted, ID: id}}, nil
+	})
+}
+
+// write は書き込みが成功した場合に変更後のTODOをイベントとして記録する
+func (r *todoRepository) write(ctx context.Context, action string, fn func(todos repository.TodoRepository) (*model.Todo, error)) (*model.Todo, error) {
+	var result *model.Todo
+	err := r.w.write(ctx, func(repos *repository.Repositories) ([]Event, error) {
+		t, err := fn(repos.Todos)
+		if err != nil {
+			return nil, err
+		}
+		result = t
+		todo := *t
+		return []Event{{Kind: KindTodo, Action: action, ID: t.ID, Todo: &todo}}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+type sprintRepository struct {
+	repository.SprintRepository
+	w writer
+}
+
+func (r *sprintRepository) Create(ctx context.Context, name, color string, isFavorite bool) (*model.Sprint, error) {
+	return r.write(ctx, ActionCreated, func(sprints repository.SprintRepository) (*model.Sprint, error) {
+		return sprints.Create(ctx, name, color, isFavorite)
+	})
+}
+
+func (r *sprintRepository) Update(ctx context.Context, id int, name, color string, version int) (*model.Sprint, error) {
+	return r.write(ctx, ActionUpdated, func(sprints repository.SprintRepository) (*model.Sprint, error) {
+		return sprints.Update(ctx, id, name, color, version)
+	})
+}
+
+func (r *sprintRepository) UpdateFavorite(ctx context.Context, id int, isFavorite bool, version int) (*model.Sprint, error) {
+	return r.write(ctx, ActionUpdated, func(sprints repository.SprintRepository) (*model.Sprint, error) {
+		return sprints.UpdateFavorite(ctx, id, isFavorite, version)
+	})
+}
+
+func (r *sprintRepository) Delete(ctx context.Context, id int, version int) error {
+	return r.w.write(ctx, func(repos *repository.Repositories) ([]Event, error) {
+		if err := repos.Sprints.Delete(ctx, id, version); err != nil {
+			return nil, err
+		}
+		return []Event{{Kind: KindSprint, Action: ActionDeleted, ID: id}}, nil
+	})
+}
+
+// write は書き込みが成功した場合に変更後のスプリントをイベントとして記録する
+func (r *sprintRepository) write(ctx context.Context, action string, fn func(sprints repository.SprintRepository) (*model.Sprint, error)) (*model.Sprint, error) {
+	var result *model.Sprint
+	err := r.w.write(ctx, func(repos *repository.Repositories) ([]Event, error) {
+		s, err := fn(repos.Sprints)
+		if err != nil {
+			return nil, err
+		}
+		result = s
+		sprint := *s
+		return []Event{{Kind: KindSprint, Action: action, ID: s.ID, Sprint: &sprint}}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
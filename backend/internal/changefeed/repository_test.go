@@ -0,0 +1,141 @@
+package changefeed
+
+import (
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"backend/internal/storage/storagetest"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recorder は送られたイベントを記録する
+type recorder struct {
+	events []Event
+}
+
+func (r *recorder) Publish(events ...Event) {
+	r.events = append(r.events, events...)
+}
+
+func (r *recorder) actions() []string {
+	var actions []string
+	for _, ev := range r.events {
+		actions = append(actions, ev.Kind+"."+ev.Action)
+	}
+	return actions
+}
+
+func TestRepositories_PublishSuccessfulWrites(t *testing.T) {
+	ctx := context.Background()
+	rec := &recorder{}
+	base := memory.NewRepositories()
+	repos := NewRepositories(base, memory.NewUnitOfWork(base), rec)
+
+	sprint, err := repos.Sprints.Create(ctx, "Sprint", "bg-purple-500", false)
+	require.NoError(t, err)
+	todo, err := repos.Todos.Create(ctx, "Todo", "", &sprint.ID)
+	require.NoError(t, err)
+	_, err = repos.Todos.SetCompleted(ctx, todo.ID, true, repository.AnyVersion)
+	require.NoError(t, err)
+	_, err = repos.Todos.MoveToSprint(ctx, todo.ID, nil, repository.AnyVersion)
+	require.NoError(t, err)
+	_, err = repos.Sprints.UpdateFavorite(ctx, sprint.ID, true, repository.AnyVersion)
+	require.NoError(t, err)
+	require.NoError(t, repos.Todos.Delete(ctx, todo.ID, repository.AnyVersion))
+	require.NoError(t, repos.Sprints.Delete(ctx, sprint.ID, repository.AnyVersion))
+
+	// 失敗した書き込みは送らない
+	_, err = repos.Todos.Update(ctx, "Missing", false, 99, repository.AnyVersion)
+	assert.ErrorIs(t, err, repository.ErrTodoNotFound)
+	assert.ErrorIs(t, repos.Sprints.Delete(ctx, 99, repository.AnyVersion), repository.ErrSprintNotFound)
+
+	assert.Equal(t, []string{
+		"sprint.created", "todo.created", "todo.updated", "todo.updated", "sprint.updated", "todo.deleted", "sprint.deleted",
+	}, rec.actions())
+	assert.True(t, rec.events[2].Todo.Completed)
+	assert.Nil(t, rec.events[3].Todo.SprintID)
+	assert.Nil(t, rec.events[5].Todo)
+	assert.Equal(t, todo.ID, rec.events[5].ID)
+}
+
+func TestUnitOfWork_PublishesAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	rec := &recorder{}
+	uow := NewUnitOfWork(memory.NewUnitOfWork(memory.NewRepositories()), rec)
+
+	err := uow.Do(ctx, func(repos *repository.Repositories) error {
+		if _, err := repos.Todos.Create(ctx, "First", "", nil); err != nil {
+			return err
+		}
+		assert.Empty(t, rec.events, "events are held until commit")
+		_, err := repos.Todos.Create(ctx, "Second", "", nil)
+		return err
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"todo.created", "todo.created"}, rec.actions())
+
+	// ロールバックした変更は送らない
+	rec.events = nil
+	rollback := errors.New("rollback")
+	err = uow.Do(ctx, func(repos *repository.Repositories) error {
+		if _, err := repos.Todos.Create(ctx, "Third", "", nil); err != nil {
+			return err
+		}
+		return rollback
+	})
+	assert.ErrorIs(t, err, rollback)
+	assert.Empty(t, rec.events)
+}
+
+// changeEventCounts は記録されたイベントの数と、そのうち番号を振っていないものの数を返す
+func changeEventCounts(t *testing.T, db *sql.DB) (total, pending int) {
+	t.Helper()
+	require.NoError(t, db.QueryRow("SELECT COUNT(*), COUNT(*) - COUNT(seq) FROM change_events").Scan(&total, &pending))
+	return total, pending
+}
+
+// イベントは変更と同じトランザクションで記録する（変更がロールバックされれば記録も残らない）
+func TestRepositories_RecordInSameTransaction(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			storagetest.Truncate(t, b.DB, "change_events", "todos", "sprints")
+			ctx := context.Background()
+			rec := &recorder{}
+			uow := repository.NewUnitOfWork(b.DB, repository.DefaultUnitOfWorkConfig())
+			repos := NewRepositories(repository.NewRepositories(b.DB), uow, rec)
+
+			todo, err := repos.Todos.Create(ctx, "Todo", "", nil)
+			require.NoError(t, err)
+			_, err = repos.Todos.SetCompleted(ctx, todo.ID, true, todo.Version+1)
+			assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+			require.NoError(t, repos.Todos.Delete(ctx, todo.ID, repository.AnyVersion))
+
+			assert.Equal(t, []string{"todo.created", "todo.deleted"}, rec.actions())
+			total, pending := changeEventCounts(t, b.DB)
+			assert.Equal(t, 2, total)
+			assert.Equal(t, 2, pending, "seq is assigned by the broker after commit")
+
+			// ロールバックした変更のイベントは記録しない
+			rec.events = nil
+			rollback := errors.New("rollback")
+			err = NewUnitOfWork(uow, rec).Do(ctx, func(repos *repository.Repositories) error {
+				if _, err := repos.Sprints.Create(ctx, "Sprint", "bg-purple-500", false); err != nil {
+					return err
+				}
+				if _, err := repos.Todos.Create(ctx, "Todo", "", nil); err != nil {
+					return err
+				}
+				return rollback
+			})
+			assert.ErrorIs(t, err, rollback)
+			assert.Empty(t, rec.events)
+			total, _ = changeEventCounts(t, b.DB)
+			assert.Equal(t, 2, total)
+		})
+	}
+}
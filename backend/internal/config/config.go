@@ -0,0 +1,270 @@
+// Package config はアプリケーション設定を読み込む
+//
+// 優先順位（後のものが優先）:
+//  1. デフォルト値
+//  2. 設定ファイル（YAML、--config または CONFIG_FILE で指定。任意）
+//  3. 環境変数（.env ファイルがあれば読み込む。任意）
+//  4. コマンドラインフラグ
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// アプリケーション環境
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// データベースドライバ
+const (
+	DriverPostgres = "postgres"
+	// DriverSQLite は組み込みの SQLite（個人利用・オフライン用）
+	DriverSQLite = "sqlite"
+	// DriverMemory はDBを使わないデモモード（データはプロセス終了時に失われる）
+	DriverMemory = "memory"
+)
+
+// Config はアプリケーション全体の設定
+type Config struct {
+	// AppEnv は実行環境（development / test / staging / production）
+	AppEnv      string            `yaml:"app_env"`
+	Server      ServerConfig      `yaml:"server"`
+	Database    DatabaseConfig    `yaml:"database"`
+	Auth        AuthConfig        `yaml:"auth"`
+	RateLimit   RateLimitConfig   `yaml:"rate_limit"`
+	Idempotency IdempotencyConfig `yaml:"idempotency"`
+	GraphQL     GraphQLConfig     `yaml:"graphql"`
+	RPC         RPCConfig         `yaml:"rpc"`
+	Changefeed  ChangefeedConfig  `yaml:"changefeed"`
+}
+
+// ServerConfig はHTTPサーバーの設定
+type ServerConfig struct {
+	Port         int           `yaml:"port"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// LegacyRoutes はパスに /api/v1 を付けない旧ルートも提供するかどうか（移行期間中のみ。廃止予定のヘッダーを付ける）
+	LegacyRoutes bool `yaml:"legacy_routes"`
+	// TrustedProxies は X-Forwarded-For を信頼するリバースプロキシのIP・CIDR
+	// 空ならクライアントの接続元のIPを使う（ヘッダーはクライアントが偽装できるため）
+	TrustedProxies []string `yaml:"trusted_proxies"`
+}
+
+// DatabaseConfig はデータベースへの接続設定
+type DatabaseConfig struct {
+	// Driver は postgres / sqlite / memory
+	Driver string `yaml:"driver"`
+	// Path は SQLite のデータベースファイル（Driver が sqlite の場合のみ使用）
+	Path           string        `yaml:"path"`
+	Host           string        `yaml:"host"`
+	Port           int           `yaml:"port"`
+	User           string        `yaml:"user"`
+	Password       string        `yaml:"password"`
+	Name           string        `yaml:"name"`
+	SSLMode        string        `yaml:"sslmode"`
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+	// ConnectMaxWait は起動時にDBの準備ができるまで待つ最大時間
+	ConnectMaxWait time.Duration `yaml:"connect_max_wait"`
+	// RequestTimeout は1リクエストあたりのDB処理のタイムアウト
+	RequestTimeout  time.Duration `yaml:"request_timeout"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+}
+
+// AuthConfig は認証関連の設定
+type AuthConfig struct {
+	JWTSecret         string `yaml:"jwt_secret"`
+	JWTKeysDir        string `yaml:"jwt_keys_dir"`
+	JWTActiveKID      string `yaml:"jwt_active_kid"`
+	PasswordMinLength int    `yaml:"password_min_length"`
+	// PasswordRejectBreached は同梱の漏洩パスワードリストに含まれるパスワードを拒否する
+	PasswordRejectBreached bool `yaml:"password_reject_breached"`
+	// PasswordMaxUsernameSimilarity はユーザー名との類似度（0〜1）の上限。0なら確認しない
+	PasswordMaxUsernameSimilarity float64 `yaml:"password_max_username_similarity"`
+}
+
+// RateLimitConfig はレート制限の設定
+type RateLimitConfig struct {
+	// Store は memory（単一インスタンス）または postgres（複数インスタンスで共有）
+	Store string `yaml:"store"`
+}
+
+// IdempotencyConfig は Idempotency-Key の設定
+type IdempotencyConfig struct {
+	// TTL は保存したレスポンスを再送に返す期間
+	TTL time.Duration `yaml:"ttl"`
+}
+
+// GraphQLConfig は POST /graphql のクエリの上限
+type GraphQLConfig struct {
+	// MaxDepth はフィールドの入れ子の深さの上限
+	MaxDepth int `yaml:"max_depth"`
+	// MaxComplexity はフィールド数の上限（リストのフィールドの子は10件分として数える）
+	MaxComplexity int `yaml:"max_complexity"`
+}
+
+// RPCConfig は Connect RPC サーバーの設定
+type RPCConfig struct {
+	// Port は待ち受けるポート（0 なら起動しない）
+	Port int `yaml:"port"`
+}
+
+// ChangefeedConfig は変更イベントの配信（GET /events）の設定
+type ChangefeedConfig struct {
+	// History は再接続時の再送のためにメモリに保持するイベント数
+	History int `yaml:"history"`
+	// Retention はDBに記録したイベントを保持する期間（memory では使わない）
+	Retention time.Duration `yaml:"retention"`
+}
+
+// Default はデフォルト設定を返す
+func Default() Config {
+	return Config{
+		AppEnv: EnvProduction,
+		Server: ServerConfig{
+			Port:         8080,
+			ReadTimeout:  15 * time.Second,
+			WriteTimeout: 15 * time.Second,
+			IdleTimeout:  60 * time.Second,
+			LegacyRoutes: true,
+		},
+		Database: DatabaseConfig{
+			Driver:          DriverPostgres,
+			Path:            "retro_todo.db",
+			Host:            "localhost",
+			Port:            5432,
+			SSLMode:         "require",
+			ConnectTimeout:  5 * time.Second,
+			ConnectMaxWait:  30 * time.Second,
+			RequestTimeout:  10 * time.Second,
+			MaxOpenConns:    25,
+			MaxIdleConns:    10,
+			ConnMaxLifetime: 30 * time.Minute,
+			ConnMaxIdleTime: 5 * time.Minute,
+		},
+		Auth: AuthConfig{
+			PasswordMinLength:             8,
+			PasswordRejectBreached:        true,
+			PasswordMaxUsernameSimilarity: 0.7,
+		},
+		RateLimit: RateLimitConfig{
+			Store: "memory",
+		},
+		Idempotency: IdempotencyConfig{
+			TTL: 24 * time.Hour,
+		},
+		GraphQL: GraphQLConfig{
+			MaxDepth:      10,
+			MaxComplexity: 1000,
+		},
+		RPC: RPCConfig{
+			Port: 8081,
+		},
+		Changefeed: ChangefeedConfig{
+			History:   1000,
+			Retention: 24 * time.Hour,
+		},
+	}
+}
+
+// IsDevelopment は開発環境かどうか
+func (c *Config) IsDevelopment() bool {
+	return c.AppEnv == EnvDevelopment
+}
+
+// Addr はHTTPサーバーの待ち受けアドレス
+func (s ServerConfig) Addr() string {
+	return ":" + strconv.Itoa(s.Port)
+}
+
+// TrustedProxyNets は TrustedProxies を IP の範囲にする（IP だけなら /32・/128）
+func (s ServerConfig) TrustedProxyNets() ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(s.TrustedProxies))
+	for _, p := range s.TrustedProxies {
+		if ip := net.ParseIP(p); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(p)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not an IP address or CIDR", p)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// Addr はRPCサーバーの待ち受けアドレス
+func (r RPCConfig) Addr() string {
+	return ":" + strconv.Itoa(r.Port)
+}
+
+// DSN は lib/pq の接続文字列を返す
+func (d DatabaseConfig) DSN() string {
+	params := []string{
+		"host=" + dsnValue(d.Host),
+		"port=" + strconv.Itoa(d.Port),
+		"user=" + dsnValue(d.User),
+		"password=" + dsnValue(d.Password),
+		"dbname=" + dsnValue(d.Name),
+		"sslmode=" + dsnValue(d.SSLMode),
+	}
+	if d.ConnectTimeout > 0 {
+		// connect_timeout は秒単位（最小1秒）
+		secs := int((d.ConnectTimeout + time.Second - 1) / time.Second)
+		params = append(params, "connect_timeout="+strconv.Itoa(secs))
+	}
+	return strings.Join(params, " ")
+}
+
+// dsnValue は空白や引用符を含む値をクォートする
+func dsnValue(v string) string {
+	if v != "" && !strings.ContainsAny(v, ` '\`) {
+		return v
+	}
+	r := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+	return "'" + r.Replace(v) + "'"
+}
+
+// String はログ出力用に接続先を返す（パスワードは含まない）
+func (d DatabaseConfig) String() string {
+	switch d.Driver {
+	case DriverSQLite:
+		return "sqlite://" + d.Path
+	case DriverMemory:
+		return "memory://"
+	}
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.User(d.User),
+		Host:     d.Host + ":" + strconv.Itoa(d.Port),
+		Path:     "/" + d.Name,
+		RawQuery: "sslmode=" + d.SSLMode,
+	}
+	return u.String()
+}
+
+func oneOf(value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s (got %q)", strings.Join(allowed, ", "), value)
+}
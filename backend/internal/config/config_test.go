@@ -0,0 +1,217 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setBaseEnv は必須項目を設定し、他のテストの環境変数の影響を受けないようにする
+func setBaseEnv(t *testing.T) {
+	t.Helper()
+	for _, s := range settings {
+		t.Setenv(s.env, "")
+	}
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DB_USER", "app")
+	t.Setenv("DB_NAME", "retro_todo_db")
+}
+
+func TestLoad_Defaults(t *testing.T) {
+	setBaseEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, EnvProduction, cfg.AppEnv)
+	assert.Equal(t, ":8080", cfg.Server.Addr())
+	assert.Equal(t, "require", cfg.Database.SSLMode)
+	assert.Equal(t, 25, cfg.Database.MaxOpenConns)
+	assert.Equal(t, "memory", cfg.RateLimit.Store)
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	setBaseEnv(t)
+
+	file := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(file, []byte(`
+server:
+  port: 9000
+  read_timeout: 3s
+database:
+  host: file-host
+  sslmode: require
+`), 0o644))
+
+	// 環境変数は設定ファイルより優先
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("PORT", "9100")
+	t.Setenv("SERVER_LEGACY_ROUTES", "false")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	loader := BindFlags(fs)
+	// フラグは環境変数より優先
+	require.NoError(t, fs.Parse([]string{"-config", file, "-port", "9200"}))
+
+	cfg, err := loader.Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, 9200, cfg.Server.Port)
+	assert.Equal(t, 3*time.Second, cfg.Server.ReadTimeout)
+	assert.False(t, cfg.Server.LegacyRoutes)
+	assert.Equal(t, "env-host", cfg.Database.Host)
+	assert.Equal(t, "require", cfg.Database.SSLMode)
+}
+
+func TestLoad_UnknownFileKey(t *testing.T) {
+	setBaseEnv(t)
+
+	file := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(file, []byte("database:\n  hots: typo\n"), 0o644))
+	t.Setenv("CONFIG_FILE", file)
+
+	_, err := Load()
+	assert.Error(t, err)
+}
+
+func TestLoad_InvalidEnv(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("DB_MAX_OPEN_CONNS", "many")
+
+	_, err := Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_MAX_OPEN_CONNS")
+}
+
+func TestValidate_AggregatesErrors(t *testing.T) {
+	cfg := Default()
+	cfg.Database.SSLMode = "on"
+	cfg.Server.Port = 0
+	cfg.RateLimit.Store = "redis"
+	cfg.Idempotency.TTL = 0
+	cfg.GraphQL.MaxDepth = 0
+	cfg.RPC.Port = cfg.Server.Port
+	cfg.Changefeed.Retention = 0
+	cfg.Server.TrustedProxies = []string{"proxy.local"}
+	cfg.Auth.PasswordMaxUsernameSimilarity = 1.5
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	for _, field := range []string{"database.user", "database.name", "database.sslmode", "server.port", "rate_limit.store", "idempotency.ttl", "graphql.max_depth", "rpc.port", "changefeed.retention", "server.trusted_proxies", "auth.password_max_username_similarity"} {
+		assert.Contains(t, err.Error(), field)
+	}
+}
+
+func TestLoad_TrustedProxies(t *testing.T) {
+	setBaseEnv(t)
+	t.Setenv("SERVER_TRUSTED_PROXIES", "10.0.0.1, 172.16.0.0/12,")
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.1", "172.16.0.0/12"}, cfg.Server.TrustedProxies)
+
+	nets, err := cfg.Server.TrustedProxyNets()
+	require.NoError(t, err)
+	require.Len(t, nets, 2)
+	assert.Equal(t, "10.0.0.1/32", nets[0].String())
+	assert.Equal(t, "172.16.0.0/12", nets[1].String())
+}
+
+func TestLoad_PasswordPolicy(t *testing.T) {
+	setBaseEnv(t)
+
+	cfg, err := Load()
+	require.NoError(t, err)
+	assert.True(t, cfg.Auth.PasswordRejectBreached)
+	assert.Equal(t, 0.7, cfg.Auth.PasswordMaxUsernameSimilarity)
+
+	t.Setenv("PASSWORD_REJECT_BREACHED", "false")
+	t.Setenv("PASSWORD_MAX_USERNAME_SIMILARITY", "0")
+	cfg, err = Load()
+	require.NoError(t, err)
+	assert.False(t, cfg.Auth.PasswordRejectBreached)
+	assert.Zero(t, cfg.Auth.PasswordMaxUsernameSimilarity)
+
+	t.Setenv("PASSWORD_MAX_USERNAME_SIMILARITY", "high")
+	_, err = Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PASSWORD_MAX_USERNAME_SIMILARITY")
+}
+
+func TestValidate_SSLModeInProduction(t *testing.T) {
+	cfg := Default()
+	cfg.Database.User = "app"
+	cfg.Database.Name = "retro_todo_db"
+	require.NoError(t, cfg.Validate())
+
+	// 本番では平文で接続しうる sslmode を拒否する
+	for _, mode := range []string{"disable", "allow", "prefer"} {
+		cfg.Database.SSLMode = mode
+		err := cfg.Validate()
+		require.Error(t, err, mode)
+		assert.Contains(t, err.Error(), "database.sslmode")
+	}
+
+	cfg.AppEnv = EnvDevelopment
+	assert.NoError(t, cfg.Validate())
+
+	cfg.AppEnv = EnvProduction
+	cfg.Database.Driver = DriverSQLite
+	assert.NoError(t, cfg.Validate(), "sslmode is not used for sqlite")
+}
+
+func TestValidate_SQLite(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Driver = DriverSQLite
+	require.NoError(t, cfg.Validate(), "host, user and name are not required for sqlite")
+
+	cfg.Database.Path = ""
+	cfg.RateLimit.Store = "postgres"
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database.path")
+	assert.Contains(t, err.Error(), "rate_limit.store")
+}
+
+func TestValidate_Memory(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Driver = DriverMemory
+	assert.NoError(t, cfg.Validate(), "connection settings are not required for memory")
+}
+
+func TestDSN(t *testing.T) {
+	db := Default().Database
+	db.User = "app"
+	db.Password = "p@ss word's"
+	db.Name = "todo"
+	db.SSLMode = "verify-full"
+
+	assert.Equal(t,
+		`host=localhost port=5432 user=app password='p@ss word\'s' dbname=todo sslmode=verify-full connect_timeout=5`,
+		db.DSN(),
+	)
+	assert.NotContains(t, db.String(), "p@ss")
+}
+
+func TestDump_RedactsSecrets(t *testing.T) {
+	cfg := Default()
+	cfg.Database.Password = "db-secret"
+	cfg.Auth.JWTSecret = "jwt-secret"
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.Dump(&buf))
+
+	out := buf.String()
+	assert.NotContains(t, out, "db-secret")
+	assert.NotContains(t, out, "jwt-secret")
+	assert.Contains(t, out, redacted)
+	assert.Contains(t, out, "read_timeout: 15s")
+	// 元の設定は変更されない
+	assert.Equal(t, "db-secret", cfg.Database.Password)
+}
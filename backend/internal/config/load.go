@@ -0,0 +1,232 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// setting は環境変数・フラグと設定項目の対応
+type setting struct {
+	env   string
+	flag  string // 空の場合はフラグなし（シークレットはフラグで渡さない）
+	usage string
+	set   func(c *Config, v string) error
+}
+
+var settings = []setting{
+	{"APP_ENV", "env", "実行環境（development / test / staging / production）", str(func(c *Config) *string { return &c.AppEnv })},
+
+	{"PORT", "port", "HTTPサーバーのポート", integer(func(c *Config) *int { return &c.Server.Port })},
+	{"SERVER_READ_TIMEOUT", "read-timeout", "リクエスト読み込みのタイムアウト", duration(func(c *Config) *time.Duration { return &c.Server.ReadTimeout })},
+	{"SERVER_WRITE_TIMEOUT", "write-timeout", "レスポンス書き込みのタイムアウト", duration(func(c *Config) *time.Duration { return &c.Server.WriteTimeout })},
+	{"SERVER_IDLE_TIMEOUT", "idle-timeout", "Keep-Alive接続のアイドルタイムアウト", duration(func(c *Config) *time.Duration { return &c.Server.IdleTimeout })},
+	{"SERVER_TRUSTED_PROXIES", "trusted-proxies", "X-Forwarded-For を信頼するプロキシのIP・CIDR（カンマ区切り。空なら接続元のIPを使う）", list(func(c *Config) *[]string { return &c.Server.TrustedProxies })},
+	{"SERVER_LEGACY_ROUTES", "legacy-routes", "/api/v1 を付けない旧ルートも提供する（廃止予定）", boolean(func(c *Config) *bool { return &c.Server.LegacyRoutes })},
+
+	{"DB_DRIVER", "db-driver", "DBドライバ（postgres / sqlite / memory）", str(func(c *Config) *string { return &c.Database.Driver })},
+	{"DB_PATH", "db-path", "SQLite のデータベースファイル", str(func(c *Config) *string { return &c.Database.Path })},
+	{"DB_HOST", "db-host", "DBホスト", str(func(c *Config) *string { return &c.Database.Host })},
+	{"DB_PORT", "db-port", "DBポート", integer(func(c *Config) *int { return &c.Database.Port })},
+	{"DB_USER", "db-user", "DBユーザー", str(func(c *Config) *string { return &c.Database.User })},
+	{"DB_PASSWORD", "", "", str(func(c *Config) *string { return &c.Database.Password })},
+	{"DB_NAME", "db-name", "DB名", str(func(c *Config) *string { return &c.Database.Name })},
+	{"DB_SSLMODE", "db-sslmode", "SSLモード（disable / require / verify-ca / verify-full など）", str(func(c *Config) *string { return &c.Database.SSLMode })},
+	{"DB_CONNECT_TIMEOUT", "db-connect-timeout", "DB接続のタイムアウト", duration(func(c *Config) *time.Duration { return &c.Database.ConnectTimeout })},
+	{"DB_CONNECT_MAX_WAIT", "db-connect-max-wait", "起動時にDBの準備を待つ最大時間（0で待たない）", duration(func(c *Config) *time.Duration { return &c.Database.ConnectMaxWait })},
+	{"DB_REQUEST_TIMEOUT", "db-request-timeout", "1リクエストあたりのDB処理のタイムアウト", duration(func(c *Config) *time.Duration { return &c.Database.RequestTimeout })},
+	{"DB_MAX_OPEN_CONNS", "db-max-open-conns", "最大接続数", integer(func(c *Config) *int { return &c.Database.MaxOpenConns })},
+	{"DB_MAX_IDLE_CONNS", "db-max-idle-conns", "最大アイドル接続数", integer(func(c *Config) *int { return &c.Database.MaxIdleConns })},
+	{"DB_CONN_MAX_LIFETIME", "db-conn-max-lifetime", "接続の最大寿命", duration(func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime })},
+	{"DB_CONN_MAX_IDLE_TIME", "db-conn-max-idle-time", "アイドル接続を閉じるまでの時間", duration(func(c *Config) *time.Duration { return &c.Database.ConnMaxIdleTime })},
+
+	{"JWT_SECRET", "", "", str(func(c *Config) *string { return &c.Auth.JWTSecret })},
+	{"JWT_KEYS_DIR", "jwt-keys-dir", "JWT署名鍵のディレクトリ", str(func(c *Config) *string { return &c.Auth.JWTKeysDir })},
+	{"JWT_ACTIVE_KID", "jwt-active-kid", "署名に使う鍵ID", str(func(c *Config) *string { return &c.Auth.JWTActiveKID })},
+	{"PASSWORD_MIN_LENGTH", "password-min-length", "パスワードの最小文字数", integer(func(c *Config) *int { return &c.Auth.PasswordMinLength })},
+	{"PASSWORD_REJECT_BREACHED", "password-reject-breached", "漏洩パスワードリストに含まれるパスワードを拒否する", boolean(func(c *Config) *bool { return &c.Auth.PasswordRejectBreached })},
+	{"PASSWORD_MAX_USERNAME_SIMILARITY", "password-max-username-similarity", "ユーザー名との類似度（0〜1）がこの値以上のパスワードを拒否する（0で確認しない）", float(func(c *Config) *float64 { return &c.Auth.PasswordMaxUsernameSimilarity })},
+
+	{"RATE_LIMIT_STORE", "rate-limit-store", "レート制限のストア（memory / postgres）", str(func(c *Config) *string { return &c.RateLimit.Store })},
+
+	{"IDEMPOTENCY_TTL", "idempotency-ttl", "Idempotency-Key のレスポンスを保存する期間", duration(func(c *Config) *time.Duration { return &c.Idempotency.TTL })},
+
+	{"GRAPHQL_MAX_DEPTH", "graphql-max-depth", "GraphQL のクエリの深さの上限", integer(func(c *Config) *int { return &c.GraphQL.MaxDepth })},
+	{"GRAPHQL_MAX_COMPLEXITY", "graphql-max-complexity", "GraphQL のクエリの複雑度の上限", integer(func(c *Config) *int { return &c.GraphQL.MaxComplexity })},
+
+	{"RPC_PORT", "rpc-port", "Connect RPC サーバーのポート（0 なら起動しない）", integer(func(c *Config) *int { return &c.RPC.Port })},
+
+	{"CHANGEFEED_HISTORY", "changefeed-history", "再接続時の再送のためにメモリに保持する変更イベント数", integer(func(c *Config) *int { return &c.Changefeed.History })},
+	{"CHANGEFEED_RETENTION", "changefeed-retention", "DBに記録した変更イベントを保持する期間", duration(func(c *Config) *time.Duration { return &c.Changefeed.Retention })},
+}
+
+// Loader はフラグと設定ファイルから Config を組み立てる
+type Loader struct {
+	configFile string
+	flagValues map[string]string
+	flagOrder  []string
+}
+
+// BindFlags は設定用のフラグを fs に登録する
+// fs.Parse の後に Load を呼ぶ
+func BindFlags(fs *flag.FlagSet) *Loader {
+	l := &Loader{flagValues: map[string]string{}}
+	fs.StringVar(&l.configFile, "config", "", "設定ファイル（YAML）のパス（環境変数 CONFIG_FILE でも指定可）")
+	for _, s := range settings {
+		if s.flag == "" {
+			continue
+		}
+		env := s.env
+		fs.Func(s.flag, s.usage+"（環境変数 "+env+"）", func(v string) error {
+			if _, ok := l.flagValues[env]; !ok {
+				l.flagOrder = append(l.flagOrder, env)
+			}
+			l.flagValues[env] = v
+			return nil
+		})
+	}
+	return l
+}
+
+// Load はフラグなしで設定を読み込む
+func Load() (*Config, error) {
+	return (&Loader{}).Load()
+}
+
+// Load は設定を読み込み、検証する
+func (l *Loader) Load() (*Config, error) {
+	// .env は任意（コンテナでは環境変数を直接渡す）
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to load .env: %w", err)
+	}
+
+	cfg := Default()
+
+	configFile := l.configFile
+	if configFile == "" {
+		configFile = os.Getenv("CONFIG_FILE")
+	}
+	if configFile != "" {
+		if err := loadFile(&cfg, configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, s := range settings {
+		if v, ok := os.LookupEnv(s.env); ok && v != "" {
+			if err := s.set(&cfg, v); err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", s.env, err)
+			}
+		}
+	}
+
+	for _, env := range l.flagOrder {
+		s := lookupSetting(env)
+		if err := s.set(&cfg, l.flagValues[env]); err != nil {
+			return nil, fmt.Errorf("invalid -%s: %w", s.flag, err)
+		}
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func loadFile(cfg *Config, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func lookupSetting(env string) setting {
+	for _, s := range settings {
+		if s.env == env {
+			return s
+		}
+	}
+	panic("config: unknown setting " + env)
+}
+
+func str(field func(*Config) *string) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		*field(c) = v
+		return nil
+	}
+}
+
+func integer(field func(*Config) *int) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", v)
+		}
+		*field(c) = n
+		return nil
+	}
+}
+
+func duration(field func(*Config) *time.Duration) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration (e.g. 5s, 1m)", v)
+		}
+		*field(c) = d
+		return nil
+	}
+}
+
+func float(field func(*Config) *float64) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", v)
+		}
+		*field(c) = f
+		return nil
+	}
+}
+
+func boolean(field func(*Config) *bool) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean (true / false)", v)
+		}
+		*field(c) = b
+		return nil
+	}
+}
+
+// list はカンマ区切りの値（空の要素は無視する）
+func list(field func(*Config) *[]string) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		var values []string
+		for _, item := range strings.Split(v, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		*field(c) = values
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Validate は設定値を検証し、全ての問題をまとめて返す
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(name string, err error) {
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	positive := func(name string, ok bool) {
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: must be positive", name))
+		}
+	}
+
+	check("app_env", oneOf(c.AppEnv, EnvDevelopment, EnvTest, EnvStaging, EnvProduction))
+
+	if c.Server.Port < 1 || c.Server.Port > 65535 {
+		errs = append(errs, fmt.Errorf("server.port: must be between 1 and 65535 (got %d)", c.Server.Port))
+	}
+	positive("server.read_timeout", c.Server.ReadTimeout > 0)
+	positive("server.write_timeout", c.Server.WriteTimeout > 0)
+	positive("server.idle_timeout", c.Server.IdleTimeout > 0)
+	_, err := c.Server.TrustedProxyNets()
+	check("server.trusted_proxies", err)
+
+	db := c.Database
+	check("database.driver", oneOf(db.Driver, DriverPostgres, DriverSQLite, DriverMemory))
+	switch db.Driver {
+	case DriverMemory:
+		// 接続設定は不要
+	case DriverSQLite:
+		if db.Path == "" {
+			errs = append(errs, errors.New("database.path: is required for sqlite"))
+		}
+	default:
+		if db.Host == "" {
+			errs = append(errs, errors.New("database.host: is required"))
+		}
+		if db.Port < 1 || db.Port > 65535 {
+			errs = append(errs, fmt.Errorf("database.port: must be between 1 and 65535 (got %d)", db.Port))
+		}
+		if db.User == "" {
+			errs = append(errs, errors.New("database.user: is required"))
+		}
+		if db.Name == "" {
+			errs = append(errs, errors.New("database.name: is required"))
+		}
+	}
+	check("database.sslmode", oneOf(db.SSLMode, "disable", "allow", "prefer", "require", "verify-ca", "verify-full"))
+	// 本番では暗号化されない接続を許可しない（disable / allow / prefer は平文で接続しうる）
+	if c.AppEnv == EnvProduction && db.Driver == DriverPostgres {
+		check("database.sslmode", oneOf(db.SSLMode, "require", "verify-ca", "verify-full"))
+	}
+	positive("database.connect_timeout", db.ConnectTimeout > 0)
+	if db.ConnectMaxWait < 0 {
+		errs = append(errs, errors.New("database.connect_max_wait: must not be negative"))
+	}
+	positive("database.request_timeout", db.RequestTimeout > 0)
+	positive("database.max_open_conns", db.MaxOpenConns > 0)
+	if db.MaxIdleConns < 0 || db.MaxIdleConns > db.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("database.max_idle_conns: must be between 0 and max_open_conns (got %d)", db.MaxIdleConns))
+	}
+	positive("database.conn_max_lifetime", db.ConnMaxLifetime > 0)
+	positive("database.conn_max_idle_time", db.ConnMaxIdleTime > 0)
+
+	// bcrypt は72バイトまでしか扱えない
+	if c.Auth.PasswordMinLength < 1 || c.Auth.PasswordMinLength > 72 {
+		errs = append(errs, fmt.Errorf("auth.password_min_length: must be between 1 and 72 (got %d)", c.Auth.PasswordMinLength))
+	}
+	// 1 を超えると類似度の確認が無効になるため、無効にする場合は 0 を指定させる
+	if s := c.Auth.PasswordMaxUsernameSimilarity; !(s >= 0 && s <= 1) {
+		errs = append(errs, fmt.Errorf("auth.password_max_username_similarity: must be between 0 and 1 (got %g)", s))
+	}
+
+	check("rate_limit.store", oneOf(c.RateLimit.Store, "memory", "postgres"))
+	if c.RateLimit.Store == "postgres" && db.Driver != DriverPostgres {
+		errs = append(errs, errors.New("rate_limit.store: postgres requires database.driver postgres"))
+	}
+
+	positive("idempotency.ttl", c.Idempotency.TTL > 0)
+	positive("graphql.max_depth", c.GraphQL.MaxDepth > 0)
+	positive("graphql.max_complexity", c.GraphQL.MaxComplexity > 0)
+
+	if c.RPC.Port < 0 || c.RPC.Port > 65535 {
+		errs = append(errs, fmt.Errorf("rpc.port: must be between 0 and 65535 (got %d)", c.RPC.Port))
+	} else if c.RPC.Port == c.Server.Port {
+		errs = append(errs, fmt.Errorf("rpc.port: must differ from server.port (got %d)", c.RPC.Port))
+	}
+
+	positive("changefeed.history", c.Changefeed.History > 0)
+	positive("changefeed.retention", c.Changefeed.Retention > 0)
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,359 @@
+// Package contract はリクエスト・レスポンスが swag で生成したAPI仕様（docs/swagger.json）どおりかを検証する
+//
+// フロントエンドの api.d.ts は同じ仕様から生成するため、ハンドラーのテストでこの検証を通すことで
+// アノテーションと実装のずれを検出する。仕様は Swagger 2.0 のため null は x-nullable のプロパティのみ許可する。
+package contract
+
+import (
+	"backend/docs"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-openapi/spec"
+)
+
+// Spec は検証に使うAPI仕様
+type Spec struct {
+	doc    *spec.Swagger
+	routes []route
+}
+
+type route struct {
+	method   string
+	template string
+	segments []string
+	op       *spec.Operation
+}
+
+// Load は docs パッケージに生成された仕様を読み込む
+func Load() (*Spec, error) {
+	return Parse([]byte(docs.SwaggerInfo.ReadDoc()))
+}
+
+// Parse は Swagger 2.0 のJSONを読み込む
+func Parse(data []byte) (*Spec, error) {
+	doc := new(spec.Swagger)
+	if err := json.Unmarshal(data, doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if doc.Paths == nil {
+		return nil, errors.New("spec has no paths")
+	}
+
+	s := &Spec{doc: doc}
+	for template, item := range doc.Paths.Paths {
+		for method, op := range map[string]*spec.Operation{
+			http.MethodGet:    item.Get,
+			http.MethodPost:   item.Post,
+			http.MethodPut:    item.Put,
+			http.MethodPatch:  item.Patch,
+			http.MethodDelete: item.Delete,
+		} {
+			if op == nil {
+				continue
+			}
+			s.routes = append(s.routes, route{
+				method:   method,
+				template: template,
+				segments: strings.Split(strings.Trim(template, "/"), "/"),
+				op:       op,
+			})
+		}
+	}
+	// /todos/search が /todos/{id} より先に一致するように、固定のパスを優先する
+	sort.Slice(s.routes, func(i, j int) bool {
+		return strings.Count(s.routes[i].template, "{") < strings.Count(s.routes[j].template, "{")
+	})
+	return s, nil
+}
+
+// Operations は仕様に定義された全ての操作を "GET /todos/{id}" の形式で返す
+func (s *Spec) Operations() []string {
+	ops := make([]string, 0, len(s.routes))
+	for _, rt := range s.routes {
+		ops = append(ops, rt.name())
+	}
+	sort.Strings(ops)
+	return ops
+}
+
+// Operation はリクエストに一致する操作を Operations と同じ形式で返す
+func (s *Spec) Operation(r *http.Request) (string, bool) {
+	rt, _, err := s.find(r)
+	if err != nil {
+		return "", false
+	}
+	return rt.name(), true
+}
+
+// ValidateRequest はリクエストのパスパラメータとボディを仕様と照合する
+func (s *Spec) ValidateRequest(r *http.Request, body []byte) error {
+	rt, params, err := s.find(r)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	var bodyParam *spec.Parameter
+	for i := range rt.op.Parameters {
+		p := &rt.op.Parameters[i]
+		switch p.In {
+		case "path":
+			if p.Type == "integer" {
+				if _, err := strconv.Atoi(params[p.Name]); err != nil {
+					errs = append(errs, fmt.Errorf("path parameter %s: %q is not an integer", p.Name, params[p.Name]))
+				}
+			}
+		case "body":
+			bodyParam = p
+		}
+	}
+
+	switch {
+	case bodyParam == nil && len(bytes.TrimSpace(body)) > 0:
+		errs = append(errs, errors.New("request body is not documented"))
+	case bodyParam != nil && len(bytes.TrimSpace(body)) == 0:
+		if bodyParam.Required {
+			errs = append(errs, errors.New("request body is required"))
+		}
+	case bodyParam != nil:
+		errs = append(errs, s.validateJSON(bodyParam.Schema, body)...)
+	}
+	return wrap(rt, "request", errors.Join(errs...))
+}
+
+// ValidateResponse はレスポンスのステータスとボディを仕様と照合する
+func (s *Spec) ValidateResponse(r *http.Request, status int, header http.Header, body []byte) error {
+	rt, _, err := s.find(r)
+	if err != nil {
+		return err
+	}
+
+	res, ok := rt.op.Responses.StatusCodeResponses[status]
+	if !ok {
+		if rt.op.Responses.Default == nil {
+			return wrap(rt, "response", fmt.Errorf("status %d is not documented", status))
+		}
+		res = *rt.op.Responses.Default
+	}
+
+	label := fmt.Sprintf("response %d", status)
+	if res.Schema == nil {
+		if len(bytes.TrimSpace(body)) > 0 {
+			return wrap(rt, label, errors.New("response body is not documented"))
+		}
+		return nil
+	}
+	if mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type")); mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return wrap(rt, label, fmt.Errorf("content type %q is not JSON", header.Get("Content-Type")))
+	}
+	return wrap(rt, label, errors.Join(s.validateJSON(res.Schema, body)...))
+}
+
+// find はリクエストのメソッドとパスに一致する操作を探す（パスの basePath は省略可）
+func (s *Spec) find(r *http.Request) (*route, map[string]string, error) {
+	path := r.URL.Path
+	if base := strings.TrimSuffix(s.doc.BasePath, "/"); base != "" && strings.HasPrefix(path, base+"/") {
+		path = strings.TrimPrefix(path, base)
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for i := range s.routes {
+		rt := &s.routes[i]
+		if rt.method != r.Method || len(rt.segments) != len(segments) {
+			continue
+		}
+		params := map[string]string{}
+		matched := true
+		for j, seg := range rt.segments {
+			if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+				params[seg[1:len(seg)-1]] = segments[j]
+			} else if seg != segments[j] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rt, params, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("%s %s is not documented", r.Method, r.URL.Path)
+}
+
+func (rt *route) name() string {
+	return rt.method + " " + rt.template
+}
+
+func wrap(rt *route, label string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s %s: %w", rt.name(), label, err)
+}
+
+func (s *Spec) validateJSON(schema *spec.Schema, body []byte) []error {
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return []error{fmt.Errorf("invalid JSON: %w", err)}
+	}
+	return s.validate("$", schema, v)
+}
+
+// validate は値をスキーマと照合し、違反をJSONのパスとともに返す
+func (s *Spec) validate(path string, schema *spec.Schema, v interface{}) []error {
+	schema, err := s.resolve(schema)
+	if err != nil {
+		return []error{fmt.Errorf("%s: %w", path, err)}
+	}
+
+	if v == nil {
+		if nullable, _ := schema.Extensions.GetBool("x-nullable"); nullable || schema.Nullable {
+			return nil
+		}
+		return []error{fmt.Errorf("%s: null is not allowed (mark the field x-nullable)", path)}
+	}
+
+	var errs []error
+	for i := range schema.AllOf {
+		errs = append(errs, s.validate(path, &schema.AllOf[i], v)...)
+	}
+	if len(schema.Enum) > 0 && !inEnum(schema.Enum, v) {
+		errs = append(errs, fmt.Errorf("%s: %v is not one of %v", path, v, schema.Enum))
+	}
+
+	switch {
+	case schema.Type.Contains("object"):
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return append(errs, typeError(path, "object", v))
+		}
+		errs = append(errs, s.validateObject(path, schema, obj)...)
+	case schema.Type.Contains("array"):
+		arr, ok := v.([]interface{})
+		if !ok {
+			return append(errs, typeError(path, "array", v))
+		}
+		if schema.Items == nil || schema.Items.Schema == nil {
+			return append(errs, fmt.Errorf("%s: array items are not documented", path))
+		}
+		for i, item := range arr {
+			errs = append(errs, s.validate(fmt.Sprintf("%s[%d]", path, i), schema.Items.Schema, item)...)
+		}
+	case schema.Type.Contains("string"):
+		str, ok := v.(string)
+		if !ok {
+			return append(errs, typeError(path, "string", v))
+		}
+		if schema.MaxLength != nil && int64(utf8.RuneCountInString(str)) > *schema.MaxLength {
+			errs = append(errs, fmt.Errorf("%s: longer than %d characters", path, *schema.MaxLength))
+		}
+	case schema.Type.Contains("integer"):
+		n, ok := v.(json.Number)
+		if _, err := n.Int64(); !ok || err != nil {
+			return append(errs, typeError(path, "integer", v))
+		}
+	case schema.Type.Contains("number"):
+		if _, ok := v.(json.Number); !ok {
+			return append(errs, typeError(path, "number", v))
+		}
+	case schema.Type.Contains("boolean"):
+		if _, ok := v.(bool); !ok {
+			return append(errs, typeError(path, "boolean", v))
+		}
+	case len(schema.AllOf) == 0:
+		// 型のないスキーマ（interface{} など）は api.d.ts で unknown になるため許可しない
+		errs = append(errs, fmt.Errorf("%s: schema has no type", path))
+	}
+	return errs
+}
+
+func (s *Spec) validateObject(path string, schema *spec.Schema, obj map[string]interface{}) []error {
+	var errs []error
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			errs = append(errs, fmt.Errorf("%s.%s: required property is missing", path, name))
+		}
+	}
+
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := path + "." + name
+		if prop, ok := schema.Properties[name]; ok {
+			errs = append(errs, s.validate(child, &prop, obj[name])...)
+			continue
+		}
+		switch ap := schema.AdditionalProperties; {
+		case ap != nil && ap.Schema != nil:
+			errs = append(errs, s.validate(child, ap.Schema, obj[name])...)
+		case ap != nil && ap.Allows:
+			// map[string]interface{} は中身が api.d.ts に現れないため、型を定義したモデルを使う
+			errs = append(errs, fmt.Errorf("%s: free-form object is not allowed, document a model", path))
+			return errs
+		default:
+			errs = append(errs, fmt.Errorf("%s: property is not documented", child))
+		}
+	}
+	return errs
+}
+
+// resolve は #/definitions/ への $ref をたどる
+func (s *Spec) resolve(schema *spec.Schema) (*spec.Schema, error) {
+	for schema.Ref.String() != "" {
+		ref := schema.Ref.String()
+		name, ok := strings.CutPrefix(ref, "#/definitions/")
+		if !ok {
+			return nil, fmt.Errorf("unsupported $ref %s", ref)
+		}
+		def, ok := s.doc.Definitions[name]
+		if !ok {
+			return nil, fmt.Errorf("undefined $ref %s", ref)
+		}
+		schema = &def
+	}
+	return schema, nil
+}
+
+func inEnum(enum []interface{}, v interface{}) bool {
+	for _, e := range enum {
+		if fmt.Sprint(e) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func typeError(path, want string, v interface{}) error {
+	return fmt.Errorf("%s: expected %s, got %s", path, want, jsonType(v))
+}
+
+func jsonType(v interface{}) string {
+	switch v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
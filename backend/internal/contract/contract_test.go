@@ -0,0 +1,138 @@
+package contract
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSpec = `{
+  "swagger": "2.0",
+  "basePath": "/api/v1",
+  "paths": {
+    "/todos/search": {
+      "post": {"responses": {"200": {"schema": {"type": "array", "items": {"$ref": "#/definitions/Todo"}}}}}
+    },
+    "/todos/{id}": {
+      "put": {
+        "parameters": [
+          {"in": "path", "name": "id", "type": "integer", "required": true},
+          {"in": "body", "name": "todo", "required": true, "schema": {"$ref": "#/definitions/Todo"}}
+        ],
+        "responses": {
+          "200": {"schema": {"$ref": "#/definitions/Todo"}},
+          "404": {"schema": {"$ref": "#/definitions/Problem"}}
+        }
+      },
+      "delete": {"responses": {"204": {"description": "No Content"}}}
+    },
+    "/legacy": {
+      "get": {"responses": {"200": {"schema": {"type": "object", "additionalProperties": true}}}}
+    }
+  },
+  "definitions": {
+    "Todo": {
+      "type": "object",
+      "required": ["title"],
+      "properties": {
+        "id": {"type": "integer"},
+        "title": {"type": "string", "maxLength": 5},
+        "status": {"type": "string", "enum": ["open", "done"]},
+        "sprint_id": {"type": "integer", "x-nullable": true},
+        "tags": {"type": "array", "items": {"type": "string"}}
+      }
+    },
+    "Problem": {"type": "object", "properties": {"code": {"type": "string"}}}
+  }
+}`
+
+func newTestSpec(t *testing.T) *Spec {
+	s, err := Parse([]byte(testSpec))
+	require.NoError(t, err)
+	return s
+}
+
+func jsonHeader() http.Header {
+	return http.Header{"Content-Type": []string{"application/json; charset=UTF-8"}}
+}
+
+func TestLoad(t *testing.T) {
+	s, err := Load()
+	require.NoError(t, err)
+	assert.Contains(t, s.Operations(), "PUT /todos/{id}")
+}
+
+func TestOperation(t *testing.T) {
+	s := newTestSpec(t)
+
+	for path, want := range map[string]string{
+		"/todos/1":        "PUT /todos/{id}",
+		"/api/v1/todos/1": "PUT /todos/{id}",
+	} {
+		op, ok := s.Operation(httptest.NewRequest(http.MethodPut, path, nil))
+		assert.True(t, ok, path)
+		assert.Equal(t, want, op, path)
+	}
+
+	// 固定のパスをパラメータより優先する
+	op, _ := s.Operation(httptest.NewRequest(http.MethodPost, "/todos/search", nil))
+	assert.Equal(t, "POST /todos/search", op)
+
+	_, ok := s.Operation(httptest.NewRequest(http.MethodGet, "/todos/1", nil))
+	assert.False(t, ok)
+}
+
+func TestValidateRequest(t *testing.T) {
+	s := newTestSpec(t)
+	req := httptest.NewRequest(http.MethodPut, "/todos/1", nil)
+
+	assert.NoError(t, s.ValidateRequest(req, []byte(`{"title":"Todo","sprint_id":null,"tags":["a"]}`)))
+
+	err := s.ValidateRequest(req, []byte(`{"title":"Too long","status":"closed","unknown":1,"tags":[1]}`))
+	require.Error(t, err)
+	for _, want := range []string{
+		"PUT /todos/{id} request",
+		"$.title: longer than 5 characters",
+		"$.status: closed is not one of",
+		"$.unknown: property is not documented",
+		"$.tags[0]: expected string, got number",
+	} {
+		assert.Contains(t, err.Error(), want)
+	}
+
+	assert.ErrorContains(t, s.ValidateRequest(req, []byte(`{}`)), "$.title: required property is missing")
+	assert.ErrorContains(t, s.ValidateRequest(req, nil), "request body is required")
+	assert.ErrorContains(t, s.ValidateRequest(httptest.NewRequest(http.MethodPut, "/todos/abc", nil), []byte(`{"title":"a"}`)), "not an integer")
+	assert.ErrorContains(t, s.ValidateRequest(httptest.NewRequest(http.MethodDelete, "/todos/1", nil), []byte(`{"a":1}`)), "request body is not documented")
+	assert.ErrorContains(t, s.ValidateRequest(httptest.NewRequest(http.MethodGet, "/sprints", nil), nil), "GET /sprints is not documented")
+}
+
+func TestValidateResponse(t *testing.T) {
+	s := newTestSpec(t)
+	req := httptest.NewRequest(http.MethodPut, "/todos/1", strings.NewReader(`{"title":"a"}`))
+
+	assert.NoError(t, s.ValidateResponse(req, http.StatusOK, jsonHeader(), []byte(`{"id":1,"title":"Todo"}`)))
+	assert.NoError(t, s.ValidateResponse(req, http.StatusNotFound, http.Header{"Content-Type": []string{"application/problem+json"}}, []byte(`{"code":"todo_not_found"}`)))
+
+	// 仕様と異なるレスポンス
+	err := s.ValidateResponse(req, http.StatusOK, jsonHeader(), []byte(`{"rows_affected":1,"message":"updated"}`))
+	assert.ErrorContains(t, err, "$.title: required property is missing")
+	assert.ErrorContains(t, err, "$.rows_affected: property is not documented")
+
+	assert.ErrorContains(t, s.ValidateResponse(req, http.StatusOK, jsonHeader(), []byte(`{"id":null,"title":"a"}`)), "$.id: null is not allowed")
+	assert.ErrorContains(t, s.ValidateResponse(req, http.StatusOK, jsonHeader(), []byte(`{"id":1.5,"title":"a"}`)), "$.id: expected integer")
+	assert.ErrorContains(t, s.ValidateResponse(req, http.StatusConflict, jsonHeader(), nil), "status 409 is not documented")
+	assert.ErrorContains(t, s.ValidateResponse(req, http.StatusOK, http.Header{"Content-Type": []string{"text/plain"}}, []byte(`{}`)), "is not JSON")
+
+	del := httptest.NewRequest(http.MethodDelete, "/todos/1", nil)
+	assert.NoError(t, s.ValidateResponse(del, http.StatusNoContent, http.Header{}, nil))
+	assert.ErrorContains(t, s.ValidateResponse(del, http.StatusNoContent, jsonHeader(), []byte(`{}`)), "response body is not documented")
+
+	// 型のない map[string]interface{} は api.d.ts で型が付かないため許可しない
+	legacy := httptest.NewRequest(http.MethodGet, "/legacy", nil)
+	assert.ErrorContains(t, s.ValidateResponse(legacy, http.StatusOK, jsonHeader(), []byte(`{"a":1}`)), "free-form object is not allowed")
+}
@@ -0,0 +1,76 @@
+// Package dataloader はリクエスト内のキーをまとめて1回で読み込むローダー（N+1 クエリの回避）
+//
+// Load は最初のキーから wait の間に Load されたキーをまとめてバッチ関数に渡し、結果を待って返す。
+// GraphQL（gqlgen）はリストの要素のリゾルバーを並行に呼ぶため、同じ階層のキーは1回の読み込みになる。
+package dataloader
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// BatchFunc はキーをまとめて読み込む。結果にないキーはゼロ値として扱う
+type BatchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader はキーごとの結果をキャッシュするローダー（リクエストごとに作成する）
+type Loader[K comparable, V any] struct {
+	batch BatchFunc[K, V]
+	wait  time.Duration
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]*result[V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+func New[K comparable, V any](batch BatchFunc[K, V], wait time.Duration) *Loader[K, V] {
+	return &Loader[K, V]{batch: batch, wait: wait, results: make(map[K]*result[V])}
+}
+
+// Load はキーの値を返す（読み込み済みのキーはキャッシュを返す）
+// バッチ関数には、そのバッチで最初に Load したときの ctx を渡す
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	r, ok := l.results[key]
+	if !ok {
+		r = &result[V]{done: make(chan struct{})}
+		l.results[key] = r
+		if len(l.pending) == 0 {
+			time.AfterFunc(l.wait, func() { l.dispatch(ctx) })
+		}
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-r.done:
+		return r.value, r.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// dispatch は読み込み待ちのキーをまとめて読み込む
+func (l *Loader[K, V]) dispatch(ctx context.Context) {
+	l.mu.Lock()
+	keys := l.pending
+	l.pending = nil
+	l.mu.Unlock()
+
+	values, err := l.batch(ctx, keys)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, key := range keys {
+		r := l.results[key]
+		r.value, r.err = values[key], err
+		close(r.done)
+	}
+}
@@ -0,0 +1,85 @@
+package dataloader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loadAll は keys を並行に Load する（gqlgen がリストの要素を解決するのと同じ）
+func loadAll[V any](ctx context.Context, loader *Loader[int, V], keys ...int) ([]V, []error) {
+	values := make([]V, len(keys))
+	errs := make([]error, len(keys))
+	var wg sync.WaitGroup
+	for i, key := range keys {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			values[i], errs[i] = loader.Load(ctx, key)
+		}()
+	}
+	wg.Wait()
+	return values, errs
+}
+
+func TestLoader_Batches(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var calls [][]int
+	loader := New(func(ctx context.Context, keys []int) (map[int]string, error) {
+		mu.Lock()
+		calls = append(calls, keys)
+		mu.Unlock()
+		values := map[int]string{}
+		for _, k := range keys {
+			if k != 3 {
+				values[k] = string(rune('a' + k))
+			}
+		}
+		return values, nil
+	}, 20*time.Millisecond)
+
+	// wait の間に Load したキーは1回でまとめて読み込む
+	values, errs := loadAll(ctx, loader, 0, 1, 0, 3)
+	assert.Equal(t, []string{"a", "b", "a", ""}, values)
+	assert.Equal(t, []error{nil, nil, nil, nil}, errs)
+	require.Len(t, calls, 1)
+	assert.ElementsMatch(t, []int{0, 1, 3}, calls[0])
+
+	// 読み込み済みのキーはキャッシュを返し、新しいキーだけを読み込む
+	v, err := loader.Load(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "b", v)
+	v, err = loader.Load(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "c", v)
+	assert.Equal(t, []int{2}, calls[1])
+	assert.Len(t, calls, 2)
+}
+
+func TestLoader_Error(t *testing.T) {
+	errDB := errors.New("db down")
+	loader := New(func(ctx context.Context, keys []int) (map[int]int, error) {
+		return nil, errDB
+	}, time.Millisecond)
+
+	_, errs := loadAll(context.Background(), loader, 1, 2)
+	assert.ErrorIs(t, errs[0], errDB)
+	assert.ErrorIs(t, errs[1], errDB)
+}
+
+func TestLoader_Canceled(t *testing.T) {
+	loader := New(func(ctx context.Context, keys []int) (map[int]int, error) {
+		return map[int]int{1: 1}, nil
+	}, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := loader.Load(ctx, 1)
+	assert.ErrorIs(t, err, context.Canceled)
+}
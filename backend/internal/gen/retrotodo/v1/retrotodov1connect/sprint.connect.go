@@ -0,0 +1,297 @@
+// Code generated by protoc-gen-connect-go. DO NOT EDIT.
+//
+// Source: retrotodo/v1/sprint.proto
+
+package retrotodov1connect
+
+import (
+	v1 "backend/internal/gen/retrotodo/v1"
+	connect "connectrpc.com/connect"
+	context "context"
+	errors "errors"
+	http "net/http"
+	strings "strings"
+)
+
+// This is a compile-time assertion to ensure that this generated file and the connect package are
+// compatible. If you get a compiler error that this constant is not defined, this code was
+// generated with a version of connect newer than the one compiled into your binary. You can fix the
+// problem by either regenerating this code with an older version of connect or updating the connect
+// version compiled into your binary.
+const _ = connect.IsAtLeastVersion1_13_0
+
+const (
+	// SprintServiceName is the fully-qualified name of the SprintService service.
+	SprintServiceName = "retrotodo.v1.SprintService"
+)
+
+// These constants are the fully-qualified names of the RPCs defined in this package. They're
+// exposed at runtime as Spec.Procedure and as the final two segments of the HTTP route.
+//
+// Note that these are different from the fully-qualified method names used by
+// google.golang.org/protobuf/reflect/protoreflect. To convert from these constants to
+// reflection-formatted method names, remove the leading slash and convert the remaining slash to a
+// period.
+const (
+	// SprintServiceListSprintsProcedure is the fully-qualified name of the SprintService's ListSprints
+	// RPC.
+	SprintServiceListSprintsProcedure = "/retrotodo.v1.SprintService/ListSprints"
+	// SprintServiceSearchSprintsProcedure is the fully-qualified name of the SprintService's
+	// SearchSprints RPC.
+	SprintServiceSearchSprintsProcedure = "/retrotodo.v1.SprintService/SearchSprints"
+	// SprintServiceCreateSprintProcedure is the fully-qualified name of the SprintService's
+	// CreateSprint RPC.
+	SprintServiceCreateSprintProcedure = "/retrotodo.v1.SprintService/CreateSprint"
+	// SprintServiceUpdateSprintProcedure is the fully-qualified name of the SprintService's
+	// UpdateSprint RPC.
+	SprintServiceUpdateSprintProcedure = "/retrotodo.v1.SprintService/UpdateSprint"
+	// SprintServiceUpdateFavoriteProcedure is the fully-qualified name of the SprintService's
+	// UpdateFavorite RPC.
+	SprintServiceUpdateFavoriteProcedure = "/retrotodo.v1.SprintService/UpdateFavorite"
+	// SprintServiceDeleteSprintProcedure is the fully-qualified name of the SprintService's
+	// DeleteSprint RPC.
+	SprintServiceDeleteSprintProcedure = "/retrotodo.v1.SprintService/DeleteSprint"
+	// SprintServiceWatchSprintsProcedure is the fully-qualified name of the SprintService's
+	// WatchSprints RPC.
+	SprintServiceWatchSprintsProcedure = "/retrotodo.v1.SprintService/WatchSprints"
+)
+
+// SprintServiceClient is a client for the retrotodo.v1.SprintService service.
+type SprintServiceClient interface {
+	// ListSprints は GET /sprints
+	ListSprints(context.Context, *connect.Request[v1.ListSprintsRequest]) (*connect.Response[v1.ListSprintsResponse], error)
+	// SearchSprints は POST /sprints/search
+	SearchSprints(context.Context, *connect.Request[v1.SearchSprintsRequest]) (*connect.Response[v1.SearchSprintsResponse], error)
+	// CreateSprint は POST /sprints
+	CreateSprint(context.Context, *connect.Request[v1.CreateSprintRequest]) (*connect.Response[v1.CreateSprintResponse], error)
+	// UpdateSprint は PUT /sprints/{id}
+	UpdateSprint(context.Context, *connect.Request[v1.UpdateSprintRequest]) (*connect.Response[v1.UpdateSprintResponse], error)
+	// UpdateFavorite は PUT /sprints/{id}/favorite
+	UpdateFavorite(context.Context, *connect.Request[v1.UpdateFavoriteRequest]) (*connect.Response[v1.UpdateFavoriteResponse], error)
+	// DeleteSprint は DELETE /sprints/{id}
+	DeleteSprint(context.Context, *connect.Request[v1.DeleteSprintRequest]) (*connect.Response[v1.DeleteSprintResponse], error)
+	// WatchSprints は購読を開始した後のスプリントの変更を送り続ける
+	WatchSprints(context.Context, *connect.Request[v1.WatchSprintsRequest]) (*connect.ServerStreamForClient[v1.WatchSprintsResponse], error)
+}
+
+// NewSprintServiceClient constructs a client for the retrotodo.v1.SprintService service. By
+// default, it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses,
+// and sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the
+// connect.WithGRPC() or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewSprintServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) SprintServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	sprintServiceMethods := v1.File_retrotodo_v1_sprint_proto.Services().ByName("SprintService").Methods()
+	return &sprintServiceClient{
+		listSprints: connect.NewClient[v1.ListSprintsRequest, v1.ListSprintsResponse](
+			httpClient,
+			baseURL+SprintServiceListSprintsProcedure,
+			connect.WithSchema(sprintServiceMethods.ByName("ListSprints")),
+			connect.WithClientOptions(opts...),
+		),
+		searchSprints: connect.NewClient[v1.SearchSprintsRequest, v1.SearchSprintsResponse](
+			httpClient,
+			baseURL+SprintServiceSearchSprintsProcedure,
+			connect.WithSchema(sprintServiceMethods.ByName("SearchSprints")),
+			connect.WithClientOptions(opts...),
+		),
+		createSprint: connect.NewClient[v1.CreateSprintRequest, v1.CreateSprintResponse](
+			httpClient,
+			baseURL+SprintServiceCreateSprintProcedure,
+			connect.WithSchema(sprintServiceMethods.ByName("CreateSprint")),
+			connect.WithClientOptions(opts...),
+		),
+		updateSprint: connect.NewClient[v1.UpdateSprintRequest, v1.UpdateSprintResponse](
+			httpClient,
+			baseURL+SprintServiceUpdateSprintProcedure,
+			connect.WithSchema(sprintServiceMethods.ByName("UpdateSprint")),
+			connect.WithClientOptions(opts...),
+		),
+		updateFavorite: connect.NewClient[v1.UpdateFavoriteRequest, v1.UpdateFavoriteResponse](
+			httpClient,
+			baseURL+SprintServiceUpdateFavoriteProcedure,
+			connect.WithSchema(sprintServiceMethods.ByName("UpdateFavorite")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteSprint: connect.NewClient[v1.DeleteSprintRequest, v1.DeleteSprintResponse](
+			httpClient,
+			baseURL+SprintServiceDeleteSprintProcedure,
+			connect.WithSchema(sprintServiceMethods.ByName("DeleteSprint")),
+			connect.WithClientOptions(opts...),
+		),
+		watchSprints: connect.NewClient[v1.WatchSprintsRequest, v1.WatchSprintsResponse](
+			httpClient,
+			baseURL+SprintServiceWatchSprintsProcedure,
+			connect.WithSchema(sprintServiceMethods.ByName("WatchSprints")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// sprintServiceClient implements SprintServiceClient.
+type sprintServiceClient struct {
+	listSprints    *connect.Client[v1.ListSprintsRequest, v1.ListSprintsResponse]
+	searchSprints  *connect.Client[v1.SearchSprintsRequest, v1.SearchSprintsResponse]
+	createSprint   *connect.Client[v1.CreateSprintRequest, v1.CreateSprintResponse]
+	updateSprint   *connect.Client[v1.UpdateSprintRequest, v1.UpdateSprintResponse]
+	updateFavorite *connect.Client[v1.UpdateFavoriteRequest, v1.UpdateFavoriteResponse]
+	deleteSprint   *connect.Client[v1.DeleteSprintRequest, v1.DeleteSprintResponse]
+	watchSprints   *connect.Client[v1.WatchSprintsRequest, v1.WatchSprintsResponse]
+}
+
+// ListSprints calls retrotodo.v1.SprintService.ListSprints.
+func (c *sprintServiceClient) ListSprints(ctx context.Context, req *connect.Request[v1.ListSprintsRequest]) (*connect.Response[v1.ListSprintsResponse], error) {
+	return c.listSprints.CallUnary(ctx, req)
+}
+
+// SearchSprints calls retrotodo.v1.SprintService.SearchSprints.
+func (c *sprintServiceClient) SearchSprints(ctx context.Context, req *connect.Request[v1.SearchSprintsRequest]) (*connect.Response[v1.SearchSprintsResponse], error) {
+	return c.searchSprints.CallUnary(ctx, req)
+}
+
+// CreateSprint calls retrotodo.v1.SprintService.CreateSprint.
+func (c *sprintServiceClient) CreateSprint(ctx context.Context, req *connect.Request[v1.CreateSprintRequest]) (*connect.Response[v1.CreateSprintResponse], error) {
+	return c.createSprint.CallUnary(ctx, req)
+}
+
+// UpdateSprint calls retrotodo.v1.SprintService.UpdateSprint.
+func (c *sprintServiceClient) UpdateSprint(ctx context.Context, req *connect.Request[v1.UpdateSprintRequest]) (*connect.Response[v1.UpdateSprintResponse], error) {
+	return c.updateSprint.CallUnary(ctx, req)
+}
+
+// UpdateFavorite calls retrotodo.v1.SprintService.UpdateFavorite.
+func (c *sprintServiceClient) UpdateFavorite(ctx context.Context, req *connect.Request[v1.UpdateFavoriteRequest]) (*connect.Response[v1.UpdateFavoriteResponse], error) {
+	return c.updateFavorite.CallUnary(ctx, req)
+}
+
+// DeleteSprint calls retrotodo.v1.SprintService.DeleteSprint.
+func (c *sprintServiceClient) DeleteSprint(ctx context.Context, req *connect.Request[v1.DeleteSprintRequest]) (*connect.Response[v1.DeleteSprintResponse], error) {
+	return c.deleteSprint.CallUnary(ctx, req)
+}
+
+// WatchSprints calls retrotodo.v1.SprintService.WatchSprints.
+func (c *sprintServiceClient) WatchSprints(ctx context.Context, req *connect.Request[v1.WatchSprintsRequest]) (*connect.ServerStreamForClient[v1.WatchSprintsResponse], error) {
+	return c.watchSprints.CallServerStream(ctx, req)
+}
+
+// SprintServiceHandler is an implementation of the retrotodo.v1.SprintService service.
+type SprintServiceHandler interface {
+	// ListSprints は GET /sprints
+	ListSprints(context.Context, *connect.Request[v1.ListSprintsRequest]) (*connect.Response[v1.ListSprintsResponse], error)
+	// SearchSprints は POST /sprints/search
+	SearchSprints(context.Context, *connect.Request[v1.SearchSprintsRequest]) (*connect.Response[v1.SearchSprintsResponse], error)
+	// CreateSprint は POST /sprints
+	CreateSprint(context.Context, *connect.Request[v1.CreateSprintRequest]) (*connect.Response[v1.CreateSprintResponse], error)
+	// UpdateSprint は PUT /sprints/{id}
+	UpdateSprint(context.Context, *connect.Request[v1.UpdateSprintRequest]) (*connect.Response[v1.UpdateSprintResponse], error)
+	// UpdateFavorite は PUT /sprints/{id}/favorite
+	UpdateFavorite(context.Context, *connect.Request[v1.UpdateFavoriteRequest]) (*connect.Response[v1.UpdateFavoriteResponse], error)
+	// DeleteSprint は DELETE /sprints/{id}
+	DeleteSprint(context.Context, *connect.Request[v1.DeleteSprintRequest]) (*connect.Response[v1.DeleteSprintResponse], error)
+	// WatchSprints は購読を開始した後のスプリントの変更を送り続ける
+	WatchSprints(context.Context, *connect.Request[v1.WatchSprintsRequest], *connect.ServerStream[v1.WatchSprintsResponse]) error
+}
+
+// NewSprintServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewSprintServiceHandler(svc SprintServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	sprintServiceMethods := v1.File_retrotodo_v1_sprint_proto.Services().ByName("SprintService").Methods()
+	sprintServiceListSprintsHandler := connect.NewUnaryHandler(
+		SprintServiceListSprintsProcedure,
+		svc.ListSprints,
+		connect.WithSchema(sprintServiceMethods.ByName("ListSprints")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sprintServiceSearchSprintsHandler := connect.NewUnaryHandler(
+		SprintServiceSearchSprintsProcedure,
+		svc.SearchSprints,
+		connect.WithSchema(sprintServiceMethods.ByName("SearchSprints")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sprintServiceCreateSprintHandler := connect.NewUnaryHandler(
+		SprintServiceCreateSprintProcedure,
+		svc.CreateSprint,
+		connect.WithSchema(sprintServiceMethods.ByName("CreateSprint")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sprintServiceUpdateSprintHandler := connect.NewUnaryHandler(
+		SprintServiceUpdateSprintProcedure,
+		svc.UpdateSprint,
+		connect.WithSchema(sprintServiceMethods.ByName("UpdateSprint")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sprintServiceUpdateFavoriteHandler := connect.NewUnaryHandler(
+		SprintServiceUpdateFavoriteProcedure,
+		svc.UpdateFavorite,
+		connect.WithSchema(sprintServiceMethods.ByName("UpdateFavorite")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sprintServiceDeleteSprintHandler := connect.NewUnaryHandler(
+		SprintServiceDeleteSprintProcedure,
+		svc.DeleteSprint,
+		connect.WithSchema(sprintServiceMethods.ByName("DeleteSprint")),
+		connect.WithHandlerOptions(opts...),
+	)
+	sprintServiceWatchSprintsHandler := connect.NewServerStreamHandler(
+		SprintServiceWatchSprintsProcedure,
+		svc.WatchSprints,
+		connect.WithSchema(sprintServiceMethods.ByName("WatchSprints")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/retrotodo.v1.SprintService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case SprintServiceListSprintsProcedure:
+			sprintServiceListSprintsHandler.ServeHTTP(w, r)
+		case SprintServiceSearchSprintsProcedure:
+			sprintServiceSearchSprintsHandler.ServeHTTP(w, r)
+		case SprintServiceCreateSprintProcedure:
+			sprintServiceCreateSprintHandler.ServeHTTP(w, r)
+		case SprintServiceUpdateSprintProcedure:
+			sprintServiceUpdateSprintHandler.ServeHTTP(w, r)
+		case SprintServiceUpdateFavoriteProcedure:
+			sprintServiceUpdateFavoriteHandler.ServeHTTP(w, r)
+		case SprintServiceDeleteSprintProcedure:
+			sprintServiceDeleteSprintHandler.ServeHTTP(w, r)
+		case SprintServiceWatchSprintsProcedure:
+			sprintServiceWatchSprintsHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedSprintServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedSprintServiceHandler struct{}
+
+func (UnimplementedSprintServiceHandler) ListSprints(context.Context, *connect.Request[v1.ListSprintsRequest]) (*connect.Response[v1.ListSprintsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("retrotodo.v1.SprintService.ListSprints is not implemented"))
+}
+
+func (UnimplementedSprintServiceHandler) SearchSprints(context.Context, *connect.Request[v1.SearchSprintsRequest]) (*connect.Response[v1.SearchSprintsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("retrotodo.v1.SprintService.SearchSprints is not implemented"))
+}
+
+func (UnimplementedSprintServiceHandler) CreateSprint(context.Context, *connect.Request[v1.CreateSprintRequest]) (*connect.Response[v1.CreateSprintResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("retrotodo.v1.SprintService.CreateSprint is not implemented"))
+}
+
+func (UnimplementedSprintServiceHandler) UpdateSprint(context.Context, *connect.Request[v1.UpdateSprintRequest]) (*connect.Response[v1.UpdateSprintResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("retrotodo.v1.SprintService.UpdateSprint is not implemented"))
+}
+
+func (UnimplementedSprintServiceHandler) UpdateFavorite(context.Context, *connect.Request[v1.UpdateFavoriteRequest]) (*connect.Response[v1.UpdateFavoriteResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("retrotodo.v1.SprintService.UpdateFavorite is not implemented"))
+}
+
+func (UnimplementedSprintServiceHandler) DeleteSprint(context.Context, *connect.Request[v1.DeleteSprintRequest]) (*connect.Response[v1.DeleteSprintResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("retrotodo.v1.SprintService.DeleteSprint is not implemented"))
+}
+
+func (UnimplementedSprintServiceHandler) WatchSprints(context.Context, *connect.Request[v1.WatchSprintsRequest], *connect.ServerStream[v1.WatchSprintsResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("retrotodo.v1.SprintService.WatchSprints is not implemented"))
+}
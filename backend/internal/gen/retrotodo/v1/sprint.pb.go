@@ -0,0 +1,959 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        (unknown)
+// source: retrotodo/v1/sprint.proto
+
+package retrotodov1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Sprint struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// Tailwind の背景色クラス
+	Color      string `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	IsFavorite bool   `protobuf:"varint,4,opt,name=is_favorite,json=isFavorite,proto3" json:"is_favorite,omitempty"`
+	// 更新のたびに増える行バージョン
+	Version       int32                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sprint) Reset() {
+	*x = Sprint{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sprint) ProtoMessage() {}
+
+func (x *Sprint) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sprint.ProtoReflect.Descriptor instead.
+func (*Sprint) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Sprint) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Sprint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Sprint) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *Sprint) GetIsFavorite() bool {
+	if x != nil {
+		return x.IsFavorite
+	}
+	return false
+}
+
+func (x *Sprint) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Sprint) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Sprint) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ListSprintsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSprintsRequest) Reset() {
+	*x = ListSprintsRequest{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSprintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSprintsRequest) ProtoMessage() {}
+
+func (x *ListSprintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSprintsRequest.ProtoReflect.Descriptor instead.
+func (*ListSprintsRequest) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{1}
+}
+
+type ListSprintsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprints       []*Sprint              `protobuf:"bytes,1,rep,name=sprints,proto3" json:"sprints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSprintsResponse) Reset() {
+	*x = ListSprintsResponse{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSprintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSprintsResponse) ProtoMessage() {}
+
+func (x *ListSprintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSprintsResponse.ProtoReflect.Descriptor instead.
+func (*ListSprintsResponse) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListSprintsResponse) GetSprints() []*Sprint {
+	if x != nil {
+		return x.Sprints
+	}
+	return nil
+}
+
+// 省略した条件では絞り込まない
+type SearchSprintsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          *string                `protobuf:"bytes,1,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	IsFavorite    *bool                  `protobuf:"varint,2,opt,name=is_favorite,json=isFavorite,proto3,oneof" json:"is_favorite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSprintsRequest) Reset() {
+	*x = SearchSprintsRequest{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSprintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSprintsRequest) ProtoMessage() {}
+
+func (x *SearchSprintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSprintsRequest.ProtoReflect.Descriptor instead.
+func (*SearchSprintsRequest) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SearchSprintsRequest) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *SearchSprintsRequest) GetIsFavorite() bool {
+	if x != nil && x.IsFavorite != nil {
+		return *x.IsFavorite
+	}
+	return false
+}
+
+type SearchSprintsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprints       []*Sprint              `protobuf:"bytes,1,rep,name=sprints,proto3" json:"sprints,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchSprintsResponse) Reset() {
+	*x = SearchSprintsResponse{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchSprintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchSprintsResponse) ProtoMessage() {}
+
+func (x *SearchSprintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchSprintsResponse.ProtoReflect.Descriptor instead.
+func (*SearchSprintsResponse) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SearchSprintsResponse) GetSprints() []*Sprint {
+	if x != nil {
+		return x.Sprints
+	}
+	return nil
+}
+
+type CreateSprintRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// 省略時は bg-purple-500
+	Color         string `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	IsFavorite    bool   `protobuf:"varint,3,opt,name=is_favorite,json=isFavorite,proto3" json:"is_favorite,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSprintRequest) Reset() {
+	*x = CreateSprintRequest{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSprintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSprintRequest) ProtoMessage() {}
+
+func (x *CreateSprintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSprintRequest.ProtoReflect.Descriptor instead.
+func (*CreateSprintRequest) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateSprintRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSprintRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *CreateSprintRequest) GetIsFavorite() bool {
+	if x != nil {
+		return x.IsFavorite
+	}
+	return false
+}
+
+type CreateSprintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprint        *Sprint                `protobuf:"bytes,1,opt,name=sprint,proto3" json:"sprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSprintResponse) Reset() {
+	*x = CreateSprintResponse{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSprintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSprintResponse) ProtoMessage() {}
+
+func (x *CreateSprintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSprintResponse.ProtoReflect.Descriptor instead.
+func (*CreateSprintResponse) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateSprintResponse) GetSprint() *Sprint {
+	if x != nil {
+		return x.Sprint
+	}
+	return nil
+}
+
+type UpdateSprintRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Color string                 `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	// 0 以外なら現在のバージョンと一致する場合のみ更新する（REST の If-Match）
+	Version       int32 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSprintRequest) Reset() {
+	*x = UpdateSprintRequest{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSprintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSprintRequest) ProtoMessage() {}
+
+func (x *UpdateSprintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSprintRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSprintRequest) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateSprintRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateSprintRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *UpdateSprintRequest) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *UpdateSprintRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateSprintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprint        *Sprint                `protobuf:"bytes,1,opt,name=sprint,proto3" json:"sprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSprintResponse) Reset() {
+	*x = UpdateSprintResponse{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSprintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSprintResponse) ProtoMessage() {}
+
+func (x *UpdateSprintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSprintResponse.ProtoReflect.Descriptor instead.
+func (*UpdateSprintResponse) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateSprintResponse) GetSprint() *Sprint {
+	if x != nil {
+		return x.Sprint
+	}
+	return nil
+}
+
+type UpdateFavoriteRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	IsFavorite bool                   `protobuf:"varint,2,opt,name=is_favorite,json=isFavorite,proto3" json:"is_favorite,omitempty"`
+	// 0 以外なら現在のバージョンと一致する場合のみ更新する（REST の If-Match）
+	Version       int32 `protobuf:"varint,3,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFavoriteRequest) Reset() {
+	*x = UpdateFavoriteRequest{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFavoriteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFavoriteRequest) ProtoMessage() {}
+
+func (x *UpdateFavoriteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFavoriteRequest.ProtoReflect.Descriptor instead.
+func (*UpdateFavoriteRequest) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UpdateFavoriteRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateFavoriteRequest) GetIsFavorite() bool {
+	if x != nil {
+		return x.IsFavorite
+	}
+	return false
+}
+
+func (x *UpdateFavoriteRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateFavoriteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sprint        *Sprint                `protobuf:"bytes,1,opt,name=sprint,proto3" json:"sprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateFavoriteResponse) Reset() {
+	*x = UpdateFavoriteResponse{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateFavoriteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateFavoriteResponse) ProtoMessage() {}
+
+func (x *UpdateFavoriteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateFavoriteResponse.ProtoReflect.Descriptor instead.
+func (*UpdateFavoriteResponse) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateFavoriteResponse) GetSprint() *Sprint {
+	if x != nil {
+		return x.Sprint
+	}
+	return nil
+}
+
+type DeleteSprintRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// 0 以外なら現在のバージョンと一致する場合のみ削除する（REST の If-Match）
+	Version       int32 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSprintRequest) Reset() {
+	*x = DeleteSprintRequest{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSprintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSprintRequest) ProtoMessage() {}
+
+func (x *DeleteSprintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSprintRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSprintRequest) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DeleteSprintRequest) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *DeleteSprintRequest) GetVersion() int32 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteSprintResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSprintResponse) Reset() {
+	*x = DeleteSprintResponse{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSprintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSprintResponse) ProtoMessage() {}
+
+func (x *DeleteSprintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSprintResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSprintResponse) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{12}
+}
+
+type WatchSprintsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSprintsRequest) Reset() {
+	*x = WatchSprintsRequest{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSprintsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSprintsRequest) ProtoMessage() {}
+
+func (x *WatchSprintsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSprintsRequest.ProtoReflect.Descriptor instead.
+func (*WatchSprintsRequest) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{13}
+}
+
+type WatchSprintsResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Type     ChangeType             `protobuf:"varint,1,opt,name=type,proto3,enum=retrotodo.v1.ChangeType" json:"type,omitempty"`
+	SprintId int32                  `protobuf:"varint,2,opt,name=sprint_id,json=sprintId,proto3" json:"sprint_id,omitempty"`
+	// 作成・更新後のスプリント（削除では省略）
+	Sprint        *Sprint `protobuf:"bytes,3,opt,name=sprint,proto3" json:"sprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchSprintsResponse) Reset() {
+	*x = WatchSprintsResponse{}
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchSprintsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchSprintsResponse) ProtoMessage() {}
+
+func (x *WatchSprintsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_retrotodo_v1_sprint_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchSprintsResponse.ProtoReflect.Descriptor instead.
+func (*WatchSprintsResponse) Descriptor() ([]byte, []int) {
+	return file_retrotodo_v1_sprint_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *WatchSprintsResponse) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *WatchSprintsResponse) GetSprintId() int32 {
+	if x != nil {
+		return x.SprintId
+	}
+	return 0
+}
+
+func (x *WatchSprintsResponse) GetSprint() *Sprint {
+	if x != nil {
+		return x.Sprint
+	}
+	return nil
+}
+
+var File_retrotodo_v1_sprint_proto protoreflect.FileDescriptor
+
+const file_retrotodo_v1_sprint_proto_rawDesc = "" +
+	"\n" +
+	"\x19retrotodo/v1/sprint.proto\x12\fretrotodo.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x17retrotodo/v1/todo.proto\"\xf3\x01\n" +
+	"\x06Sprint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12\x1f\n" +
+	"\vis_favorite\x18\x04 \x01(\bR\n" +
+	"isFavorite\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x05R\aversion\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x14\n" +
+	"\x12ListSprintsRequest\"E\n" +
+	"\x13ListSprintsResponse\x12.\n" +
+	"\asprints\x18\x01 \x03(\v2\x14.retrotodo.v1.SprintR\asprints\"n\n" +
+	"\x14SearchSprintsRequest\x12\x17\n" +
+	"\x04name\x18\x01 \x01(\tH\x00R\x04name\x88\x01\x01\x12$\n" +
+	"\vis_favorite\x18\x02 \x01(\bH\x01R\n" +
+	"isFavorite\x88\x01\x01B\a\n" +
+	"\x05_nameB\x0e\n" +
+	"\f_is_favorite\"G\n" +
+	"\x15SearchSprintsResponse\x12.\n" +
+	"\asprints\x18\x01 \x03(\v2\x14.retrotodo.v1.SprintR\asprints\"`\n" +
+	"\x13CreateSprintRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\x12\x1f\n" +
+	"\vis_favorite\x18\x03 \x01(\bR\n" +
+	"isFavorite\"D\n" +
+	"\x14CreateSprintResponse\x12,\n" +
+	"\x06sprint\x18\x01 \x01(\v2\x14.retrotodo.v1.SprintR\x06sprint\"i\n" +
+	"\x13UpdateSprintRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x05R\aversion\"D\n" +
+	"\x14UpdateSprintResponse\x12,\n" +
+	"\x06sprint\x18\x01 \x01(\v2\x14.retrotodo.v1.SprintR\x06sprint\"b\n" +
+	"\x15UpdateFavoriteRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x1f\n" +
+	"\vis_favorite\x18\x02 \x01(\bR\n" +
+	"isFavorite\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\x05R\aversion\"F\n" +
+	"\x16UpdateFavoriteResponse\x12,\n" +
+	"\x06sprint\x18\x01 \x01(\v2\x14.retrotodo.v1.SprintR\x06sprint\"?\n" +
+	"\x13DeleteSprintRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x05R\aversion\"\x16\n" +
+	"\x14DeleteSprintResponse\"\x15\n" +
+	"\x13WatchSprintsRequest\"\x8f\x01\n" +
+	"\x14WatchSprintsResponse\x12,\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x18.retrotodo.v1.ChangeTypeR\x04type\x12\x1b\n" +
+	"\tsprint_id\x18\x02 \x01(\x05R\bsprintId\x12,\n" +
+	"\x06sprint\x18\x03 \x01(\v2\x14.retrotodo.v1.SprintR\x06sprint2\xf8\x04\n" +
+	"\rSprintService\x12R\n" +
+	"\vListSprints\x12 .retrotodo.v1.ListSprintsRequest\x1a!.retrotodo.v1.ListSprintsResponse\x12X\n" +
+	"\rSearchSprints\x12\".retrotodo.v1.SearchSprintsRequest\x1a#.retrotodo.v1.SearchSprintsResponse\x12U\n" +
+	"\fCreateSprint\x12!.retrotodo.v1.CreateSprintRequest\x1a\".retrotodo.v1.CreateSprintResponse\x12U\n" +
+	"\fUpdateSprint\x12!.retrotodo.v1.UpdateSprintRequest\x1a\".retrotodo.v1.UpdateSprintResponse\x12[\n" +
+	"\x0eUpdateFavorite\x12#.retrotodo.v1.UpdateFavoriteRequest\x1a$.retrotodo.v1.UpdateFavoriteResponse\x12U\n" +
+	"\fDeleteSprint\x12!.retrotodo.v1.DeleteSprintRequest\x1a\".retrotodo.v1.DeleteSprintResponse\x12W\n" +
+	"\fWatchSprints\x12!.retrotodo.v1.WatchSprintsRequest\x1a\".retrotodo.v1.WatchSprintsResponse0\x01B\x9f\x01\n" +
+	"\x10com.retrotodo.v1B\vSprintProtoP\x01Z-backend/internal/gen/retrotodo/v1;retrotodov1\xa2\x02\x03RXX\xaa\x02\fRetrotodo.V1\xca\x02\fRetrotodo\\V1\xe2\x02\x18Retrotodo\\V1\\GPBMetadata\xea\x02\rRetrotodo::V1b\x06proto3"
+
+var (
+	file_retrotodo_v1_sprint_proto_rawDescOnce sync.Once
+	file_retrotodo_v1_sprint_proto_rawDescData []byte
+)
+
+func file_retrotodo_v1_sprint_proto_rawDescGZIP() []byte {
+	file_retrotodo_v1_sprint_proto_rawDescOnce.Do(func() {
+		file_retrotodo_v1_sprint_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_retrotodo_v1_sprint_proto_rawDesc), len(file_retrotodo_v1_sprint_proto_rawDesc)))
+	})
+	return file_retrotodo_v1_sprint_proto_rawDescData
+}
+
+var file_retrotodo_v1_sprint_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_retrotodo_v1_sprint_proto_goTypes = []any{
+	(*Sprint)(nil),                 // 0: retrotodo.v1.Sprint
+	(*ListSprintsRequest)(nil),     // 1: retrotodo.v1.ListSprintsRequest
+	(*ListSprintsResponse)(nil),    // 2: retrotodo.v1.ListSprintsResponse
+	(*SearchSprintsRequest)(nil),   // 3: retrotodo.v1.SearchSprintsRequest
+	(*SearchSprintsResponse)(nil),  // 4: retrotodo.v1.SearchSprintsResponse
+	(*CreateSprintRequest)(nil),    // 5: retrotodo.v1.CreateSprintRequest
+	(*CreateSprintResponse)(nil),   // 6: retrotodo.v1.CreateSprintResponse
+	(*UpdateSprintRequest)(nil),    // 7: retrotodo.v1.UpdateSprintRequest
+	(*UpdateSprintResponse)(nil),   // 8: retrotodo.v1.UpdateSprintResponse
+	(*UpdateFavoriteRequest)(nil),  // 9: retrotodo.v1.UpdateFavoriteRequest
+	(*UpdateFavoriteResponse)(nil), // 10: retrotodo.v1.UpdateFavoriteResponse
+	(*DeleteSprintRequest)(nil),    // 11: retrotodo.v1.DeleteSprintRequest
+	(*DeleteSprintResponse)(nil),   // 12: retrotodo.v1.DeleteSprintResponse
+	(*WatchSprintsRequest)(nil),    // 13: retrotodo.v1.WatchSprintsRequest
+	(*WatchSprintsResponse)(nil),   // 14: retrotodo.v1.WatchSprintsResponse
+	(*timestamppb.Timestamp)(nil),  // 15: google.protobuf.Timestamp
+	(ChangeType)(0),                // 16: retrotodo.v1.ChangeType
+}
+var file_retrotodo_v1_sprint_proto_depIdxs = []int32{
+	15, // 0: retrotodo.v1.Sprint.created_at:type_name -> google.protobuf.Timestamp
+	15, // 1: retrotodo.v1.Sprint.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 2: retrotodo.v1.ListSprintsResponse.sprints:type_name -> retrotodo.v1.Sprint
+	0,  // 3: retrotodo.v1.SearchSprintsResponse.sprints:type_name -> retrotodo.v1.Sprint
+	0,  // 4: retrotodo.v1.CreateSprintResponse.sprint:type_name -> retrotodo.v1.Sprint
+	0,  // 5: retrotodo.v1.UpdateSprintResponse.sprint:type_name -> retrotodo.v1.Sprint
+	0,  // 6: retrotodo.v1.UpdateFavoriteResponse.sprint:type_name -> retrotodo.v1.Sprint
+	16, // 7: retrotodo.v1.WatchSprintsResponse.type:type_name -> retrotodo.v1.ChangeType
+	0,  // 8: retrotodo.v1.WatchSprintsResponse.sprint:type_name -> retrotodo.v1.Sprint
+	1,  // 9: retrotodo.v1.SprintService.ListSprints:input_type -> retrotodo.v1.ListSprintsRequest
+	3,  // 10: retrotodo.v1.SprintService.SearchSprints:input_type -> retrotodo.v1.SearchSprintsRequest
+	5,  // 11: retrotodo.v1.SprintService.CreateSprint:input_type -> retrotodo.v1.CreateSprintRequest
+	7,  // 12: retrotodo.v1.SprintService.UpdateSprint:input_type -> retrotodo.v1.UpdateSprintRequest
+	9,  // 13: retrotodo.v1.SprintService.UpdateFavorite:input_type -> retrotodo.v1.UpdateFavoriteRequest
+	11, // 14: retrotodo.v1.SprintService.DeleteSprint:input_type -> retrotodo.v1.DeleteSprintRequest
+	13, // 15: retrotodo.v1.SprintService.WatchSprints:input_type -> retrotodo.v1.WatchSprintsRequest
+	2,  // 16: retrotodo.v1.SprintService.ListSprints:output_type -> retrotodo.v1.ListSprintsResponse
+	4,  // 17: retrotodo.v1.SprintService.SearchSprints:output_type -> retrotodo.v1.SearchSprintsResponse
+	6,  // 18: retrotodo.v1.SprintService.CreateSprint:output_type -> retrotodo.v1.CreateSprintResponse
+	8,  // 19: retrotodo.v1.SprintService.UpdateSprint:output_type -> retrotodo.v1.UpdateSprintResponse
+	10, // 20: retrotodo.v1.SprintService.UpdateFavorite:output_type -> retrotodo.v1.UpdateFavoriteResponse
+	12, // 21: retrotodo.v1.SprintService.DeleteSprint:output_type -> retrotodo.v1.DeleteSprintResponse
+	14, // 22: retrotodo.v1.SprintService.WatchSprints:output_type -> retrotodo.v1.WatchSprintsResponse
+	16, // [16:23] is the sub-list for method output_type
+	9,  // [9:16] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_retrotodo_v1_sprint_proto_init() }
+func file_retrotodo_v1_sprint_proto_init() {
+	if File_retrotodo_v1_sprint_proto != nil {
+		return
+	}
+	file_retrotodo_v1_todo_proto_init()
+	file_retrotodo_v1_sprint_proto_msgTypes[3].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_retrotodo_v1_sprint_proto_rawDesc), len(file_retrotodo_v1_sprint_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   15,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_retrotodo_v1_sprint_proto_goTypes,
+		DependencyIndexes: file_retrotodo_v1_sprint_proto_depIdxs,
+		MessageInfos:      file_retrotodo_v1_sprint_proto_msgTypes,
+	}.Build()
+	File_retrotodo_v1_sprint_proto = out.File
+	file_retrotodo_v1_sprint_proto_goTypes = nil
+	file_retrotodo_v1_sprint_proto_depIdxs = nil
+}
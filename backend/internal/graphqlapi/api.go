@@ -0,0 +1,154 @@
+// Package graphqlapi は TODO・スプリントの GraphQL スキーマとリゾルバー
+//
+// スキーマは schema.graphqls で、実行部分（generated.go）は gqlgen で生成する。
+// REST の各ハンドラーと同じリポジトリ・入力検証を使い、エラーも同じ apperror を返す。
+// スプリントの todos・TODO の sprint はリクエストごとの DataLoader でまとめて読み込む。
+package graphqlapi
+
+//go:generate go tool gqlgen generate --config gqlgen.yml
+
+import (
+	"backend/internal/dataloader"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"context"
+	_ "embed"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+)
+
+//go:embed schema.graphqls
+var sdl string
+
+// listComplexityFactor はリストを返すフィールドの子の複雑度に掛ける係数（件数の見積もり）
+const listComplexityFactor = 10
+
+// loaderWait は同じ階層のリゾルバーが Load したキーをまとめる待ち時間
+const loaderWait = 2 * time.Millisecond
+
+// Validator は入力を model の validate タグで検証する（validation.Validator）
+type Validator interface {
+	ValidateContext(ctx context.Context, i interface{}) error
+}
+
+// Viewer はリクエストの利用者（認証ミドルウェアが確認したトークンの内容）
+type Viewer struct {
+	UserID int
+	Role   string
+}
+
+// Limits はクエリの深さと複雑度の上限（0 は無制限）
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// API はリゾルバーが使う依存関係（gqlgen の ResolverRoot）
+type API struct {
+	todos     repository.TodoRepository
+	sprints   repository.SprintRepository
+	users     repository.UserRepository
+	validator Validator
+}
+
+// New は API を作成する（validator が nil なら入力を検証しない）
+func New(todos repository.TodoRepository, sprints repository.SprintRepository, users repository.UserRepository, validator Validator) *API {
+	return &API{todos: todos, sprints: sprints, users: users, validator: validator}
+}
+
+// Server は POST でクエリ・ミューテーションを受け付ける gqlgen のサーバーを返す
+// 利用者は WithViewer でリクエストのコンテキストに設定する
+func (a *API) Server(limits Limits) *handler.Server {
+	cfg := Config{Resolvers: a}
+	// リストのフィールドの子は listComplexityFactor 件分として数える
+	cfg.Complexity.Query.Todos = func(childComplexity int, _ *string, _ *bool, _ *int) int {
+		return 1 + childComplexity*listComplexityFactor
+	}
+	cfg.Complexity.Query.Sprints = func(childComplexity int, _ *string, _ *bool) int {
+		return 1 + childComplexity*listComplexityFactor
+	}
+	cfg.Complexity.Sprint.Todos = func(childComplexity int, _ *bool) int {
+		return 1 + childComplexity*listComplexityFactor
+	}
+
+	srv := handler.New(NewExecutableSchema(cfg))
+	srv.AddTransport(transport.POST{})
+	if limits.MaxDepth > 0 {
+		srv.Use(depthLimit(limits.MaxDepth))
+	}
+	if limits.MaxComplexity > 0 {
+		srv.Use(extension.FixedComplexityLimit(limits.MaxComplexity))
+	}
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		viewer, _ := ctx.Value(viewerKey{}).(Viewer)
+		state := &requestState{viewer: viewer}
+		a.resetLoaders(state)
+		return next(context.WithValue(ctx, stateKey{}, state))
+	})
+	return srv
+}
+
+// SDL はスキーマ定義を返す
+func (a *API) SDL() string {
+	return sdl
+}
+
+type viewerKey struct{}
+
+// WithViewer はリクエストの利用者をコンテキストに設定する
+func WithViewer(ctx context.Context, viewer Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer)
+}
+
+type stateKey struct{}
+
+// requestState はリクエストごとの利用者とローダー
+type requestState struct {
+	viewer Viewer
+	// sprintByID はIDからスプリント（存在しない・削除済みなら nil）
+	sprintByID *dataloader.Loader[int, *model.Sprint]
+	// todosBySprint はスプリントIDからそのTODO
+	todosBySprint *dataloader.Loader[int, []model.Todo]
+}
+
+func stateFrom(ctx context.Context) *requestState {
+	return ctx.Value(stateKey{}).(*requestState)
+}
+
+// resetLoaders はローダーを作り直す
+// ミューテーションの実行後は読み込み済みの値が古くなるため、ミューテーションごとに呼ぶ
+func (a *API) resetLoaders(state *requestState) {
+	state.sprintByID = dataloader.New(func(ctx context.Context, ids []int) (map[int]*model.Sprint, error) {
+		sprints, err := a.sprints.FindByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[int]*model.Sprint, len(sprints))
+		for i := range sprints {
+			byID[sprints[i].ID] = &sprints[i]
+		}
+		return byID, nil
+	}, loaderWait)
+	state.todosBySprint = dataloader.New(func(ctx context.Context, sprintIDs []int) (map[int][]model.Todo, error) {
+		todos, err := a.todos.FindBySprintIDs(ctx, sprintIDs)
+		if err != nil {
+			return nil, err
+		}
+		bySprint := make(map[int][]model.Todo, len(sprintIDs))
+		for _, t := range todos {
+			bySprint[*t.SprintID] = append(bySprint[*t.SprintID], t)
+		}
+		return bySprint, nil
+	}, loaderWait)
+}
+
+func (a *API) validate(ctx context.Context, v interface{}) error {
+	if a.validator == nil {
+		return nil
+	}
+	return a.validator.ValidateContext(ctx, v)
+}
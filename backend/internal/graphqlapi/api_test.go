@@ -0,0 +1,271 @@
+package graphqlapi
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"backend/internal/validation"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// countingSprints / countingTodos はまとめて読み込んだ回数を数える
+type countingSprints struct {
+	repository.SprintRepository
+	mu    sync.Mutex
+	calls [][]int
+}
+
+func (r *countingSprints) FindByIDs(ctx context.Context, ids []int) ([]model.Sprint, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, ids)
+	r.mu.Unlock()
+	return r.SprintRepository.FindByIDs(ctx, ids)
+}
+
+type countingTodos struct {
+	repository.TodoRepository
+	mu    sync.Mutex
+	calls [][]int
+}
+
+func (r *countingTodos) FindBySprintIDs(ctx context.Context, ids []int) ([]model.Todo, error) {
+	r.mu.Lock()
+	r.calls = append(r.calls, ids)
+	r.mu.Unlock()
+	return r.TodoRepository.FindBySprintIDs(ctx, ids)
+}
+
+type testAPI struct {
+	api     *API
+	repos   *repository.Repositories
+	sprints *countingSprints
+	todos   *countingTodos
+}
+
+// newTestAPI はインメモリのリポジトリに alice を作成した API を返す
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	repos := memory.NewRepositories()
+	_, err := repos.Users.Create(context.Background(), "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+
+	validator := validation.NewValidator(validation.DefaultPasswordPolicy())
+	validator.RegisterExists("sprint", repos.Sprints.Exists)
+
+	ta := &testAPI{repos: repos, sprints: &countingSprints{SprintRepository: repos.Sprints}, todos: &countingTodos{TodoRepository: repos.Todos}}
+	ta.api = New(ta.todos, ta.sprints, repos.Users, validator)
+	return ta
+}
+
+// exec は alice としてクエリを実行し、data とリゾルバーが返したエラーを返す
+func (ta *testAPI) exec(t *testing.T, query string, vars map[string]interface{}) (map[string]interface{}, []error) {
+	t.Helper()
+	var mu sync.Mutex
+	var errs []error
+	srv := ta.api.Server(Limits{})
+	srv.SetErrorPresenter(func(ctx context.Context, err error) *gqlerror.Error {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+		return graphql.DefaultErrorPresenter(ctx, err)
+	})
+
+	body, err := json.Marshal(map[string]interface{}{"query": query, "variables": vars})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	req = req.WithContext(WithViewer(req.Context(), Viewer{UserID: 1, Role: model.RoleUser}))
+	rec := httptest.NewRecorder()
+	srv.ServeHTTP(rec, req)
+
+	var out struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+	return out.Data, errs
+}
+
+func TestAPI_Me(t *testing.T) {
+	ta := newTestAPI(t)
+
+	data, errs := ta.exec(t, `{ me { id username role isActive createdAt } }`, nil)
+	require.Empty(t, errs)
+	me := data["me"].(map[string]interface{})
+	assert.Equal(t, "alice", me["username"])
+	assert.Equal(t, "user", me["role"])
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`, me["createdAt"])
+}
+
+// スプリント → TODO → スプリントの入れ子を、階層ごとに1回の読み込みで返す
+func TestAPI_BatchesNestedQueries(t *testing.T) {
+	ta := newTestAPI(t)
+	ctx := context.Background()
+	for _, name := range []string{"Sprint 1", "Sprint 2", "Sprint 3"} {
+		sprint, err := ta.repos.Sprints.Create(ctx, name, "bg-purple-500", false)
+		require.NoError(t, err)
+		for _, title := range []string{"a", "b"} {
+			_, err := ta.repos.Todos.Create(ctx, name+"/"+title, "", &sprint.ID)
+			require.NoError(t, err)
+		}
+	}
+
+	data, errs := ta.exec(t, `{
+		sprints { name todos { title sprint { name } } }
+	}`, nil)
+	require.Empty(t, errs)
+
+	sprints := data["sprints"].([]interface{})
+	require.Len(t, sprints, 3)
+	for _, s := range sprints {
+		sprint := s.(map[string]interface{})
+		todos := sprint["todos"].([]interface{})
+		require.Len(t, todos, 2)
+		assert.Equal(t, sprint["name"], todos[0].(map[string]interface{})["sprint"].(map[string]interface{})["name"])
+	}
+
+	assert.Len(t, ta.todos.calls, 1)
+	require.Len(t, ta.sprints.calls, 1)
+	assert.Len(t, ta.sprints.calls[0], 3)
+}
+
+func TestAPI_TodoQueries(t *testing.T) {
+	ta := newTestAPI(t)
+	ctx := context.Background()
+	sprint, err := ta.repos.Sprints.Create(ctx, "Sprint", "bg-purple-500", false)
+	require.NoError(t, err)
+	done, err := ta.repos.Todos.Create(ctx, "Done", "", &sprint.ID)
+	require.NoError(t, err)
+	_, err = ta.repos.Todos.SetCompleted(ctx, done.ID, true, repository.AnyVersion)
+	require.NoError(t, err)
+	_, err = ta.repos.Todos.Create(ctx, "Open", "", nil)
+	require.NoError(t, err)
+
+	data, errs := ta.exec(t, `query ($id: Int!) {
+		open: todos(completed: false) { title sprintId sprint { id } }
+		sprint(id: $id) { todos(completed: true) { title } }
+		missing: sprint(id: 999) { id }
+	}`, map[string]interface{}{"id": 1})
+	require.Empty(t, errs)
+
+	assert.Equal(t, []interface{}{map[string]interface{}{"title": "Open", "sprintId": nil, "sprint": nil}}, data["open"])
+	assert.Equal(t, map[string]interface{}{"todos": []interface{}{map[string]interface{}{"title": "Done"}}}, data["sprint"])
+	assert.Nil(t, data["missing"])
+}
+
+func TestAPI_Mutations(t *testing.T) {
+	ta := newTestAPI(t)
+
+	data, errs := ta.exec(t, `mutation {
+		sprint: createSprint(name: "Sprint") { id name color isFavorite version }
+	}`, nil)
+	require.Empty(t, errs)
+	sprint := data["sprint"].(map[string]interface{})
+	assert.Equal(t, "bg-purple-500", sprint["color"])
+	assert.Equal(t, false, sprint["isFavorite"])
+
+	// 作成したTODOの sprint は変更後の状態で読み込む
+	data, errs = ta.exec(t, `mutation ($sprintId: Int) {
+		todo: createTodo(title: "Todo", sprintId: $sprintId) { id version sprint { name } }
+		renamed: updateSprint(id: 1, name: "Renamed", color: "bg-blue-500", version: 1) { version }
+		moved: moveTodo(id: 1, sprintId: null) { sprintId }
+		completed: setTodoCompleted(id: 1, completed: true) { completed version }
+	}`, map[string]interface{}{"sprintId": 1})
+	require.Empty(t, errs)
+	assert.Equal(t, map[string]interface{}{"id": float64(1), "version": float64(1), "sprint": map[string]interface{}{"name": "Sprint"}}, data["todo"])
+	assert.Equal(t, float64(2), data["renamed"].(map[string]interface{})["version"])
+	assert.Nil(t, data["moved"].(map[string]interface{})["sprintId"])
+	assert.Equal(t, map[string]interface{}{"completed": true, "version": float64(3)}, data["completed"])
+
+	data, errs = ta.exec(t, `mutation {
+		fav: setSprintFavorite(id: 1, isFavorite: true) { isFavorite }
+		updated: updateTodo(id: 1, title: "Updated", completed: false) { title completed }
+		deleteTodo(id: 1)
+		deleteSprint(id: 1, version: 3)
+	}`, nil)
+	require.Empty(t, errs)
+	assert.Equal(t, true, data["deleteTodo"])
+	assert.Equal(t, true, data["deleteSprint"])
+
+	todos, err := ta.repos.Todos.FindAll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, todos)
+}
+
+func TestAPI_MutationErrors(t *testing.T) {
+	ta := newTestAPI(t)
+	_, err := ta.repos.Todos.Create(context.Background(), "Todo", "", nil)
+	require.NoError(t, err)
+
+	// 非 null のルートフィールドが失敗すると data は null になる（後続のミューテーションは実行する）
+	data, errs := ta.exec(t, `mutation {
+		invalid: createTodo(title: " ", sprintId: 99) { id }
+		ok: setTodoCompleted(id: 1, completed: true) { completed }
+	}`, nil)
+	assert.Nil(t, data)
+	require.Len(t, errs, 1)
+
+	var appErr *apperror.Error
+	require.True(t, errors.As(errs[0], &appErr))
+	assert.ErrorIs(t, appErr, apperror.ErrValidation)
+	assert.Len(t, appErr.Fields, 2)
+
+	todos, err := ta.repos.Todos.FindAll(context.Background())
+	require.NoError(t, err)
+	assert.True(t, todos[0].Completed)
+
+	for query, want := range map[string]error{
+		`mutation { updateTodo(id: 1, title: "Changed", completed: true, version: 5) { id } }`: repository.ErrVersionMismatch,
+		`mutation { deleteTodo(id: 99) }`:                           repository.ErrTodoNotFound,
+		`mutation { moveTodo(id: 1, sprintId: 99) { id } }`:         apperror.ErrValidation,
+		`mutation { createSprint(name: "S", color: "red") { id } }`: apperror.ErrValidation,
+	} {
+		_, errs := ta.exec(t, query, nil)
+		require.Len(t, errs, 1, query)
+		assert.ErrorIs(t, errs[0], want, query)
+	}
+}
+
+// 削除済みのスプリントには移動できない（外部キーは論理削除した行も受け付けるため検証する）
+func TestAPI_MoveTodoToDeletedSprint(t *testing.T) {
+	ta := newTestAPI(t)
+	ctx := context.Background()
+	sprint, err := ta.repos.Sprints.Create(ctx, "Deleted", "bg-purple-500", false)
+	require.NoError(t, err)
+	require.NoError(t, ta.repos.Sprints.Delete(ctx, sprint.ID, repository.AnyVersion))
+	_, err = ta.repos.Todos.Create(ctx, "Todo", "", nil)
+	require.NoError(t, err)
+
+	_, errs := ta.exec(t, `mutation ($sprintId: Int) { moveTodo(id: 1, sprintId: $sprintId) { id } }`, map[string]interface{}{"sprintId": sprint.ID})
+	require.Len(t, errs, 1)
+	var appErr *apperror.Error
+	require.True(t, errors.As(errs[0], &appErr))
+	assert.ErrorIs(t, appErr, apperror.ErrValidation)
+	require.Len(t, appErr.Fields, 1)
+	assert.Equal(t, "sprint_id", appErr.Fields[0].Field)
+
+	todos, err := ta.repos.Todos.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Nil(t, todos[0].SprintID)
+}
+
+// SDL は schema.graphqls をそのまま返す
+func TestAPI_SDL(t *testing.T) {
+	sdl := newTestAPI(t).api.SDL()
+	assert.Contains(t, sdl, "type Sprint {")
+	assert.Contains(t, sdl, `todos("完了状態でフィルタ" completed: Boolean): [Todo!]!`)
+	assert.Contains(t, sdl, "moveTodo(id: Int!, sprintId: Int, version: Int): Todo!")
+	assert.Contains(t, sdl, "scalar DateTime")
+}
@@ -0,0 +1,60 @@
+package graphqlapi
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+// errDepthLimit は深さの上限を超えたクエリのエラーコード
+const errDepthLimit = "DEPTH_LIMIT_EXCEEDED"
+
+// depthLimit はクエリの深さ（フィールドの入れ子の数）を制限する gqlgen の拡張
+type depthLimit int
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = depthLimit(0)
+
+func (depthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (depthLimit) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+func (d depthLimit) MutateOperationContext(_ context.Context, opCtx *graphql.OperationContext) *gqlerror.Error {
+	op := opCtx.Doc.Operations.ForName(opCtx.OperationName)
+	if depth := selectionDepth(op.SelectionSet); depth > int(d) {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, int(d))
+		errcode.Set(err, errDepthLimit)
+		return err
+	}
+	return nil
+}
+
+// selectionDepth はフラグメントを展開した選択の深さ
+// フラグメントの循環は検証（NoFragmentCycles）で拒否済み
+func selectionDepth(set ast.SelectionSet) int {
+	depth := 0
+	for _, sel := range set {
+		var d int
+		switch sel := sel.(type) {
+		case *ast.Field:
+			d = 1 + selectionDepth(sel.SelectionSet)
+		case *ast.FragmentSpread:
+			if sel.Definition != nil {
+				d = selectionDepth(sel.Definition.SelectionSet)
+			}
+		case *ast.InlineFragment:
+			d = selectionDepth(sel.SelectionSet)
+		}
+		depth = max(depth, d)
+	}
+	return depth
+}
@@ -0,0 +1,163 @@
+package graphqlapi
+
+import (
+	"backend/internal/model"
+	"backend/internal/repository"
+	"context"
+)
+
+func (a *API) Query() QueryResolver       { return &queryResolver{a} }
+func (a *API) Mutation() MutationResolver { return &mutationResolver{a} }
+func (a *API) Sprint() SprintResolver     { return &sprintResolver{a} }
+func (a *API) Todo() TodoResolver         { return &todoResolver{a} }
+
+type queryResolver struct{ *API }
+
+func (r *queryResolver) Me(ctx context.Context) (*model.User, error) {
+	return r.users.FindByID(ctx, stateFrom(ctx).viewer.UserID)
+}
+
+func (r *queryResolver) Todos(ctx context.Context, title *string, completed *bool, sprintID *int) ([]model.Todo, error) {
+	return r.todos.Search(ctx, &model.TodoSearchRequest{Title: title, Completed: completed, SprintID: sprintID})
+}
+
+func (r *queryResolver) Sprints(ctx context.Context, name *string, isFavorite *bool) ([]model.Sprint, error) {
+	return r.sprints.Search(ctx, &model.SprintSearchRequest{Name: name, IsFavorite: isFavorite})
+}
+
+func (r *queryResolver) Sprint(ctx context.Context, id int) (*model.Sprint, error) {
+	return stateFrom(ctx).sprintByID.Load(ctx, id)
+}
+
+type todoResolver struct{ *API }
+
+// Sprint は同じ階層の全てのTODOのスプリントを1回で読み込む
+func (r *todoResolver) Sprint(ctx context.Context, obj *model.Todo) (*model.Sprint, error) {
+	if obj.SprintID == nil {
+		return nil, nil
+	}
+	return stateFrom(ctx).sprintByID.Load(ctx, *obj.SprintID)
+}
+
+type sprintResolver struct{ *API }
+
+// Todos は同じ階層の全てのスプリントのTODOを1回で読み込む
+func (r *sprintResolver) Todos(ctx context.Context, obj *model.Sprint, completed *bool) ([]model.Todo, error) {
+	todos, err := stateFrom(ctx).todosBySprint.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+	filtered := []model.Todo{}
+	for _, t := range todos {
+		if completed == nil || t.Completed == *completed {
+			filtered = append(filtered, t)
+		}
+	}
+	return filtered, nil
+}
+
+// mutationResolver は REST のハンドラーと同じ検証・リポジトリの操作をする
+// 実行後はローダーを作り直し、返した値の子フィールドを最新の状態で読み込む
+type mutationResolver struct{ *API }
+
+func (r *mutationResolver) CreateTodo(ctx context.Context, title string, description *string, sprintID *int) (*model.Todo, error) {
+	t := &model.Todo{Title: title, SprintID: sprintID}
+	if description != nil {
+		t.Description = *description
+	}
+	if err := r.validate(ctx, t); err != nil {
+		return nil, err
+	}
+	return mutated(ctx, r.API, func() (*model.Todo, error) {
+		return r.todos.Create(ctx, t.Title, t.Description, t.SprintID)
+	})
+}
+
+func (r *mutationResolver) UpdateTodo(ctx context.Context, id int, title string, completed bool, ver *int) (*model.Todo, error) {
+	t := &model.Todo{Title: title, Completed: completed}
+	if err := r.validate(ctx, t); err != nil {
+		return nil, err
+	}
+	return mutated(ctx, r.API, func() (*model.Todo, error) {
+		return r.todos.Update(ctx, t.Title, t.Completed, id, version(ver))
+	})
+}
+
+func (r *mutationResolver) SetTodoCompleted(ctx context.Context, id int, completed bool, ver *int) (*model.Todo, error) {
+	return mutated(ctx, r.API, func() (*model.Todo, error) {
+		return r.todos.SetCompleted(ctx, id, completed, version(ver))
+	})
+}
+
+// MoveTodo は一括操作の move と同じく、移動先が削除済みでないスプリントか検証する
+func (r *mutationResolver) MoveTodo(ctx context.Context, id int, sprintID *int, ver *int) (*model.Todo, error) {
+	if err := r.validate(ctx, &model.BulkTodoOperation{Op: model.BulkOpMove, ID: id, SprintID: sprintID}); err != nil {
+		return nil, err
+	}
+	return mutated(ctx, r.API, func() (*model.Todo, error) {
+		return r.todos.MoveToSprint(ctx, id, sprintID, version(ver))
+	})
+}
+
+func (r *mutationResolver) DeleteTodo(ctx context.Context, id int, ver *int) (bool, error) {
+	return mutated(ctx, r.API, func() (bool, error) {
+		return true, r.todos.Delete(ctx, id, version(ver))
+	})
+}
+
+func (r *mutationResolver) CreateSprint(ctx context.Context, name string, color *string, isFavorite *bool) (*model.Sprint, error) {
+	s := &model.Sprint{Name: name, IsFavorite: isFavorite != nil && *isFavorite}
+	if color != nil {
+		s.Color = *color
+	}
+	if err := r.validate(ctx, s); err != nil {
+		return nil, err
+	}
+	if s.Color == "" {
+		s.Color = "bg-purple-500"
+	}
+	return mutated(ctx, r.API, func() (*model.Sprint, error) {
+		return r.sprints.Create(ctx, s.Name, s.Color, s.IsFavorite)
+	})
+}
+
+func (r *mutationResolver) UpdateSprint(ctx context.Context, id int, name string, color string, ver *int) (*model.Sprint, error) {
+	s := &model.Sprint{Name: name, Color: color}
+	if err := r.validate(ctx, s); err != nil {
+		return nil, err
+	}
+	return mutated(ctx, r.API, func() (*model.Sprint, error) {
+		return r.sprints.Update(ctx, id, s.Name, s.Color, version(ver))
+	})
+}
+
+func (r *mutationResolver) SetSprintFavorite(ctx context.Context, id int, isFavorite bool, ver *int) (*model.Sprint, error) {
+	return mutated(ctx, r.API, func() (*model.Sprint, error) {
+		return r.sprints.UpdateFavorite(ctx, id, isFavorite, version(ver))
+	})
+}
+
+func (r *mutationResolver) DeleteSprint(ctx context.Context, id int, ver *int) (bool, error) {
+	return mutated(ctx, r.API, func() (bool, error) {
+		return true, r.sprints.Delete(ctx, id, version(ver))
+	})
+}
+
+// mutated は変更を実行し、成功すればローダーを作り直す
+func mutated[T any](ctx context.Context, a *API, fn func() (T, error)) (T, error) {
+	v, err := fn()
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	a.resetLoaders(stateFrom(ctx))
+	return v, nil
+}
+
+// version は引数 version を省略したらバージョンを確認しない
+func version(v *int) int {
+	if v == nil {
+		return repository.AnyVersion
+	}
+	return *v
+}
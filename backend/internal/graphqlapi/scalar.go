@@ -0,0 +1,23 @@
+package graphqlapi
+
+import (
+	"backend/internal/types"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+)
+
+// MarshalDateTime は DateTime を model の CustomTime と同じ形式（ISO 8601、UTC、秒まで）で書き出す
+func MarshalDateTime(t types.CustomTime) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		_, _ = io.WriteString(w, strconv.Quote(time.Time(t).UTC().Format("2006-01-02T15:04:05Z")))
+	})
+}
+
+// UnmarshalDateTime は入力の DateTime（引数には使っていない）
+func UnmarshalDateTime(v interface{}) (types.CustomTime, error) {
+	return types.CustomTime{}, errors.New("DateTime cannot be used as an input")
+}
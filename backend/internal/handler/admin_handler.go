@@ -0,0 +1,259 @@
+package handler
+
+//go:generate mockgen -source=admin_handler.go -destination=mock/mock_admin_handler.go -package=mock
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 管理者向けユーザー検索の件数
+const (
+	defaultUserSearchLimit = 50
+	maxUserSearchLimit     = 200
+)
+
+type AdminHandlerInterface interface {
+	SearchUsers(c echo.Context) error
+	DeactivateUser(c echo.Context) error
+	ReactivateUser(c echo.Context) error
+	UpdateUserRole(c echo.Context) error
+	ForceLogout(c echo.Context) error
+	ResetMFA(c echo.Context) error
+	GetStats(c echo.Context) error
+}
+
+type AdminHandler struct {
+	userRepo  repository.UserRepository
+	statsRepo repository.StatsRepository
+	uow       repository.UnitOfWork
+}
+
+func NewAdminHandler(userRepo repository.UserRepository, statsRepo repository.StatsRepository, uow repository.UnitOfWork) AdminHandlerInterface {
+	return &AdminHandler{userRepo: userRepo, statsRepo: statsRepo, uow: uow}
+}
+
+// SearchUsers godoc
+// @Summary ユーザーを検索（管理者）
+// @Description 検索条件に基づいてユーザーを検索します。無効化されたユーザーも含みます。条件を省略すると全件を返します
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body model.UserSearchRequest true "検索条件"
+// @Success 200 {array} model.User
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /admin/users/search [post]
+func (h *AdminHandler) SearchUsers(c echo.Context) error {
+	req := new(model.UserSearchRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	if req.Limit <= 0 {
+		req.Limit = defaultUserSearchLimit
+	}
+	if req.Limit > maxUserSearchLimit {
+		req.Limit = maxUserSearchLimit
+	}
+	if req.Offset < 0 {
+		req.Offset = 0
+	}
+
+	users, err := h.userRepo.Search(c.Request().Context(), req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, users)
+}
+
+// DeactivateUser godoc
+// @Summary ユーザーを無効化（管理者）
+// @Description ユーザーを無効化し、発行済みのトークンを失効させます。自分自身は無効化できません
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "ユーザー ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /admin/users/{id}/deactivate [put]
+func (h *AdminHandler) DeactivateUser(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+	if id == currentUserID(c) {
+		return apperror.BadRequest(apperror.CodeCannotModifySelf, "Cannot deactivate yourself")
+	}
+
+	if _, err := h.userRepo.SetActive(c.Request().Context(), id, false); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "User deactivated successfully"})
+}
+
+// ReactivateUser godoc
+// @Summary ユーザーを再有効化（管理者）
+// @Description 無効化されたユーザーを再び有効にします
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "ユーザー ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /admin/users/{id}/reactivate [put]
+func (h *AdminHandler) ReactivateUser(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.userRepo.SetActive(c.Request().Context(), id, true); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "User reactivated successfully"})
+}
+
+// UpdateUserRole godoc
+// @Summary ユーザーのロールを変更（管理者）
+// @Description ユーザーのロール（user / admin）を変更します。自分自身のロールは変更できません
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "ユーザー ID"
+// @Param request body model.UpdateRoleRequest true "ロール"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /admin/users/{id}/role [put]
+func (h *AdminHandler) UpdateUserRole(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(model.UpdateRoleRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+	// 最後の管理者が自分を降格して締め出されるのを防ぐ
+	if id == currentUserID(c) {
+		return apperror.BadRequest(apperror.CodeCannotModifySelf, "Cannot change your own role")
+	}
+
+	if _, err := h.userRepo.SetRole(c.Request().Context(), id, req.Role); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "User role updated successfully"})
+}
+
+// ForceLogout godoc
+// @Summary ユーザーを強制ログアウト（管理者）
+// @Description ユーザーに発行済みのトークンをすべて失効させます
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "ユーザー ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /admin/users/{id}/logout [post]
+func (h *AdminHandler) ForceLogout(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	if _, err := h.userRepo.RevokeTokens(c.Request().Context(), id); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "User logged out successfully"})
+}
+
+// ResetMFA godoc
+// @Summary ユーザーのMFAをリセット（管理者）
+// @Description 認証アプリを紛失したユーザーのTOTP設定とリカバリーコードを削除し、発行済みのトークンを失効させます
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path int true "ユーザー ID"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /admin/users/{id}/mfa [delete]
+func (h *AdminHandler) ResetMFA(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	// MFAを削除できなかった場合にトークンだけ失効した状態にならないよう、1つのトランザクションで実行する
+	err = h.uow.Do(c.Request().Context(), func(repos *repository.Repositories) error {
+		if _, err := repos.Users.RevokeTokens(c.Request().Context(), id); err != nil {
+			return err
+		}
+		return repos.MFA.Delete(c.Request().Context(), id)
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "MFA reset successfully"})
+}
+
+// GetStats godoc
+// @Summary システム統計を取得（管理者）
+// @Description ユーザー数、TODO数などのシステム統計を返します
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.SystemStats
+// @Failure 403 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /admin/stats [get]
+func (h *AdminHandler) GetStats(c echo.Context) error {
+	stats, err := h.statsRepo.GetSystemStats(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, stats)
+}
@@ -0,0 +1,223 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/repository/mock"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearchUsers_DefaultLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/search", strings.NewReader(`{"query":"ali","limit":1000}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	query := "ali"
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().Search(gomock.Any(), &model.UserSearchRequest{Query: &query, Limit: maxUserSearchLimit}).Return([]model.User{
+		{ID: 1, Username: "alice", Role: model.RoleUser, IsActive: true, CreatedAt: testTime, UpdatedAt: testTime},
+	}, nil)
+
+	handler := NewAdminHandler(mockUserRepo, mock.NewMockStatsRepository(ctrl), mock.NewMockUnitOfWork(ctrl))
+	err := handler.SearchUsers(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var users []model.User
+	json.Unmarshal(rec.Body.Bytes(), &users)
+	assert.Equal(t, 1, len(users))
+}
+
+func TestDeactivateUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/2/deactivate", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	c.Set("user_id", 1)
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().SetActive(gomock.Any(), 2, false).Return(1, nil)
+
+	handler := NewAdminHandler(mockUserRepo, mock.NewMockStatsRepository(ctrl), mock.NewMockUnitOfWork(ctrl))
+	err := handler.DeactivateUser(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestDeactivateUser_Self(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/1/deactivate", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+	c.Set("user_id", 1)
+
+	handler := NewAdminHandler(mock.NewMockUserRepository(ctrl), mock.NewMockStatsRepository(ctrl), mock.NewMockUnitOfWork(ctrl))
+	err := handler.DeactivateUser(c)
+
+	assertProblem(t, c, err, http.StatusBadRequest)
+}
+
+func TestForceLogout_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/admin/users/999/logout", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("999")
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().RevokeTokens(gomock.Any(), 999).Return(0, repository.ErrUserNotFound)
+
+	handler := NewAdminHandler(mockUserRepo, mock.NewMockStatsRepository(ctrl), mock.NewMockUnitOfWork(ctrl))
+	err := handler.ForceLogout(c)
+
+	problem := assertProblem(t, c, err, http.StatusNotFound)
+	assert.Equal(t, apperror.CodeUserNotFound, problem.Code)
+}
+
+// resetMFATestContext は DELETE /admin/users/2/mfa のコンテキストと、mock のリポジトリで fn を実行する UnitOfWork を返す
+func resetMFATestContext(t *testing.T, ctrl *gomock.Controller, repos *repository.Repositories) (echo.Context, *httptest.ResponseRecorder, *mock.MockUnitOfWork) {
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodDelete, "/admin/users/2/mfa", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	uow := mock.NewMockUnitOfWork(ctrl)
+	uow.EXPECT().Do(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, fn func(*repository.Repositories) error) error {
+		return fn(repos)
+	})
+	return c, rec, uow
+}
+
+func TestResetMFA_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().RevokeTokens(gomock.Any(), 2).Return(1, nil)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockMFARepo.EXPECT().Delete(gomock.Any(), 2).Return(nil)
+	c, rec, uow := resetMFATestContext(t, ctrl, &repository.Repositories{Users: mockUserRepo, MFA: mockMFARepo})
+
+	handler := NewAdminHandler(mock.NewMockUserRepository(ctrl), mock.NewMockStatsRepository(ctrl), uow)
+	err := handler.ResetMFA(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+// MFAの削除に失敗したらトランザクションのエラーを返す（トークンの失効もロールバックされる）
+func TestResetMFA_DeleteFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().RevokeTokens(gomock.Any(), 2).Return(1, nil)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockMFARepo.EXPECT().Delete(gomock.Any(), 2).Return(errors.New("connection reset"))
+	c, _, uow := resetMFATestContext(t, ctrl, &repository.Repositories{Users: mockUserRepo, MFA: mockMFARepo})
+
+	handler := NewAdminHandler(mock.NewMockUserRepository(ctrl), mock.NewMockStatsRepository(ctrl), uow)
+	err := handler.ResetMFA(c)
+
+	assert.EqualError(t, err, "connection reset")
+}
+
+func TestUpdateUserRole_InvalidRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/2/role", strings.NewReader(`{"role":"superuser"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+
+	handler := NewAdminHandler(mock.NewMockUserRepository(ctrl), mock.NewMockStatsRepository(ctrl), mock.NewMockUnitOfWork(ctrl))
+	err := handler.UpdateUserRole(c)
+
+	problem := assertProblem(t, c, err, http.StatusUnprocessableEntity)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "role", problem.Errors[0].Field)
+}
+
+func TestReactivateUser_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/2/reactivate", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("2")
+	c.Set("user_id", 1)
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().SetActive(gomock.Any(), 2, true).Return(1, nil)
+
+	handler := NewAdminHandler(mockUserRepo, mock.NewMockStatsRepository(ctrl), mock.NewMockUnitOfWork(ctrl))
+	err := handler.ReactivateUser(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestGetStats_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/admin/stats", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	mockStatsRepo := mock.NewMockStatsRepository(ctrl)
+	mockStatsRepo.EXPECT().GetSystemStats(gomock.Any()).Return(&model.SystemStats{Users: 3, ActiveUsers: 2, Todos: 10}, nil)
+
+	handler := NewAdminHandler(mock.NewMockUserRepository(ctrl), mockStatsRepo, mock.NewMockUnitOfWork(ctrl))
+	err := handler.GetStats(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var stats model.SystemStats
+	json.Unmarshal(rec.Body.Bytes(), &stats)
+	assert.Equal(t, 3, stats.Users)
+}
@@ -3,70 +3,122 @@ package handler
 //go:generate mockgen -source=auth_handler.go -destination=mock/mock_auth_handler.go -package=mock
 
 import (
+	"backend/internal/apperror"
+	"backend/internal/auth"
 	"backend/internal/model"
+	"backend/internal/ratelimit"
 	"backend/internal/repository"
-	"backend/internal/utils"
+	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/labstack/echo/v4"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// errInvalidCredentials はユーザーが存在しない場合もパスワードが違う場合も同じエラーを返すためのもの
+var errInvalidCredentials = apperror.Unauthorized(apperror.CodeInvalidCredentials, "Invalid credentials")
+
 type AuthHandlerInterface interface {
 	Login(c echo.Context) error
 	Register(c echo.Context) error
 }
 
 type AuthHandler struct {
-	userRepo repository.UserRepository
+	loginGuard
+	userRepo      repository.UserRepository
+	mfaRepo       repository.MFARepository
+	workspaceRepo repository.WorkspaceRepository
+	tokens        *auth.TokenService
 }
 
-func NewAuthHandler(userRepo repository.UserRepository) AuthHandlerInterface {
-	return &AuthHandler{userRepo: userRepo}
+func NewAuthHandler(
+	userRepo repository.UserRepository,
+	mfaRepo repository.MFARepository,
+	workspaceRepo repository.WorkspaceRepository,
+	auditRepo repository.AuthAuditRepository,
+	limiter *ratelimit.Limiter,
+	tokens *auth.TokenService,
+) AuthHandlerInterface {
+	return &AuthHandler{
+		loginGuard:    loginGuard{auditRepo: auditRepo, limiter: limiter},
+		userRepo:      userRepo,
+		mfaRepo:       mfaRepo,
+		workspaceRepo: workspaceRepo,
+		tokens:        tokens,
+	}
 }
 
 // Login godoc
 // @Summary ユーザーログイン
-// @Description ユーザー名とパスワードでログインし、JWTトークンを返します
+// @Description ユーザー名とパスワードでログインし、JWTトークンを返します。MFA登録済みの場合はトークンの代わりに mfa_token を返します
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body model.LoginRequest true "ログイン情報"
 // @Success 200 {object} model.LoginResponse
-// @Failure 400 {object} map[string]string
-// @Failure 401 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 401 {object} model.Problem
+// @Failure 429 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /login [post]
 func (h *AuthHandler) Login(c echo.Context) error {
 	req := new(model.LoginRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	// バックオフ中・ロックアウト中ならbcryptの比較をせずに拒否
+	if err := h.check(c, req.Username); err != nil {
+		return err
 	}
 
 	// ユーザーを検索
-	user, err := h.userRepo.FindByUsername(req.Username)
+	user, err := h.userRepo.FindByUsername(c.Request().Context(), req.Username)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Database error"})
+		return err
 	}
 
 	if user == nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid credentials"})
+		return h.reject(c, req.Username, model.AuthFailureUnknownUser, errInvalidCredentials)
 	}
 
 	// パスワード検証
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid credentials"})
+		return h.reject(c, req.Username, model.AuthFailureInvalidPassword, errInvalidCredentials)
+	}
+
+	// MFA登録済みなら2段階目の確認用トークンを返す（失敗カウンタはMFA確認後にリセット）
+	mfa, err := h.mfaRepo.FindByUserID(c.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	if mfa != nil && mfa.Enabled {
+		return h.mfaPending(c, user, auth.TokenPurposeMFAChallenge)
+	}
+
+	// MFA必須のワークスペースに所属している場合は登録用トークンのみ返す
+	required, err := h.workspaceRepo.IsMFARequiredForUser(c.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return h.mfaPending(c, user, auth.TokenPurposeMFAEnroll)
 	}
 
+	h.succeed(c, req.Username)
+
 	// JWTトークン生成
-	token, err := utils.GenerateJWT(user.ID, user.Username)
+	token, err := h.tokens.GenerateJWT(user)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to generate token"})
+		return fmt.Errorf("generate token: %w", err)
 	}
 
 	response := model.LoginResponse{
 		Token: token,
-		User:  *user,
+		User:  user,
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -80,56 +132,81 @@ func (h *AuthHandler) Login(c echo.Context) error {
 // @Produce json
 // @Param request body model.RegisterRequest true "登録情報"
 // @Success 201 {object} model.LoginResponse
-// @Failure 400 {object} map[string]string
-// @Failure 409 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 429 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /register [post]
 func (h *AuthHandler) Register(c echo.Context) error {
 	req := new(model.RegisterRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	if err := bindBody(c, req); err != nil {
+		return err
+	}
+
+	// 入力の正規化と検証
+	req.Username = strings.TrimSpace(req.Username)
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	if err := validateRequest(c, req); err != nil {
+		return err
 	}
 
 	// ユーザー名の重複チェック
-	existingUser, err := h.userRepo.FindByUsername(req.Username)
+	existingUser, err := h.userRepo.FindByUsername(c.Request().Context(), req.Username)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Database error"})
+		return err
 	}
 	if existingUser != nil {
-		return c.JSON(http.StatusConflict, map[string]string{"error": "Username already exists"})
+		return repository.ErrUsernameTaken
 	}
 
 	// メールアドレスの重複チェック
-	existingUser, err = h.userRepo.FindByEmail(req.Email)
+	existingUser, err = h.userRepo.FindByEmail(c.Request().Context(), req.Email)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Database error"})
+		return err
 	}
 	if existingUser != nil {
-		return c.JSON(http.StatusConflict, map[string]string{"error": "Email already exists"})
+		return repository.ErrEmailTaken
 	}
 
 	// パスワードをハッシュ化
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to hash password"})
+		return fmt.Errorf("hash password: %w", err)
 	}
 
 	// ユーザー作成
-	user, err := h.userRepo.Create(req.Username, req.Email, string(hashedPassword))
+	user, err := h.userRepo.Create(c.Request().Context(), req.Username, req.Email, string(hashedPassword))
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create user"})
+		return err
 	}
 
 	// JWTトークン生成
-	token, err := utils.GenerateJWT(user.ID, user.Username)
+	token, err := h.tokens.GenerateJWT(user)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to generate token"})
+		return fmt.Errorf("generate token: %w", err)
 	}
 
 	response := model.LoginResponse{
 		Token: token,
-		User:  *user,
+		User:  user,
 	}
 
 	return c.JSON(http.StatusCreated, response)
 }
+
+// mfaPending はMFAの確認・登録用トークンを返す
+func (h *AuthHandler) mfaPending(c echo.Context, user *model.User, purpose string) error {
+	token, err := h.tokens.GenerateMFAToken(user, purpose)
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, model.LoginResponse{
+		MFARequired:           purpose == auth.TokenPurposeMFAChallenge,
+		MFAEnrollmentRequired: purpose == auth.TokenPurposeMFAEnroll,
+		MFAToken:              token,
+	})
+}
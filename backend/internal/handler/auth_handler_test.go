@@ -0,0 +1,241 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/auth"
+	"backend/internal/model"
+	"backend/internal/ratelimit"
+	"backend/internal/repository/mock"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTestTokenService は開発モード（デフォルトシークレット）のトークンサービスを返す
+func newTestTokenService(t *testing.T) *auth.TokenService {
+	keys, err := auth.NewKeyManager(auth.Config{DevMode: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return auth.NewTokenService(keys)
+}
+
+func newLoginContext(t *testing.T, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/login", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return newTestContext(t, e, req, rec), rec
+}
+
+func TestLogin_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().FindByUsername(gomock.Any(), "alice").Return(&model.User{ID: 1, Username: "alice", PasswordHash: string(hash), CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	mockAuditRepo := mock.NewMockAuthAuditRepository(ctrl)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockMFARepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(nil, nil)
+	mockWorkspaceRepo := mock.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().IsMFARequiredForUser(gomock.Any(), 1).Return(false, nil)
+
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+	handler := NewAuthHandler(mockUserRepo, mockMFARepo, mockWorkspaceRepo, mockAuditRepo, limiter, newTestTokenService(t))
+
+	c, rec := newLoginContext(t, `{"username":"alice","password":"password123"}`)
+	err := handler.Login(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.LoginResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.NotEmpty(t, res.Token)
+	assert.False(t, res.MFARequired)
+}
+
+func TestLogin_MFAEnrolledReturnsChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().FindByUsername(gomock.Any(), "alice").Return(&model.User{ID: 1, Username: "alice", PasswordHash: string(hash), CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	mockAuditRepo := mock.NewMockAuthAuditRepository(ctrl)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockMFARepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Enabled: true}, nil)
+	mockWorkspaceRepo := mock.NewMockWorkspaceRepository(ctrl)
+
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+	handler := NewAuthHandler(mockUserRepo, mockMFARepo, mockWorkspaceRepo, mockAuditRepo, limiter, newTestTokenService(t))
+
+	c, rec := newLoginContext(t, `{"username":"alice","password":"password123"}`)
+	err := handler.Login(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.LoginResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.Empty(t, res.Token)
+	assert.True(t, res.MFARequired)
+
+	claims, err := newTestTokenService(t).ValidateJWT(res.MFAToken)
+	assert.NoError(t, err)
+	assert.Equal(t, auth.TokenPurposeMFAChallenge, claims.Purpose)
+}
+
+func TestLogin_WorkspaceRequiresMFAEnrollment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().FindByUsername(gomock.Any(), "alice").Return(&model.User{ID: 1, Username: "alice", PasswordHash: string(hash), CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	mockAuditRepo := mock.NewMockAuthAuditRepository(ctrl)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockMFARepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(nil, nil)
+	mockWorkspaceRepo := mock.NewMockWorkspaceRepository(ctrl)
+	mockWorkspaceRepo.EXPECT().IsMFARequiredForUser(gomock.Any(), 1).Return(true, nil)
+
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+	handler := NewAuthHandler(mockUserRepo, mockMFARepo, mockWorkspaceRepo, mockAuditRepo, limiter, newTestTokenService(t))
+
+	c, rec := newLoginContext(t, `{"username":"alice","password":"password123"}`)
+	err := handler.Login(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.LoginResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.Empty(t, res.Token)
+	assert.True(t, res.MFAEnrollmentRequired)
+
+	claims, err := newTestTokenService(t).ValidateJWT(res.MFAToken)
+	assert.NoError(t, err)
+	assert.Equal(t, auth.TokenPurposeMFAEnroll, claims.Purpose)
+}
+
+func TestLogin_InvalidPasswordIsAudited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.MinCost)
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().FindByUsername(gomock.Any(), "alice").Return(&model.User{ID: 1, Username: "alice", PasswordHash: string(hash), CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	mockAuditRepo := mock.NewMockAuthAuditRepository(ctrl)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockWorkspaceRepo := mock.NewMockWorkspaceRepository(ctrl)
+	mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, entry *model.AuthAuditLog) error {
+		assert.Equal(t, "alice", entry.Username)
+		assert.Equal(t, model.AuthFailureInvalidPassword, entry.Reason)
+		return nil
+	})
+
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+	handler := NewAuthHandler(mockUserRepo, mockMFARepo, mockWorkspaceRepo, mockAuditRepo, limiter, newTestTokenService(t))
+
+	c, _ := newLoginContext(t, `{"username":"alice","password":"wrong"}`)
+	err := handler.Login(c)
+
+	problem := assertProblem(t, c, err, http.StatusUnauthorized)
+	assert.Equal(t, apperror.CodeInvalidCredentials, problem.Code)
+}
+
+func TestLogin_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().FindByUsername(gomock.Any(), "alice").Return(nil, nil).Times(1)
+	mockAuditRepo := mock.NewMockAuthAuditRepository(ctrl)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockWorkspaceRepo := mock.NewMockWorkspaceRepository(ctrl)
+	mockAuditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	cfg := ratelimit.DefaultConfig()
+	cfg.FreeAttempts = 0
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), cfg)
+	handler := NewAuthHandler(mockUserRepo, mockMFARepo, mockWorkspaceRepo, mockAuditRepo, limiter, newTestTokenService(t))
+
+	// 1回目の失敗でバックオフが始まる
+	c, _ := newLoginContext(t, `{"username":"alice","password":"wrong"}`)
+	assertProblem(t, c, handler.Login(c), http.StatusUnauthorized)
+
+	// バックオフ中はユーザー検索もせずに429
+	c, rec := newLoginContext(t, `{"username":"alice","password":"wrong"}`)
+	problem := assertProblem(t, c, handler.Login(c), http.StatusTooManyRequests)
+	assert.Equal(t, apperror.CodeRateLimited, problem.Code)
+	assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+}
+
+func TestRegister_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	body := `{"username":"a","email":"not-an-email","password":"password"}`
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	// 検証エラーの場合はリポジトリを呼ばない
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockAuditRepo := mock.NewMockAuthAuditRepository(ctrl)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockWorkspaceRepo := mock.NewMockWorkspaceRepository(ctrl)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+	handler := NewAuthHandler(mockUserRepo, mockMFARepo, mockWorkspaceRepo, mockAuditRepo, limiter, newTestTokenService(t))
+
+	err := handler.Register(c)
+
+	problem := assertProblem(t, c, err, http.StatusUnprocessableEntity)
+	assert.Equal(t, apperror.CodeValidationFailed, problem.Code)
+
+	fields := map[string]string{}
+	for _, f := range problem.Errors {
+		fields[f.Field] = f.Code
+	}
+	assert.Equal(t, "invalid_format", fields["username"])
+	assert.Equal(t, "invalid_format", fields["email"])
+	assert.Equal(t, "breached", fields["password"])
+}
+
+func TestRegister_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	body := `{"username":"alice","email":"Alice@Example.com","password":"correct horse battery"}`
+	req := httptest.NewRequest(http.MethodPost, "/register", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	mockUserRepo := mock.NewMockUserRepository(ctrl)
+	mockUserRepo.EXPECT().FindByUsername(gomock.Any(), "alice").Return(nil, nil)
+	mockUserRepo.EXPECT().FindByEmail(gomock.Any(), "alice@example.com").Return(nil, nil)
+	mockUserRepo.EXPECT().Create(gomock.Any(), "alice", "alice@example.com", gomock.Any()).Return(&model.User{ID: 1, Username: "alice", Email: "alice@example.com", CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	mockAuditRepo := mock.NewMockAuthAuditRepository(ctrl)
+	mockMFARepo := mock.NewMockMFARepository(ctrl)
+	mockWorkspaceRepo := mock.NewMockWorkspaceRepository(ctrl)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+	handler := NewAuthHandler(mockUserRepo, mockMFARepo, mockWorkspaceRepo, mockAuditRepo, limiter, newTestTokenService(t))
+
+	err := handler.Register(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
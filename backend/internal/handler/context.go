@@ -0,0 +1,15 @@
+package handler
+
+import "github.com/labstack/echo/v4"
+
+// currentUserID は AuthMiddleware がコンテキストに保存したユーザーIDを返す
+func currentUserID(c echo.Context) int {
+	id, _ := c.Get("user_id").(int)
+	return id
+}
+
+// currentUsername は AuthMiddleware がコンテキストに保存したユーザー名を返す
+func currentUsername(c echo.Context) string {
+	username, _ := c.Get("username").(string)
+	return username
+}
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"backend/internal/contract"
+	"backend/internal/types"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testTime はモックが返すモデルの作成・更新日時（実際の行と同じく null にならない値）
+var testTime = types.CustomTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+var (
+	apiSpecOnce sync.Once
+	apiSpec     *contract.Spec
+	apiSpecErr  error
+
+	// coveredOperations はテストで照合した操作
+	coveredOperations sync.Map
+)
+
+// TestMain はパッケージの全てのテストを実行した場合、仕様の全ての操作が照合されたかを確認する
+// ハンドラーを追加してテストがない（またはテストが照合していない）と失敗する
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if code == 0 && flag.Lookup("test.run").Value.String() == "" && flag.Lookup("test.skip").Value.String() == "" {
+		if missing := uncoveredOperations(); len(missing) > 0 {
+			fmt.Fprintf(os.Stderr, "operations not checked against the API spec:\n  %s\n", strings.Join(missing, "\n  "))
+			code = 1
+		}
+	}
+	os.Exit(code)
+}
+
+func uncoveredOperations() []string {
+	spec, err := contract.Load()
+	if err != nil {
+		return []string{err.Error()}
+	}
+	var missing []string
+	for _, op := range spec.Operations() {
+		if _, ok := coveredOperations.Load(op); !ok {
+			missing = append(missing, op)
+		}
+	}
+	return missing
+}
+
+// loadAPISpec は docs に生成されたAPI仕様を読み込む（パッケージのテスト全体で1回）
+func loadAPISpec(t *testing.T) *contract.Spec {
+	t.Helper()
+	apiSpecOnce.Do(func() {
+		apiSpec, apiSpecErr = contract.Load()
+	})
+	require.NoError(t, apiSpecErr)
+	return apiSpec
+}
+
+// newTestContext は e.NewContext と同じコンテキストを返し、テストの終了時にリクエストとレスポンスをAPI仕様と照合する
+// ハンドラーがエラーを返してレスポンスを書いていない場合は照合しない（assertProblem で書いた場合は照合する）
+func newTestContext(t *testing.T, e *echo.Echo, req *http.Request, rec *httptest.ResponseRecorder) echo.Context {
+	t.Helper()
+	body := readRequestBody(t, req)
+	c := e.NewContext(req, rec)
+	t.Cleanup(func() {
+		if c.Response().Committed {
+			assertContract(t, req, body, rec)
+		}
+	})
+	return c
+}
+
+// assertContract はレスポンスのステータス・ボディがAPI仕様どおりかを検証する
+// リクエストは成功した場合のみ検証する（エラーのテストは仕様に反する入力を意図的に送る）
+func assertContract(t *testing.T, req *http.Request, body []byte, rec *httptest.ResponseRecorder) {
+	t.Helper()
+	spec := loadAPISpec(t)
+	if op, ok := spec.Operation(req); ok {
+		coveredOperations.Store(op, true)
+	}
+	if rec.Code < http.StatusBadRequest {
+		assert.NoError(t, spec.ValidateRequest(req, body))
+	}
+	assert.NoError(t, spec.ValidateResponse(req, rec.Code, rec.Header(), rec.Body.Bytes()))
+}
+
+// readRequestBody はリクエストのボディを読み出し、ハンドラーが読めるように戻す
+func readRequestBody(t *testing.T, req *http.Request) []byte {
+	t.Helper()
+	if req.Body == nil {
+		return nil
+	}
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body
+}
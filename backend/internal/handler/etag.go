@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"backend/internal/repository"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 条件付きリクエストのヘッダー（echo には定数がない）
+const (
+	headerETag        = "ETag"
+	headerIfMatch     = "If-Match"
+	headerIfNoneMatch = "If-None-Match"
+)
+
+// setVersionETag は行バージョンを ETag（"3" の形式）として設定する
+func setVersionETag(c echo.Context, version int) {
+	c.Response().Header().Set(headerETag, `"`+strconv.Itoa(version)+`"`)
+}
+
+// ifMatchVersion は If-Match ヘッダーの行バージョンを返す。ヘッダーがない・* なら AnyVersion
+// If-Match は強い比較のため、弱いETag（W/"3"）や行バージョンとして解釈できない値はどの行とも一致せず ErrVersionMismatch
+func ifMatchVersion(c echo.Context) (int, error) {
+	tag := strings.TrimSpace(c.Request().Header.Get(headerIfMatch))
+	if tag == "" || tag == "*" {
+		return repository.AnyVersion, nil
+	}
+	if len(tag) < 3 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, repository.ErrVersionMismatch
+	}
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version <= 0 {
+		return 0, repository.ErrVersionMismatch
+	}
+	return version, nil
+}
+
+// jsonWithETag は本文のハッシュを ETag に設定してJSONを返す
+// If-None-Match と一致すれば本文を返さず 304 を返す
+func jsonWithETag(c echo.Context, status int, v any) error {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := c.Response().Header()
+	header.Set(headerETag, etag)
+	// 認証済みのレスポンスなので共有キャッシュには保存させず、毎回 If-None-Match で再検証させる
+	header.Set(echo.HeaderCacheControl, "private, no-cache")
+
+	if etagMatches(c.Request().Header.Get(headerIfNoneMatch), etag) {
+		return c.NoContent(http.StatusNotModified)
+	}
+	return c.JSONBlob(status, body)
+}
+
+// etagMatches は If-None-Match（カンマ区切り・弱い比較）に etag が含まれるかどうか
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	tests := []struct {
+		header  string
+		want    int
+		wantErr bool
+	}{
+		{"", repository.AnyVersion, false},
+		{"*", repository.AnyVersion, false},
+		{`"3"`, 3, false},
+		{` "12" `, 12, false},
+		{`W/"3"`, 0, true},
+		{"3", 0, true},
+		{`"abc"`, 0, true},
+		{`"0"`, 0, true},
+		{`"1", "2"`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/todos/1", nil)
+			if tt.header != "" {
+				req.Header.Set(headerIfMatch, tt.header)
+			}
+			c := newTestEcho().NewContext(req, httptest.NewRecorder())
+
+			version, err := ifMatchVersion(c)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, version)
+		})
+	}
+}
+
+func TestJSONWithETag(t *testing.T) {
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/todos", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set(headerIfNoneMatch, ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		require.NoError(t, jsonWithETag(newTestEcho().NewContext(req, rec), http.StatusOK, []string{"a"}))
+		return rec
+	}
+
+	rec := get("")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `["a"]`, rec.Body.String())
+	etag := rec.Header().Get(headerETag)
+	require.NotEmpty(t, etag)
+	assert.Equal(t, "private, no-cache", rec.Header().Get(echo.HeaderCacheControl))
+
+	// 同じ内容なら同じ ETag
+	assert.Equal(t, etag, get(`"other"`).Header().Get(headerETag))
+
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		rec = get(header)
+		assert.Equal(t, http.StatusNotModified, rec.Code, header)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, etag, rec.Header().Get(headerETag))
+	}
+
+	assert.Equal(t, http.StatusOK, get(`"other"`).Code)
+}
+
+// 2つのタブが同じTODOを編集すると、古いバージョンで送った側は 412 になる
+func TestTodoHandler_IfMatch(t *testing.T) {
+	repos := memory.NewRepositories()
+	e := newTestEcho()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	handler := NewTodoHandler(repos.Todos)
+	e.GET("/todos", handler.GetTodos)
+	e.POST("/todos", handler.CreateTodo)
+	e.PUT("/todos/:id", handler.UpdateTodo)
+	e.DELETE("/todos/:id", handler.DeleteTodo)
+
+	do := func(method, path, body string, header map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		for k, v := range header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assertContract(t, req, []byte(body), rec)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/todos", `{"title":"Shared"}`, nil)
+	require.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, `"1"`, rec.Header().Get(headerETag))
+
+	listETag := do(http.MethodGet, "/todos", "", nil).Header().Get(headerETag)
+	assert.Equal(t, http.StatusNotModified, do(http.MethodGet, "/todos", "", map[string]string{headerIfNoneMatch: listETag}).Code)
+
+	// タブ1の更新は成功し、バージョンが上がる
+	rec = do(http.MethodPut, "/todos/1", `{"title":"Tab 1"}`, map[string]string{headerIfMatch: `"1"`})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get(headerETag))
+
+	// タブ2は古いバージョンのまま更新・削除しようとして失敗する
+	rec = do(http.MethodPut, "/todos/1", `{"title":"Tab 2"}`, map[string]string{headerIfMatch: `"1"`})
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"`+apperror.CodeVersionMismatch+`"`)
+	assert.Equal(t, http.StatusPreconditionFailed, do(http.MethodDelete, "/todos/1", "", map[string]string{headerIfMatch: `"1"`}).Code)
+
+	// 一覧が変わったので ETag も変わる
+	rec = do(http.MethodGet, "/todos", "", map[string]string{headerIfNoneMatch: listETag})
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"title":"Tab 1"`)
+	assert.Contains(t, rec.Body.String(), `"version":2`)
+
+	assert.Equal(t, http.StatusNoContent, do(http.MethodDelete, "/todos/1", "", map[string]string{headerIfMatch: `"2"`}).Code)
+}
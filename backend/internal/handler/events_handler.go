@@ -0,0 +1,304 @@
+package handler
+
+//go:generate mockgen -source=events_handler.go -destination=mock/mock_events_handler.go -package=mock
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/auth"
+	"backend/internal/changefeed"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/net/websocket"
+)
+
+const (
+	// heartbeatInterval はプロキシに接続を切られないよう、イベントがなくても送る間隔
+	heartbeatInterval = 15 * time.Second
+	// authCheckInterval は購読中のユーザーが無効化・強制ログアウトされていないかを確認する間隔
+	authCheckInterval = time.Minute
+	// streamWriteTimeout は1件の書き込みのタイムアウト（受け取らないクライアントを切断する）
+	streamWriteTimeout = 10 * time.Second
+	// streamDBTimeout は購読中に行うDB処理のタイムアウト（DBTimeout は購読に適用しないため）
+	streamDBTimeout = 5 * time.Second
+)
+
+type EventsHandlerInterface interface {
+	CreateTicket(c echo.Context) error
+	StreamEvents(c echo.Context) error
+	StreamEventsWebSocket(c echo.Context) error
+}
+
+type EventsHandler struct {
+	hub    *changefeed.Hub
+	users  repository.UserRepository
+	tokens *auth.TokenService
+}
+
+func NewEventsHandler(hub *changefeed.Hub, users repository.UserRepository, tokens *auth.TokenService) EventsHandlerInterface {
+	return &EventsHandler{hub: hub, users: users, tokens: tokens}
+}
+
+// CreateTicket godoc
+// @Summary 変更イベントの購読用チケットを発行
+// @Description GET /events（SSE）と GET /events/ws（WebSocket）に ?ticket= で接続するための短命のトークンを発行します。ブラウザの EventSource / WebSocket は Authorization ヘッダーを設定できないため、アクセストークンの代わりに使います
+// @Tags events
+// @Produce json
+// @Success 200 {object} model.EventStreamTicketResponse
+// @Failure 401 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /events/tickets [post]
+func (h *EventsHandler) CreateTicket(c echo.Context) error {
+	user, err := h.users.FindByID(c.Request().Context(), currentUserID(c))
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperror.Unauthorized(apperror.CodeInvalidToken, "Invalid or expired token")
+	}
+
+	ticket, err := h.tokens.GenerateEventStreamTicket(user)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, model.EventStreamTicketResponse{
+		Ticket:    ticket,
+		ExpiresIn: int(auth.EventStreamTicketTTL / time.Second),
+	})
+}
+
+// StreamEvents は GET /events で TODO・スプリントの変更を Server-Sent Events で送る
+// レスポンスがイベントのストリーム（text/event-stream）のため、Swagger には記載しない（README を参照）
+func (h *EventsHandler) StreamEvents(c echo.Context) error {
+	req, err := parseStreamRequest(c)
+	if err != nil {
+		return err
+	}
+	sub, first := h.subscribe(req)
+	defer sub.Close()
+
+	w := c.Response()
+	w.Header().Set(echo.HeaderContentType, "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	// nginx などのプロキシにバッファリングさせない
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	// サーバーの ReadTimeout / WriteTimeout は接続中ずっと有効なため、読み込みの期限は解除し、書き込みは1件ごとに期限を設定し直す
+	// （読み込みの期限が切れるとリクエストのコンテキストが取り消される）
+	rc := http.NewResponseController(w)
+	_ = rc.SetReadDeadline(time.Time{})
+	write := func(format string, args ...interface{}) error {
+		_ = rc.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+		if _, err := fmt.Fprintf(w, format, args...); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	if err := write(": connected\n\n"); err != nil {
+		return nil
+	}
+
+	h.stream(c.Request().Context(), req, sub, first, eventConn{
+		send: func(ev model.ChangeEvent) error {
+			data, err := json.Marshal(ev)
+			if err != nil {
+				return err
+			}
+			return write("id: %d\nevent: %s\ndata: %s\n\n", ev.ID, ev.Type, data)
+		},
+		// 条件に一致せず送らなかったイベントの分も Last-Event-ID を進める（データのないイベントは EventSource に通知されない）
+		heartbeat: func(last int64) error {
+			return write(": ping\nid: %d\n\n", last)
+		},
+	})
+	return nil
+}
+
+// StreamEventsWebSocket は GET /events/ws で StreamEvents と同じイベントを WebSocket（1メッセージ1イベントの JSON）で送る
+// クライアントからのメッセージは使わない
+func (h *EventsHandler) StreamEventsWebSocket(c echo.Context) error {
+	req, err := parseStreamRequest(c)
+	if err != nil {
+		return err
+	}
+
+	// 認証はチケット（Cookie ではない）で行うため、Origin は確認しない
+	websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+		// サーバーの ReadTimeout / WriteTimeout の期限が接続に残っているため解除する
+		_ = ws.SetDeadline(time.Time{})
+
+		ctx, cancel := context.WithCancel(c.Request().Context())
+		defer cancel()
+		go func() {
+			// 切断の検知と ping への応答のため読み続ける
+			defer cancel()
+			_, _ = io.Copy(io.Discard, ws)
+		}()
+
+		sub, first := h.subscribe(req)
+		defer sub.Close()
+
+		// Write はハートビート（ping）にだけ使う
+		ws.PayloadType = websocket.PingFrame
+		h.stream(ctx, req, sub, first, eventConn{
+			send: func(ev model.ChangeEvent) error {
+				_ = ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+				return websocket.JSON.Send(ws, ev)
+			},
+			heartbeat: func(int64) error {
+				_ = ws.SetWriteDeadline(time.Now().Add(streamWriteTimeout))
+				_, err := ws.Write(nil)
+				return err
+			},
+		})
+	}}.ServeHTTP(c.Response(), c.Request())
+	return nil
+}
+
+// streamRequest は購読の条件
+type streamRequest struct {
+	filter changefeed.Filter
+	// lastEventID を指定すると、その後のイベントから再開する
+	lastEventID *int64
+	// userID / tokenVersion は購読中に認証が取り消されていないかの確認に使う
+	userID       int
+	tokenVersion int
+}
+
+// parseStreamRequest はクエリ（kind, sprint_id, last_event_id）と Last-Event-ID ヘッダーを読み取る
+func parseStreamRequest(c echo.Context) (streamRequest, error) {
+	req := streamRequest{userID: currentUserID(c)}
+	req.tokenVersion, _ = c.Get("token_version").(int)
+
+	for _, kind := range c.QueryParams()["kind"] {
+		if kind != changefeed.KindTodo && kind != changefeed.KindSprint {
+			return req, apperror.BadRequest(apperror.CodeInvalidInput, "kind must be todo or sprint")
+		}
+		req.filter.Kinds = append(req.filter.Kinds, kind)
+	}
+	for _, v := range c.QueryParams()["sprint_id"] {
+		id, err := strconv.Atoi(v)
+		if err != nil || id <= 0 {
+			return req, apperror.BadRequest(apperror.CodeInvalidID, "Invalid sprint_id parameter")
+		}
+		req.filter.SprintIDs = append(req.filter.SprintIDs, id)
+	}
+
+	// EventSource は再接続時に Last-Event-ID ヘッダーを送る。最初の接続や WebSocket ではクエリで指定する
+	v := c.Request().Header.Get("Last-Event-ID")
+	if v == "" {
+		v = c.QueryParam("last_event_id")
+	}
+	if v != "" {
+		id, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || id < 0 {
+			return req, apperror.BadRequest(apperror.CodeInvalidInput, "Invalid Last-Event-ID")
+		}
+		req.lastEventID = &id
+	}
+	return req, nil
+}
+
+// subscribe は購読を開始し、最初に送るイベント（再送するイベント、または再開できない場合の reset）を返す
+func (h *EventsHandler) subscribe(req streamRequest) (*changefeed.Subscription, []model.ChangeEvent) {
+	if req.lastEventID == nil {
+		return h.hub.Subscribe(), nil
+	}
+	sub, missed, ok := h.hub.SubscribeFrom(*req.lastEventID)
+	if !ok {
+		return sub, []model.ChangeEvent{{ID: sub.Since(), Type: model.ChangeEventReset}}
+	}
+	var first []model.ChangeEvent
+	for _, ev := range missed {
+		if req.filter.Match(ev) {
+			first = append(first, changeEvent(ev))
+		}
+	}
+	return sub, first
+}
+
+// eventConn は SSE / WebSocket への書き込み
+type eventConn struct {
+	send func(ev model.ChangeEvent) error
+	// heartbeat は last（このストリームが受け取った最後のイベントID）を添えて接続を維持する
+	heartbeat func(last int64) error
+}
+
+// stream は ctx が終わるか、書き込みに失敗するか、購読が打ち切られるか、認証が取り消されるまでイベントを送る
+func (h *EventsHandler) stream(ctx context.Context, req streamRequest, sub *changefeed.Subscription, first []model.ChangeEvent, conn eventConn) {
+	for _, ev := range first {
+		if err := conn.send(ev); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	authCheck := time.NewTicker(authCheckInterval)
+	defer authCheck.Stop()
+
+	last := sub.Since()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ev, ok := <-sub.Events():
+			if !ok {
+				// 受け取りが遅く打ち切られた。クライアントは Last-Event-ID から再接続する
+				log.Printf("[EVENTS] Closed stream for user %d: %v", req.userID, sub.Err())
+				return
+			}
+			last = ev.Seq
+			if !req.filter.Match(ev) {
+				continue
+			}
+			if err := conn.send(changeEvent(ev)); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if err := conn.heartbeat(last); err != nil {
+				return
+			}
+		case <-authCheck.C:
+			if !h.stillAuthorized(ctx, req) {
+				return
+			}
+		}
+	}
+}
+
+// stillAuthorized は購読を始めたユーザーが無効化・強制ログアウトされていないかを確認する
+// DBのエラーでは購読を切らない
+func (h *EventsHandler) stillAuthorized(ctx context.Context, req streamRequest) bool {
+	ctx, cancel := context.WithTimeout(ctx, streamDBTimeout)
+	defer cancel()
+	user, err := h.users.FindByID(ctx, req.userID)
+	if err != nil {
+		log.Printf("[EVENTS] Failed to check user %d: %v", req.userID, err)
+		return true
+	}
+	return user != nil && user.TokenVersion == req.tokenVersion
+}
+
+func changeEvent(ev changefeed.Event) model.ChangeEvent {
+	return model.ChangeEvent{
+		ID:       ev.Seq,
+		Type:     ev.Kind + "." + ev.Action,
+		EntityID: ev.ID,
+		Todo:     ev.Todo,
+		Sprint:   ev.Sprint,
+	}
+}
@@ -0,0 +1,207 @@
+package handler
+
+import (
+	"backend/internal/auth"
+	"backend/internal/changefeed"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/repository/memory"
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/websocket"
+)
+
+// newEventsTestServer はインメモリのリポジトリ（ユーザー alice）と hub で /events を登録したサーバーを起動する
+func newEventsTestServer(t *testing.T, hub *changefeed.Hub) *httptest.Server {
+	repos := memory.NewRepositories()
+	user, err := repos.Users.Create(context.Background(), "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+	h := NewEventsHandler(hub, repos.Users, newTestTokenService(t))
+
+	e := newTestEcho()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	// EventStreamAuthMiddleware の代わりに利用者を設定する
+	authenticated := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", user.ID)
+			c.Set("token_version", user.TokenVersion)
+			return next(c)
+		}
+	}
+	e.GET("/events", h.StreamEvents, authenticated)
+	e.GET("/events/ws", h.StreamEventsWebSocket, authenticated)
+
+	srv := httptest.NewServer(e)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// sseStream は GET /events のレスポンスを1イベントずつ読む
+type sseStream struct {
+	res *http.Response
+	r   *bufio.Reader
+}
+
+// openSSE は GET /events に接続し、購読が始まる（最初のコメントが届く）まで待つ
+func openSSE(t *testing.T, srv *httptest.Server, query, lastEventID string) *sseStream {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/events"+query, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { res.Body.Close() })
+	require.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get(echo.HeaderContentType))
+
+	s := &sseStream{res: res, r: bufio.NewReader(res.Body)}
+	assert.Equal(t, []string{": connected"}, s.block(t))
+	return s
+}
+
+// block は空行で区切られた次のブロックの行を返す
+func (s *sseStream) block(t *testing.T) []string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := s.r.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimSuffix(line, "\n")
+		if line == "" {
+			return lines
+		}
+		lines = append(lines, line)
+	}
+}
+
+// next は次のイベントの id / event 行と data を返す
+func (s *sseStream) next(t *testing.T) ([]string, model.ChangeEvent) {
+	t.Helper()
+	lines := s.block(t)
+	require.Len(t, lines, 3)
+	var ev model.ChangeEvent
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[2], "data: ")), &ev))
+	return lines[:2], ev
+}
+
+func TestStreamEvents(t *testing.T) {
+	hub := changefeed.NewHub(0, 0)
+	srv := newEventsTestServer(t, hub)
+	stream := openSSE(t, srv, "?kind=todo&sprint_id=1", "")
+
+	sprint1, sprint2 := 1, 2
+	hub.Publish(
+		changefeed.Event{Kind: changefeed.KindSprint, Action: changefeed.ActionUpdated, ID: 1, Sprint: &model.Sprint{ID: 1}},
+		changefeed.Event{Kind: changefeed.KindTodo, Action: changefeed.ActionCreated, ID: 1, Todo: &model.Todo{ID: 1, SprintID: &sprint2}},
+		changefeed.Event{Kind: changefeed.KindTodo, Action: changefeed.ActionCreated, ID: 2, Todo: &model.Todo{ID: 2, Title: "Board", SprintID: &sprint1}},
+	)
+
+	// 条件に一致しないイベントは送らない
+	header, ev := stream.next(t)
+	assert.Equal(t, []string{"id: 3", "event: todo.created"}, header)
+	assert.Equal(t, int64(3), ev.ID)
+	assert.Equal(t, 2, ev.EntityID)
+	assert.Equal(t, "Board", ev.Todo.Title)
+	assert.Nil(t, ev.Sprint)
+}
+
+func TestStreamEvents_Resume(t *testing.T) {
+	hub := changefeed.NewHub(0, 2)
+	srv := newEventsTestServer(t, hub)
+	for id := 1; id <= 3; id++ {
+		hub.Publish(changefeed.Event{Kind: changefeed.KindTodo, Action: changefeed.ActionDeleted, ID: id})
+	}
+
+	// Last-Event-ID の後のイベントを再送する
+	stream := openSSE(t, srv, "", "2")
+	header, ev := stream.next(t)
+	assert.Equal(t, []string{"id: 3", "event: todo.deleted"}, header)
+	assert.Equal(t, 3, ev.EntityID)
+
+	// 履歴に残っていない場合は reset を送る（クライアントは一覧を読み直し、この id から再開する）
+	stream = openSSE(t, srv, "?last_event_id=0", "")
+	header, ev = stream.next(t)
+	assert.Equal(t, []string{"id: 3", "event: reset"}, header)
+	assert.Equal(t, model.ChangeEventReset, ev.Type)
+}
+
+func TestStreamEvents_InvalidParams(t *testing.T) {
+	h := NewEventsHandler(changefeed.NewHub(0, 0), memory.NewRepositories().Users, newTestTokenService(t))
+	for _, query := range []string{"?kind=section", "?sprint_id=abc", "?sprint_id=0", "?last_event_id=-1"} {
+		t.Run(query, func(t *testing.T) {
+			c := newTestEcho().NewContext(httptest.NewRequest(http.MethodGet, "/events"+query, nil), httptest.NewRecorder())
+			c.Set("user_id", 1)
+			assertProblem(t, c, h.StreamEvents(c), http.StatusBadRequest)
+		})
+	}
+}
+
+func TestStreamEventsWebSocket(t *testing.T) {
+	hub := changefeed.NewHub(0, 0)
+	srv := newEventsTestServer(t, hub)
+
+	// 接続と購読の開始を待たずに送るため、last_event_id=0 で最初から再送させる
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/events/ws?kind=sprint&last_event_id=0", "", srv.URL)
+	require.NoError(t, err)
+	defer ws.Close()
+	require.NoError(t, ws.SetDeadline(time.Now().Add(5*time.Second)))
+
+	hub.Publish(
+		changefeed.Event{Kind: changefeed.KindTodo, Action: changefeed.ActionDeleted, ID: 1},
+		changefeed.Event{Kind: changefeed.KindSprint, Action: changefeed.ActionCreated, ID: 4, Sprint: &model.Sprint{ID: 4, Name: "Sprint 4"}},
+	)
+
+	var ev model.ChangeEvent
+	require.NoError(t, websocket.JSON.Receive(ws, &ev))
+	assert.Equal(t, int64(2), ev.ID)
+	assert.Equal(t, "sprint.created", ev.Type)
+	assert.Equal(t, "Sprint 4", ev.Sprint.Name)
+}
+
+func TestCreateTicket(t *testing.T) {
+	repos := memory.NewRepositories()
+	user, err := repos.Users.Create(context.Background(), "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+	tokens := newTestTokenService(t)
+	h := NewEventsHandler(changefeed.NewHub(0, 0), repos.Users, tokens)
+
+	req := httptest.NewRequest(http.MethodPost, "/events/tickets", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, newTestEcho(), req, rec)
+	c.Set("user_id", user.ID)
+
+	require.NoError(t, h.CreateTicket(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	var res model.EventStreamTicketResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, 60, res.ExpiresIn)
+
+	claims, err := tokens.ValidateJWT(res.Ticket)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, claims.UserID)
+	assert.Equal(t, auth.TokenPurposeEventStream, claims.Purpose)
+}
+
+func TestCreateTicket_UserNotFound(t *testing.T) {
+	h := NewEventsHandler(changefeed.NewHub(0, 0), memory.NewRepositories().Users, newTestTokenService(t))
+
+	req := httptest.NewRequest(http.MethodPost, "/events/tickets", nil)
+	c := newTestContext(t, newTestEcho(), req, httptest.NewRecorder())
+	c.Set("user_id", 99)
+
+	assertProblem(t, c, h.CreateTicket(c), http.StatusUnauthorized)
+}
@@ -0,0 +1,71 @@
+package handler
+
+//go:generate mockgen -source=graphql_handler.go -destination=mock/mock_graphql_handler.go -package=mock
+
+import (
+	"backend/internal/graphqlapi"
+	"backend/internal/middleware"
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/labstack/echo/v4"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+type GraphQLHandlerInterface interface {
+	Query(c echo.Context) error
+	GetSchema(c echo.Context) error
+}
+
+type GraphQLHandler struct {
+	api    *graphqlapi.API
+	server *handler.Server
+}
+
+func NewGraphQLHandler(api *graphqlapi.API, limits graphqlapi.Limits) GraphQLHandlerInterface {
+	server := api.Server(limits)
+	server.SetErrorPresenter(presentError)
+	return &GraphQLHandler{api: api, server: server}
+}
+
+// Query は POST /graphql のクエリ・ミューテーションを実行する
+// GraphQL のスキーマ（GET /graphql/schema の SDL）が仕様のため、Swagger には記載しない。
+// リゾルバーのエラーは 200 の errors で返す
+func (h *GraphQLHandler) Query(c echo.Context) error {
+	viewer := graphqlapi.Viewer{UserID: currentUserID(c)}
+	viewer.Role, _ = c.Get("role").(string)
+
+	ctx := graphqlapi.WithViewer(c.Request().Context(), viewer)
+	ctx = context.WithValue(ctx, echoContextKey{}, c)
+	h.server.ServeHTTP(c.Response(), c.Request().WithContext(ctx))
+	return nil
+}
+
+// GetSchema はスキーマを SDL で返す
+func (h *GraphQLHandler) GetSchema(c echo.Context) error {
+	return c.String(http.StatusOK, h.api.SDL())
+}
+
+type echoContextKey struct{}
+
+// presentError はリゾルバーが返したエラーを REST と同じメッセージとエラーコードにする
+// 5xx の詳細は REST と同じくレスポンスに含めず、ログに出力する
+// クエリのエラー（パース・検証・上限）は gqlgen のエラーコードのまま返す
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	c, ok := ctx.Value(echoContextKey{}).(echo.Context)
+	var inner *gqlerror.Error
+	if !ok || gqlErr.Err == nil || errors.As(gqlErr.Err, &inner) {
+		return gqlErr
+	}
+	problem := middleware.ProblemFor(c, gqlErr.Err)
+	gqlErr.Message = problem.Detail
+	gqlErr.Extensions = map[string]interface{}{"code": problem.Code, "status": problem.Status}
+	if len(problem.Errors) > 0 {
+		gqlErr.Extensions["errors"] = problem.Errors
+	}
+	return gqlErr
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/graphqlapi"
+	"backend/internal/middleware"
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"backend/internal/validation"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newGraphQLTestServer はインメモリのリポジトリ（ユーザー alice）で /graphql を登録する
+func newGraphQLTestServer(t *testing.T) (*echo.Echo, *repository.Repositories) {
+	repos := memory.NewRepositories()
+	user, err := repos.Users.Create(context.Background(), "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+
+	validator := validation.NewValidator(validation.DefaultPasswordPolicy())
+	validator.RegisterExists("sprint", repos.Sprints.Exists)
+	api := graphqlapi.New(repos.Todos, repos.Sprints, repos.Users, validator)
+	h := NewGraphQLHandler(api, graphqlapi.Limits{MaxDepth: 4, MaxComplexity: 400})
+
+	e := newTestEcho()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	// AuthMiddleware の代わりに利用者を設定する
+	authenticated := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", user.ID)
+			c.Set("role", user.Role)
+			return next(c)
+		}
+	}
+	e.POST("/graphql", h.Query, authenticated)
+	e.GET("/graphql/schema", h.GetSchema, authenticated)
+	return e, repos
+}
+
+// postGraphQL は /graphql を呼び出す（Swagger に記載しないため API仕様とは照合しない）
+func postGraphQL(t *testing.T, e *echo.Echo, body string) (*httptest.ResponseRecorder, map[string]json.RawMessage) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	var res map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	return rec, res
+}
+
+// graphQLError はレスポンスの errors の要素
+type graphQLError struct {
+	Message    string                 `json:"message"`
+	Path       []interface{}          `json:"path"`
+	Extensions map[string]interface{} `json:"extensions"`
+}
+
+func graphQLErrors(t *testing.T, res map[string]json.RawMessage) []graphQLError {
+	t.Helper()
+	var errs []graphQLError
+	require.NoError(t, json.Unmarshal(res["errors"], &errs))
+	return errs
+}
+
+func TestGraphQL_Query(t *testing.T) {
+	e, repos := newGraphQLTestServer(t)
+	sprint, err := repos.Sprints.Create(context.Background(), "Sprint", "bg-purple-500", false)
+	require.NoError(t, err)
+	_, err = repos.Todos.Create(context.Background(), "Todo", "", &sprint.ID)
+	require.NoError(t, err)
+
+	rec, res := postGraphQL(t, e, `{
+		"query": "query Dashboard($favorite: Boolean) { me { username } sprints(isFavorite: $favorite) { name todos { title sprint { id } } } }",
+		"operationName": "Dashboard",
+		"variables": {"favorite": false}
+	}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, res, "errors")
+	assert.JSONEq(t, `{
+		"me": {"username": "alice"},
+		"sprints": [{"name": "Sprint", "todos": [{"title": "Todo", "sprint": {"id": 1}}]}]
+	}`, string(res["data"]))
+}
+
+// リゾルバーのエラーは REST と同じエラーコード・ステータス・入力検証の詳細を extensions に含める
+func TestGraphQL_ResolverErrors(t *testing.T) {
+	e, _ := newGraphQLTestServer(t)
+
+	rec, res := postGraphQL(t, e, `{"query": "mutation { createTodo(title: \"\", sprintId: 99) { id } }"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `null`, string(res["data"]))
+	errs := graphQLErrors(t, res)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Validation failed", errs[0].Message)
+	assert.Equal(t, apperror.CodeValidationFailed, errs[0].Extensions["code"])
+	assert.Equal(t, float64(http.StatusUnprocessableEntity), errs[0].Extensions["status"])
+	assert.Len(t, errs[0].Extensions["errors"], 2)
+	assert.Equal(t, []interface{}{"createTodo"}, errs[0].Path)
+
+	rec, res = postGraphQL(t, e, `{"query": "mutation { deleteTodo(id: 99) }"}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+	errs = graphQLErrors(t, res)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Todo not found", errs[0].Message)
+	assert.Equal(t, "todo_not_found", errs[0].Extensions["code"])
+	assert.Equal(t, float64(http.StatusNotFound), errs[0].Extensions["status"])
+}
+
+// 上限を超えるクエリやパースできないクエリは実行せず、data を返さない
+// パース・検証のエラーは 422、上限のエラーは 200（gqlgen のステータス）
+func TestGraphQL_RejectedQueries(t *testing.T) {
+	e, _ := newGraphQLTestServer(t)
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		code   string
+	}{
+		{"too deep", `{ sprints { todos { sprint { todos { id } } } } }`, http.StatusOK, "DEPTH_LIMIT_EXCEEDED"},
+		{"too complex", `{ sprints { todos { id title sprint { id name } } } }`, http.StatusOK, "COMPLEXITY_LIMIT_EXCEEDED"},
+		{"syntax error", `{ todos { id }`, http.StatusUnprocessableEntity, "GRAPHQL_PARSE_FAILED"},
+		{"unknown field", `{ todos { tags } }`, http.StatusUnprocessableEntity, "GRAPHQL_VALIDATION_FAILED"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(map[string]string{"query": tt.query})
+			require.NoError(t, err)
+			rec, res := postGraphQL(t, e, string(body))
+			require.Equal(t, tt.status, rec.Code)
+			if data, ok := res["data"]; ok {
+				assert.JSONEq(t, `null`, string(data))
+			}
+			errs := graphQLErrors(t, res)
+			require.NotEmpty(t, errs)
+			assert.Equal(t, tt.code, errs[0].Extensions["code"])
+		})
+	}
+}
+
+func TestGraphQL_InvalidBody(t *testing.T) {
+	e, _ := newGraphQLTestServer(t)
+
+	rec, res := postGraphQL(t, e, `{"query":`)
+	require.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.NotEmpty(t, graphQLErrors(t, res))
+}
+
+func TestGraphQL_GetSchema(t *testing.T) {
+	e, _ := newGraphQLTestServer(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/graphql/schema", nil)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, strings.HasPrefix(rec.Header().Get(echo.HeaderContentType), echo.MIMETextPlain))
+	assert.Contains(t, rec.Body.String(), "type Query {")
+	assert.Contains(t, rec.Body.String(), "type Mutation {")
+}
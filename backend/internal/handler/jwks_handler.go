@@ -0,0 +1,35 @@
+package handler
+
+//go:generate mockgen -source=jwks_handler.go -destination=mock/mock_jwks_handler.go -package=mock
+
+import (
+	"backend/internal/auth"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type JWKSHandlerInterface interface {
+	GetJWKS(c echo.Context) error
+}
+
+type JWKSHandler struct {
+	keys *auth.KeyManager
+}
+
+func NewJWKSHandler(keys *auth.KeyManager) JWKSHandlerInterface {
+	return &JWKSHandler{keys: keys}
+}
+
+// GetJWKS godoc
+// @Summary JWT検証用の公開鍵を取得
+// @Description 他のサービスがトークンを検証するための公開鍵（JWK Set）を返します。ローテーション中は旧鍵も含まれます
+// @Tags auth
+// @Produce json
+// @Success 200 {object} auth.JWKSet
+// @Router /.well-known/jwks.json [get]
+func (h *JWKSHandler) GetJWKS(c echo.Context) error {
+	// ローテーションが反映されるよう短めにキャッシュさせる
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, h.keys.JWKS())
+}
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"backend/internal/auth"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetJWKS(t *testing.T) {
+	keys, err := auth.NewKeyManager(auth.Config{DevMode: true})
+	require.NoError(t, err)
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	err = NewJWKSHandler(keys).GetJWKS(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "public, max-age=300", rec.Header().Get("Cache-Control"))
+
+	var set auth.JWKSet
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+}
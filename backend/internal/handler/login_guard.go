@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/ratelimit"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"log"
+
+	"github.com/labstack/echo/v4"
+)
+
+// loginGuard はログイン系ハンドラーで共通のレート制限と監査ログを扱う
+type loginGuard struct {
+	auditRepo repository.AuthAuditRepository
+	limiter   *ratelimit.Limiter
+}
+
+// check はバックオフ中・ロックアウト中なら Retry-After ヘッダーを設定してエラーを返す
+func (g *loginGuard) check(c echo.Context, username string) error {
+	checkErr := g.limiter.CheckLogin(c.Request().Context(), c.RealIP(), username)
+	if checkErr == nil {
+		return nil
+	}
+
+	var limitErr *ratelimit.LimitError
+	if !errors.As(checkErr, &limitErr) {
+		return checkErr
+	}
+
+	g.audit(c, username, model.AuthFailureRateLimited)
+	middleware.SetRetryAfter(c, limitErr.RetryAfter)
+
+	if limitErr.Locked {
+		return apperror.RateLimited(apperror.CodeAccountLocked, "Account temporarily locked")
+	}
+	return apperror.RateLimited(apperror.CodeRateLimited, "Too many login attempts")
+}
+
+// reject はログイン失敗を記録して err を返す
+func (g *loginGuard) reject(c echo.Context, username, reason string, err *apperror.Error) error {
+	locked, recordErr := g.limiter.RecordFailure(c.Request().Context(), c.RealIP(), username)
+	if recordErr != nil {
+		log.Printf("[AUTH] Failed to record login failure for %s: %v", username, recordErr)
+	}
+
+	g.audit(c, username, reason)
+	if locked {
+		g.audit(c, username, model.AuthFailureLocked)
+	}
+
+	return err
+}
+
+// succeed はログイン成功時にユーザー名の失敗カウンタをリセットする
+func (g *loginGuard) succeed(c echo.Context, username string) {
+	if err := g.limiter.RecordSuccess(c.Request().Context(), username); err != nil {
+		log.Printf("[AUTH] Failed to reset login failures for %s: %v", username, err)
+	}
+}
+
+// audit は認証失敗の監査ログを残す（失敗してもレスポンスには影響させない）
+func (g *loginGuard) audit(c echo.Context, username, reason string) {
+	entry := &model.AuthAuditLog{
+		Username:  username,
+		IPAddress: c.RealIP(),
+		UserAgent: c.Request().UserAgent(),
+		Reason:    reason,
+	}
+	// クライアントが切断しても監査ログは残す
+	if err := g.auditRepo.Create(context.WithoutCancel(c.Request().Context()), entry); err != nil {
+		log.Printf("[AUTH] Failed to write audit log for %s: %v", username, err)
+	}
+}
@@ -0,0 +1,384 @@
+package handler
+
+//go:generate mockgen -source=mfa_handler.go -destination=mock/mock_mfa_handler.go -package=mock
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/auth"
+	"backend/internal/model"
+	"backend/internal/ratelimit"
+	"backend/internal/repository"
+	"backend/internal/utils"
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// recoveryCodeCount は一度に発行するリカバリーコードの数
+const recoveryCodeCount = 10
+
+type MFAHandlerInterface interface {
+	EnrollTOTP(c echo.Context) error
+	ConfirmTOTP(c echo.Context) error
+	DisableTOTP(c echo.Context) error
+	RegenerateRecoveryCodes(c echo.Context) error
+	VerifyLogin(c echo.Context) error
+}
+
+type MFAHandler struct {
+	loginGuard
+	userRepo      repository.UserRepository
+	mfaRepo       repository.MFARepository
+	workspaceRepo repository.WorkspaceRepository
+	tokens        *auth.TokenService
+}
+
+func NewMFAHandler(
+	userRepo repository.UserRepository,
+	mfaRepo repository.MFARepository,
+	workspaceRepo repository.WorkspaceRepository,
+	auditRepo repository.AuthAuditRepository,
+	limiter *ratelimit.Limiter,
+	tokens *auth.TokenService,
+) MFAHandlerInterface {
+	return &MFAHandler{
+		loginGuard:    loginGuard{auditRepo: auditRepo, limiter: limiter},
+		userRepo:      userRepo,
+		mfaRepo:       mfaRepo,
+		workspaceRepo: workspaceRepo,
+		tokens:        tokens,
+	}
+}
+
+// EnrollTOTP godoc
+// @Summary TOTPの登録を開始
+// @Description 新しいTOTPシークレットを発行し、認証アプリ登録用の otpauth URI を返します。確認するまで有効になりません
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Success 200 {object} model.MFAEnrollResponse
+// @Failure 409 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /mfa/totp/enroll [post]
+func (h *MFAHandler) EnrollTOTP(c echo.Context) error {
+	userID := currentUserID(c)
+
+	mfa, err := h.mfaRepo.FindByUserID(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+	if mfa != nil && mfa.Enabled {
+		return apperror.Conflict(apperror.CodeMFAAlreadyEnabled, "MFA already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return fmt.Errorf("generate TOTP secret: %w", err)
+	}
+
+	if err := h.mfaRepo.SaveSecret(c.Request().Context(), userID, secret); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, model.MFAEnrollResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(secret, currentUsername(c)),
+	})
+}
+
+// ConfirmTOTP godoc
+// @Summary TOTPの登録を確認
+// @Description 認証アプリのコードで登録を確認してMFAを有効化し、リカバリーコードを返します。登録用トークンで呼び出した場合はJWTも返します
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body model.MFACodeRequest true "確認コード"
+// @Success 200 {object} model.MFAConfirmResponse
+// @Failure 400 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /mfa/totp/confirm [post]
+func (h *MFAHandler) ConfirmTOTP(c echo.Context) error {
+	req := new(model.MFACodeRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	userID := currentUserID(c)
+	mfa, err := h.mfaRepo.FindByUserID(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil {
+		return apperror.BadRequest(apperror.CodeMFAEnrollmentNotStarted, "MFA enrollment not started")
+	}
+	if mfa.Enabled {
+		return apperror.Conflict(apperror.CodeMFAAlreadyEnabled, "MFA already enabled")
+	}
+
+	ok, err := h.verifyTOTP(c.Request().Context(), mfa, req.Code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperror.BadRequest(apperror.CodeInvalidMFACode, "Invalid MFA code")
+	}
+
+	if err := h.mfaRepo.Enable(c.Request().Context(), userID); err != nil {
+		return err
+	}
+
+	codes, err := h.issueRecoveryCodes(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	response := model.MFAConfirmResponse{RecoveryCodes: codes}
+
+	// 登録用トークンの場合はここで通常のログインを完了させる
+	if purpose, _ := c.Get("token_purpose").(string); purpose == auth.TokenPurposeMFAEnroll {
+		user, err := h.userRepo.FindByID(c.Request().Context(), userID)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return apperror.Unauthorized(apperror.CodeInvalidToken, "Invalid or expired token")
+		}
+
+		token, err := h.tokens.GenerateJWT(user)
+		if err != nil {
+			return fmt.Errorf("generate token: %w", err)
+		}
+		response.Token = token
+		response.User = user
+		h.succeed(c, user.Username)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DisableTOTP godoc
+// @Summary TOTPを無効化
+// @Description 現在のコードで確認してMFAを無効化します。MFA必須のワークスペースに所属している場合は無効化できません。コードの誤りはログインと同じく数え、続くと一時的に拒否します
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body model.MFACodeRequest true "確認コード"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 429 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /mfa/totp [delete]
+func (h *MFAHandler) DisableTOTP(c echo.Context) error {
+	req := new(model.MFACodeRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	userID := currentUserID(c)
+	required, err := h.workspaceRepo.IsMFARequiredForUser(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return apperror.Forbidden(apperror.CodeMFARequiredByWorkspace, "MFA is required by a workspace")
+	}
+
+	mfa, err := h.enabledMFA(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil {
+		return apperror.BadRequest(apperror.CodeMFANotEnabled, "MFA not enabled")
+	}
+
+	if err := h.confirmCode(c, mfa, req.Code); err != nil {
+		return err
+	}
+
+	if err := h.mfaRepo.Delete(c.Request().Context(), userID); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "MFA disabled successfully"})
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary リカバリーコードを再発行
+// @Description 現在のコードで確認してリカバリーコードを再発行します。以前のコードは無効になります。コードの誤りはログインと同じく数え、続くと一時的に拒否します
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Param request body model.MFACodeRequest true "確認コード"
+// @Success 200 {object} model.RecoveryCodesResponse
+// @Failure 400 {object} model.Problem
+// @Failure 429 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /mfa/recovery-codes [post]
+func (h *MFAHandler) RegenerateRecoveryCodes(c echo.Context) error {
+	req := new(model.MFACodeRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	userID := currentUserID(c)
+	mfa, err := h.enabledMFA(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil {
+		return apperror.BadRequest(apperror.CodeMFANotEnabled, "MFA not enabled")
+	}
+
+	if err := h.confirmCode(c, mfa, req.Code); err != nil {
+		return err
+	}
+
+	codes, err := h.issueRecoveryCodes(c.Request().Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, model.RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// VerifyLogin godoc
+// @Summary MFAでログインを完了
+// @Description ログイン時に返された mfa_token と、TOTPコードまたはリカバリーコードでログインを完了し、JWTトークンを返します
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body model.MFALoginRequest true "MFA情報"
+// @Success 200 {object} model.LoginResponse
+// @Failure 400 {object} model.Problem
+// @Failure 401 {object} model.Problem
+// @Failure 429 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /login/mfa [post]
+func (h *MFAHandler) VerifyLogin(c echo.Context) error {
+	req := new(model.MFALoginRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	claims, err := h.tokens.ValidateJWT(req.MFAToken)
+	if err != nil || claims.Purpose != auth.TokenPurposeMFAChallenge {
+		return apperror.Unauthorized(apperror.CodeInvalidToken, "Invalid or expired MFA token")
+	}
+
+	if err := h.check(c, claims.Username); err != nil {
+		return err
+	}
+
+	user, err := h.userRepo.FindByID(c.Request().Context(), claims.UserID)
+	if err != nil {
+		return err
+	}
+	// チャレンジの発行後に強制ログアウトされていれば、そのチャレンジも失効扱い
+	if user == nil || user.TokenVersion != claims.TokenVersion {
+		return apperror.Unauthorized(apperror.CodeInvalidToken, "Invalid or expired MFA token")
+	}
+
+	mfa, err := h.enabledMFA(c.Request().Context(), user.ID)
+	if err != nil {
+		return err
+	}
+	if mfa == nil {
+		return apperror.Unauthorized(apperror.CodeInvalidToken, "Invalid or expired MFA token")
+	}
+
+	var ok bool
+	if req.RecoveryCode != "" {
+		ok, err = h.mfaRepo.UseRecoveryCode(c.Request().Context(), user.ID, utils.HashRecoveryCode(req.RecoveryCode))
+	} else {
+		ok, err = h.verifyTOTP(c.Request().Context(), mfa, req.Code)
+	}
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return h.reject(c, user.Username, model.AuthFailureInvalidMFACode, apperror.Unauthorized(apperror.CodeInvalidMFACode, "Invalid MFA code"))
+	}
+
+	h.succeed(c, user.Username)
+
+	token, err := h.tokens.GenerateJWT(user)
+	if err != nil {
+		return fmt.Errorf("generate token: %w", err)
+	}
+
+	return c.JSON(http.StatusOK, model.LoginResponse{
+		Token: token,
+		User:  user,
+	})
+}
+
+// enabledMFA は有効化済みのMFA設定を返す。未登録・未確認なら nil
+func (h *MFAHandler) enabledMFA(ctx context.Context, userID int) (*model.UserMFA, error) {
+	mfa, err := h.mfaRepo.FindByUserID(ctx, userID)
+	if err != nil || mfa == nil || !mfa.Enabled {
+		return nil, err
+	}
+	return mfa, nil
+}
+
+// confirmCode はログイン中のユーザーが操作を確認するためのコードを検証する
+// /login/mfa と同じくユーザーごとに失敗を数え、バックオフ・ロックアウト中は検証せずに拒否する
+func (h *MFAHandler) confirmCode(c echo.Context, mfa *model.UserMFA, code string) error {
+	username := currentUsername(c)
+	if err := h.check(c, username); err != nil {
+		return err
+	}
+
+	ok, err := h.verifyTOTP(c.Request().Context(), mfa, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return h.reject(c, username, model.AuthFailureInvalidMFACode, apperror.BadRequest(apperror.CodeInvalidMFACode, "Invalid MFA code"))
+	}
+
+	h.succeed(c, username)
+	return nil
+}
+
+// verifyTOTP はコードを検証し、使用したタイムステップを記録する
+func (h *MFAHandler) verifyTOTP(ctx context.Context, mfa *model.UserMFA, code string) (bool, error) {
+	step, ok := utils.ValidateTOTP(mfa.Secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	return h.mfaRepo.MarkStepUsed(ctx, mfa.UserID, step)
+}
+
+// issueRecoveryCodes は新しいリカバリーコードを発行して保存する
+func (h *MFAHandler) issueRecoveryCodes(ctx context.Context, userID int) ([]string, error) {
+	codes, err := utils.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]string, len(codes))
+	for i, code := range codes {
+		hashes[i] = utils.HashRecoveryCode(code)
+	}
+
+	if err := h.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
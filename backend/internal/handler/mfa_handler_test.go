@@ -0,0 +1,315 @@
+package handler
+
+import (
+	"backend/internal/auth"
+	"backend/internal/model"
+	"backend/internal/ratelimit"
+	"backend/internal/repository/mock"
+	"backend/internal/utils"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type mfaTestMocks struct {
+	userRepo      *mock.MockUserRepository
+	mfaRepo       *mock.MockMFARepository
+	workspaceRepo *mock.MockWorkspaceRepository
+	auditRepo     *mock.MockAuthAuditRepository
+}
+
+func newMFATestHandler(t *testing.T, ctrl *gomock.Controller) (MFAHandlerInterface, mfaTestMocks) {
+	m := mfaTestMocks{
+		userRepo:      mock.NewMockUserRepository(ctrl),
+		mfaRepo:       mock.NewMockMFARepository(ctrl),
+		workspaceRepo: mock.NewMockWorkspaceRepository(ctrl),
+		auditRepo:     mock.NewMockAuthAuditRepository(ctrl),
+	}
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+	return NewMFAHandler(m.userRepo, m.mfaRepo, m.workspaceRepo, m.auditRepo, limiter, newTestTokenService(t)), m
+}
+
+func newMFALoginContext(t *testing.T, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/login/mfa", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	return newTestContext(t, e, req, rec), rec
+}
+
+func TestVerifyLogin_TOTPSuccess(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.GenerateTOTPCode(secret, time.Now())
+	mfaToken, _ := newTestTokenService(t).GenerateMFAToken(&model.User{ID: 1, Username: "alice"}, auth.TokenPurposeMFAChallenge)
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.userRepo.EXPECT().FindByID(gomock.Any(), 1).Return(&model.User{ID: 1, Username: "alice", CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Secret: secret, Enabled: true}, nil)
+	m.mfaRepo.EXPECT().MarkStepUsed(gomock.Any(), 1, gomock.Any()).Return(true, nil)
+
+	c, rec := newMFALoginContext(t, fmt.Sprintf(`{"mfa_token":%q,"code":%q}`, mfaToken, code))
+	err := handler.VerifyLogin(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.LoginResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.NotEmpty(t, res.Token)
+}
+
+func TestVerifyLogin_InvalidCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, _ := utils.GenerateTOTPSecret()
+	mfaToken, _ := newTestTokenService(t).GenerateMFAToken(&model.User{ID: 1, Username: "alice"}, auth.TokenPurposeMFAChallenge)
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.userRepo.EXPECT().FindByID(gomock.Any(), 1).Return(&model.User{ID: 1, Username: "alice", CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Secret: secret, Enabled: true}, nil)
+	m.auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, entry *model.AuthAuditLog) error {
+		assert.Equal(t, model.AuthFailureInvalidMFACode, entry.Reason)
+		return nil
+	})
+
+	c, _ := newMFALoginContext(t, fmt.Sprintf(`{"mfa_token":%q,"code":"000000x"}`, mfaToken))
+	err := handler.VerifyLogin(c)
+
+	assertProblem(t, c, err, http.StatusUnauthorized)
+}
+
+func TestVerifyLogin_RecoveryCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mfaToken, _ := newTestTokenService(t).GenerateMFAToken(&model.User{ID: 1, Username: "alice"}, auth.TokenPurposeMFAChallenge)
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.userRepo.EXPECT().FindByID(gomock.Any(), 1).Return(&model.User{ID: 1, Username: "alice", CreatedAt: testTime, UpdatedAt: testTime}, nil)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Enabled: true}, nil)
+	m.mfaRepo.EXPECT().UseRecoveryCode(gomock.Any(), 1, utils.HashRecoveryCode("abcde-fghij")).Return(true, nil)
+
+	c, rec := newMFALoginContext(t, fmt.Sprintf(`{"mfa_token":%q,"recovery_code":"ABCDE-FGHIJ"}`, mfaToken))
+	err := handler.VerifyLogin(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestVerifyLogin_RejectsAccessToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 通常のアクセストークンはチャレンジトークンとして使えない
+	token, _ := newTestTokenService(t).GenerateJWT(&model.User{ID: 1, Username: "alice"})
+	handler, _ := newMFATestHandler(t, ctrl)
+
+	c, _ := newMFALoginContext(t, fmt.Sprintf(`{"mfa_token":%q,"code":"123456"}`, token))
+	err := handler.VerifyLogin(c)
+
+	assertProblem(t, c, err, http.StatusUnauthorized)
+}
+
+func TestVerifyLogin_RevokedAfterChallenge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.GenerateTOTPCode(secret, time.Now())
+	mfaToken, _ := newTestTokenService(t).GenerateMFAToken(&model.User{ID: 1, Username: "alice"}, auth.TokenPurposeMFAChallenge)
+
+	// チャレンジの発行後に RevokeTokens で TokenVersion が増えた
+	handler, m := newMFATestHandler(t, ctrl)
+	m.userRepo.EXPECT().FindByID(gomock.Any(), 1).Return(&model.User{ID: 1, Username: "alice", TokenVersion: 1, CreatedAt: testTime, UpdatedAt: testTime}, nil)
+
+	c, _ := newMFALoginContext(t, fmt.Sprintf(`{"mfa_token":%q,"code":%q}`, mfaToken, code))
+	err := handler.VerifyLogin(c)
+
+	// コードは確認せずに拒否する
+	assertProblem(t, c, err, http.StatusUnauthorized)
+}
+
+func TestConfirmTOTP_WithEnrollmentToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.GenerateTOTPCode(secret, time.Now())
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Secret: secret}, nil)
+	m.mfaRepo.EXPECT().MarkStepUsed(gomock.Any(), 1, gomock.Any()).Return(true, nil)
+	m.mfaRepo.EXPECT().Enable(gomock.Any(), 1).Return(nil)
+	m.mfaRepo.EXPECT().ReplaceRecoveryCodes(gomock.Any(), 1, gomock.Len(recoveryCodeCount)).Return(nil)
+	m.userRepo.EXPECT().FindByID(gomock.Any(), 1).Return(&model.User{ID: 1, Username: "alice", CreatedAt: testTime, UpdatedAt: testTime}, nil)
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPost, "/mfa/totp/confirm", strings.NewReader(fmt.Sprintf(`{"code":%q}`, code)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.Set("user_id", 1)
+	c.Set("username", "alice")
+	c.Set("token_purpose", auth.TokenPurposeMFAEnroll)
+
+	err := handler.ConfirmTOTP(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.MFAConfirmResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.Len(t, res.RecoveryCodes, recoveryCodeCount)
+	assert.NotEmpty(t, res.Token)
+}
+
+// newMFAContext は認証済みのユーザー（ID 1）として呼び出すコンテキストを返す
+func newMFAContext(t *testing.T, method, path, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := newTestEcho()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.Set("user_id", 1)
+	c.Set("username", "alice")
+	return c, rec
+}
+
+func TestEnrollTOTP_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(nil, nil)
+	m.mfaRepo.EXPECT().SaveSecret(gomock.Any(), 1, gomock.Any()).Return(nil)
+
+	c, rec := newMFAContext(t, http.MethodPost, "/mfa/totp/enroll", "")
+	err := handler.EnrollTOTP(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.MFAEnrollResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.NotEmpty(t, res.Secret)
+	assert.Contains(t, res.OTPAuthURI, "otpauth://totp/")
+}
+
+func TestEnrollTOTP_AlreadyEnabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Enabled: true}, nil)
+
+	c, _ := newMFAContext(t, http.MethodPost, "/mfa/totp/enroll", "")
+	err := handler.EnrollTOTP(c)
+
+	assertProblem(t, c, err, http.StatusConflict)
+}
+
+func TestDisableTOTP_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.GenerateTOTPCode(secret, time.Now())
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.workspaceRepo.EXPECT().IsMFARequiredForUser(gomock.Any(), 1).Return(false, nil)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Secret: secret, Enabled: true}, nil)
+	m.mfaRepo.EXPECT().MarkStepUsed(gomock.Any(), 1, gomock.Any()).Return(true, nil)
+	m.mfaRepo.EXPECT().Delete(gomock.Any(), 1).Return(nil)
+
+	c, rec := newMFAContext(t, http.MethodDelete, "/mfa/totp", fmt.Sprintf(`{"code":%q}`, code))
+	err := handler.DisableTOTP(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestDisableTOTP_RequiredByWorkspace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.workspaceRepo.EXPECT().IsMFARequiredForUser(gomock.Any(), 1).Return(true, nil)
+
+	c, _ := newMFAContext(t, http.MethodDelete, "/mfa/totp", `{"code":"123456"}`)
+	err := handler.DisableTOTP(c)
+
+	assertProblem(t, c, err, http.StatusForbidden)
+}
+
+func TestMFAHandler_ConfirmCodeRateLimited(t *testing.T) {
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.GenerateTOTPCode(secret, time.Now())
+
+	for _, tt := range []struct {
+		name   string
+		method string
+		path   string
+		call   func(h MFAHandlerInterface, c echo.Context) error
+	}{
+		{"DisableTOTP", http.MethodDelete, "/mfa/totp", MFAHandlerInterface.DisableTOTP},
+		{"RegenerateRecoveryCodes", http.MethodPost, "/mfa/recovery-codes", MFAHandlerInterface.RegenerateRecoveryCodes},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			handler, m := newMFATestHandler(t, ctrl)
+			m.workspaceRepo.EXPECT().IsMFARequiredForUser(gomock.Any(), 1).Return(false, nil).AnyTimes()
+			m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Secret: secret, Enabled: true}, nil).AnyTimes()
+			m.auditRepo.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+			// FreeAttempts を超えて失敗するとバックオフが始まる
+			free := ratelimit.DefaultConfig().FreeAttempts
+			for i := 0; i <= free; i++ {
+				c, _ := newMFAContext(t, tt.method, tt.path, `{"code":"000000x"}`)
+				assertProblem(t, c, tt.call(handler, c), http.StatusBadRequest)
+			}
+
+			// バックオフ中は正しいコードでも検証しない
+			c, rec := newMFAContext(t, tt.method, tt.path, fmt.Sprintf(`{"code":%q}`, code))
+			assertProblem(t, c, tt.call(handler, c), http.StatusTooManyRequests)
+			assert.NotEmpty(t, rec.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestRegenerateRecoveryCodes_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	secret, _ := utils.GenerateTOTPSecret()
+	code, _ := utils.GenerateTOTPCode(secret, time.Now())
+
+	handler, m := newMFATestHandler(t, ctrl)
+	m.mfaRepo.EXPECT().FindByUserID(gomock.Any(), 1).Return(&model.UserMFA{UserID: 1, Secret: secret, Enabled: true}, nil)
+	m.mfaRepo.EXPECT().MarkStepUsed(gomock.Any(), 1, gomock.Any()).Return(true, nil)
+	m.mfaRepo.EXPECT().ReplaceRecoveryCodes(gomock.Any(), 1, gomock.Len(recoveryCodeCount)).Return(nil)
+
+	c, rec := newMFAContext(t, http.MethodPost, "/mfa/recovery-codes", fmt.Sprintf(`{"code":%q}`, code))
+	err := handler.RegenerateRecoveryCodes(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.RecoveryCodesResponse
+	json.Unmarshal(rec.Body.Bytes(), &res)
+	assert.Len(t, res.RecoveryCodes, recoveryCodeCount)
+}
@@ -0,0 +1,139 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: admin_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=admin_handler.go -destination=mock/mock_admin_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAdminHandlerInterface is a mock of AdminHandlerInterface interface.
+type MockAdminHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminHandlerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockAdminHandlerInterfaceMockRecorder is the mock recorder for MockAdminHandlerInterface.
+type MockAdminHandlerInterfaceMockRecorder struct {
+	mock *MockAdminHandlerInterface
+}
+
+// NewMockAdminHandlerInterface creates a new mock instance.
+func NewMockAdminHandlerInterface(ctrl *gomock.Controller) *MockAdminHandlerInterface {
+	mock := &MockAdminHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockAdminHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminHandlerInterface) EXPECT() *MockAdminHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// DeactivateUser mocks base method.
+func (m *MockAdminHandlerInterface) DeactivateUser(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockAdminHandlerInterfaceMockRecorder) DeactivateUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockAdminHandlerInterface)(nil).DeactivateUser), c)
+}
+
+// ForceLogout mocks base method.
+func (m *MockAdminHandlerInterface) ForceLogout(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceLogout", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceLogout indicates an expected call of ForceLogout.
+func (mr *MockAdminHandlerInterfaceMockRecorder) ForceLogout(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceLogout", reflect.TypeOf((*MockAdminHandlerInterface)(nil).ForceLogout), c)
+}
+
+// GetStats mocks base method.
+func (m *MockAdminHandlerInterface) GetStats(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockAdminHandlerInterfaceMockRecorder) GetStats(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockAdminHandlerInterface)(nil).GetStats), c)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockAdminHandlerInterface) ReactivateUser(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockAdminHandlerInterfaceMockRecorder) ReactivateUser(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockAdminHandlerInterface)(nil).ReactivateUser), c)
+}
+
+// ResetMFA mocks base method.
+func (m *MockAdminHandlerInterface) ResetMFA(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetMFA", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetMFA indicates an expected call of ResetMFA.
+func (mr *MockAdminHandlerInterfaceMockRecorder) ResetMFA(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetMFA", reflect.TypeOf((*MockAdminHandlerInterface)(nil).ResetMFA), c)
+}
+
+// SearchUsers mocks base method.
+func (m *MockAdminHandlerInterface) SearchUsers(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchUsers", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SearchUsers indicates an expected call of SearchUsers.
+func (mr *MockAdminHandlerInterfaceMockRecorder) SearchUsers(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchUsers", reflect.TypeOf((*MockAdminHandlerInterface)(nil).SearchUsers), c)
+}
+
+// UpdateUserRole mocks base method.
+func (m *MockAdminHandlerInterface) UpdateUserRole(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockAdminHandlerInterfaceMockRecorder) UpdateUserRole(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockAdminHandlerInterface)(nil).UpdateUserRole), c)
+}
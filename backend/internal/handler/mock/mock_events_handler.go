@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: events_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=events_handler.go -destination=mock/mock_events_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockEventsHandlerInterface is a mock of EventsHandlerInterface interface.
+type MockEventsHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockEventsHandlerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockEventsHandlerInterfaceMockRecorder is the mock recorder for MockEventsHandlerInterface.
+type MockEventsHandlerInterfaceMockRecorder struct {
+	mock *MockEventsHandlerInterface
+}
+
+// NewMockEventsHandlerInterface creates a new mock instance.
+func NewMockEventsHandlerInterface(ctrl *gomock.Controller) *MockEventsHandlerInterface {
+	mock := &MockEventsHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockEventsHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockEventsHandlerInterface) EXPECT() *MockEventsHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateTicket mocks base method.
+func (m *MockEventsHandlerInterface) CreateTicket(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTicket", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTicket indicates an expected call of CreateTicket.
+func (mr *MockEventsHandlerInterfaceMockRecorder) CreateTicket(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTicket", reflect.TypeOf((*MockEventsHandlerInterface)(nil).CreateTicket), c)
+}
+
+// StreamEvents mocks base method.
+func (m *MockEventsHandlerInterface) StreamEvents(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamEvents", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamEvents indicates an expected call of StreamEvents.
+func (mr *MockEventsHandlerInterfaceMockRecorder) StreamEvents(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamEvents", reflect.TypeOf((*MockEventsHandlerInterface)(nil).StreamEvents), c)
+}
+
+// StreamEventsWebSocket mocks base method.
+func (m *MockEventsHandlerInterface) StreamEventsWebSocket(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StreamEventsWebSocket", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StreamEventsWebSocket indicates an expected call of StreamEventsWebSocket.
+func (mr *MockEventsHandlerInterfaceMockRecorder) StreamEventsWebSocket(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamEventsWebSocket", reflect.TypeOf((*MockEventsHandlerInterface)(nil).StreamEventsWebSocket), c)
+}
@@ -0,0 +1,69 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: graphql_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=graphql_handler.go -destination=mock/mock_graphql_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockGraphQLHandlerInterface is a mock of GraphQLHandlerInterface interface.
+type MockGraphQLHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockGraphQLHandlerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockGraphQLHandlerInterfaceMockRecorder is the mock recorder for MockGraphQLHandlerInterface.
+type MockGraphQLHandlerInterfaceMockRecorder struct {
+	mock *MockGraphQLHandlerInterface
+}
+
+// NewMockGraphQLHandlerInterface creates a new mock instance.
+func NewMockGraphQLHandlerInterface(ctrl *gomock.Controller) *MockGraphQLHandlerInterface {
+	mock := &MockGraphQLHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockGraphQLHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGraphQLHandlerInterface) EXPECT() *MockGraphQLHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetSchema mocks base method.
+func (m *MockGraphQLHandlerInterface) GetSchema(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSchema", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetSchema indicates an expected call of GetSchema.
+func (mr *MockGraphQLHandlerInterfaceMockRecorder) GetSchema(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSchema", reflect.TypeOf((*MockGraphQLHandlerInterface)(nil).GetSchema), c)
+}
+
+// Query mocks base method.
+func (m *MockGraphQLHandlerInterface) Query(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Query", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Query indicates an expected call of Query.
+func (mr *MockGraphQLHandlerInterfaceMockRecorder) Query(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Query", reflect.TypeOf((*MockGraphQLHandlerInterface)(nil).Query), c)
+}
@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: jwks_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=jwks_handler.go -destination=mock/mock_jwks_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockJWKSHandlerInterface is a mock of JWKSHandlerInterface interface.
+type MockJWKSHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockJWKSHandlerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockJWKSHandlerInterfaceMockRecorder is the mock recorder for MockJWKSHandlerInterface.
+type MockJWKSHandlerInterfaceMockRecorder struct {
+	mock *MockJWKSHandlerInterface
+}
+
+// NewMockJWKSHandlerInterface creates a new mock instance.
+func NewMockJWKSHandlerInterface(ctrl *gomock.Controller) *MockJWKSHandlerInterface {
+	mock := &MockJWKSHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockJWKSHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockJWKSHandlerInterface) EXPECT() *MockJWKSHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetJWKS mocks base method.
+func (m *MockJWKSHandlerInterface) GetJWKS(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockJWKSHandlerInterfaceMockRecorder) GetJWKS(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockJWKSHandlerInterface)(nil).GetJWKS), c)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: mfa_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=mfa_handler.go -destination=mock/mock_mfa_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockMFAHandlerInterface is a mock of MFAHandlerInterface interface.
+type MockMFAHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockMFAHandlerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockMFAHandlerInterfaceMockRecorder is the mock recorder for MockMFAHandlerInterface.
+type MockMFAHandlerInterfaceMockRecorder struct {
+	mock *MockMFAHandlerInterface
+}
+
+// NewMockMFAHandlerInterface creates a new mock instance.
+func NewMockMFAHandlerInterface(ctrl *gomock.Controller) *MockMFAHandlerInterface {
+	mock := &MockMFAHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockMFAHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMFAHandlerInterface) EXPECT() *MockMFAHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockMFAHandlerInterface) ConfirmTOTP(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockMFAHandlerInterfaceMockRecorder) ConfirmTOTP(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockMFAHandlerInterface)(nil).ConfirmTOTP), c)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockMFAHandlerInterface) DisableTOTP(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockMFAHandlerInterfaceMockRecorder) DisableTOTP(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockMFAHandlerInterface)(nil).DisableTOTP), c)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockMFAHandlerInterface) EnrollTOTP(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockMFAHandlerInterfaceMockRecorder) EnrollTOTP(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockMFAHandlerInterface)(nil).EnrollTOTP), c)
+}
+
+// RegenerateRecoveryCodes mocks base method.
+func (m *MockMFAHandlerInterface) RegenerateRecoveryCodes(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateRecoveryCodes", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegenerateRecoveryCodes indicates an expected call of RegenerateRecoveryCodes.
+func (mr *MockMFAHandlerInterfaceMockRecorder) RegenerateRecoveryCodes(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateRecoveryCodes", reflect.TypeOf((*MockMFAHandlerInterface)(nil).RegenerateRecoveryCodes), c)
+}
+
+// VerifyLogin mocks base method.
+func (m *MockMFAHandlerInterface) VerifyLogin(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyLogin", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyLogin indicates an expected call of VerifyLogin.
+func (mr *MockMFAHandlerInterfaceMockRecorder) VerifyLogin(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyLogin", reflect.TypeOf((*MockMFAHandlerInterface)(nil).VerifyLogin), c)
+}
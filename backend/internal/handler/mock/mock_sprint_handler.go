@@ -96,6 +96,20 @@ func (mr *MockSprintHandlerInterfaceMockRecorder) SearchSprints(c any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSprints", reflect.TypeOf((*MockSprintHandlerInterface)(nil).SearchSprints), c)
 }
 
+// UpdateFavorite mocks base method.
+func (m *MockSprintHandlerInterface) UpdateFavorite(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFavorite", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateFavorite indicates an expected call of UpdateFavorite.
+func (mr *MockSprintHandlerInterfaceMockRecorder) UpdateFavorite(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorite", reflect.TypeOf((*MockSprintHandlerInterface)(nil).UpdateFavorite), c)
+}
+
 // UpdateSprint mocks base method.
 func (m *MockSprintHandlerInterface) UpdateSprint(c echo.Context) error {
 	m.ctrl.T.Helper()
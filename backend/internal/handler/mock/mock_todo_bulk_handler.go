@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: todo_bulk_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=todo_bulk_handler.go -destination=mock/mock_todo_bulk_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTodoBulkHandlerInterface is a mock of TodoBulkHandlerInterface interface.
+type MockTodoBulkHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoBulkHandlerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockTodoBulkHandlerInterfaceMockRecorder is the mock recorder for MockTodoBulkHandlerInterface.
+type MockTodoBulkHandlerInterfaceMockRecorder struct {
+	mock *MockTodoBulkHandlerInterface
+}
+
+// NewMockTodoBulkHandlerInterface creates a new mock instance.
+func NewMockTodoBulkHandlerInterface(ctrl *gomock.Controller) *MockTodoBulkHandlerInterface {
+	mock := &MockTodoBulkHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockTodoBulkHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoBulkHandlerInterface) EXPECT() *MockTodoBulkHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BulkTodos mocks base method.
+func (m *MockTodoBulkHandlerInterface) BulkTodos(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkTodos", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkTodos indicates an expected call of BulkTodos.
+func (mr *MockTodoBulkHandlerInterfaceMockRecorder) BulkTodos(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkTodos", reflect.TypeOf((*MockTodoBulkHandlerInterface)(nil).BulkTodos), c)
+}
@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workspace_handler.go
+//
+// Generated by this command:
+//
+//	mockgen -source=workspace_handler.go -destination=mock/mock_workspace_handler.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	reflect "reflect"
+
+	echo "github.com/labstack/echo/v4"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWorkspaceHandlerInterface is a mock of WorkspaceHandlerInterface interface.
+type MockWorkspaceHandlerInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceHandlerInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceHandlerInterfaceMockRecorder is the mock recorder for MockWorkspaceHandlerInterface.
+type MockWorkspaceHandlerInterfaceMockRecorder struct {
+	mock *MockWorkspaceHandlerInterface
+}
+
+// NewMockWorkspaceHandlerInterface creates a new mock instance.
+func NewMockWorkspaceHandlerInterface(ctrl *gomock.Controller) *MockWorkspaceHandlerInterface {
+	mock := &MockWorkspaceHandlerInterface{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceHandlerInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceHandlerInterface) EXPECT() *MockWorkspaceHandlerInterfaceMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockWorkspaceHandlerInterface) AddMember(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockWorkspaceHandlerInterfaceMockRecorder) AddMember(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockWorkspaceHandlerInterface)(nil).AddMember), c)
+}
+
+// CreateWorkspace mocks base method.
+func (m *MockWorkspaceHandlerInterface) CreateWorkspace(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWorkspace", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWorkspace indicates an expected call of CreateWorkspace.
+func (mr *MockWorkspaceHandlerInterfaceMockRecorder) CreateWorkspace(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWorkspace", reflect.TypeOf((*MockWorkspaceHandlerInterface)(nil).CreateWorkspace), c)
+}
+
+// GetWorkspaces mocks base method.
+func (m *MockWorkspaceHandlerInterface) GetWorkspaces(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkspaces", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetWorkspaces indicates an expected call of GetWorkspaces.
+func (mr *MockWorkspaceHandlerInterfaceMockRecorder) GetWorkspaces(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkspaces", reflect.TypeOf((*MockWorkspaceHandlerInterface)(nil).GetWorkspaces), c)
+}
+
+// UpdateMFARequirement mocks base method.
+func (m *MockWorkspaceHandlerInterface) UpdateMFARequirement(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMFARequirement", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMFARequirement indicates an expected call of UpdateMFARequirement.
+func (mr *MockWorkspaceHandlerInterfaceMockRecorder) UpdateMFARequirement(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMFARequirement", reflect.TypeOf((*MockWorkspaceHandlerInterface)(nil).UpdateMFARequirement), c)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspaceHandlerInterface) UpdateMemberRole(c echo.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", c)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceHandlerInterfaceMockRecorder) UpdateMemberRole(c any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspaceHandlerInterface)(nil).UpdateMemberRole), c)
+}
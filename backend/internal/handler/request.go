@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"context"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+// contextValidator はリクエストのコンテキストで存在確認などを行う Validator
+type contextValidator interface {
+	ValidateContext(ctx context.Context, i interface{}) error
+}
+
+// bindRequest はリクエストボディを v にバインドし、validate タグで検証する
+func bindRequest(c echo.Context, v any) error {
+	if err := bindBody(c, v); err != nil {
+		return err
+	}
+	return validateRequest(c, v)
+}
+
+// bindBody はリクエストボディを v にバインドする（検証の前に値を整える場合に使う）
+func bindBody(c echo.Context, v any) error {
+	if err := c.Bind(v); err != nil {
+		return apperror.BadRequest(apperror.CodeInvalidInput, "Invalid input")
+	}
+	return nil
+}
+
+// validateRequest は e.Validator で v を検証する
+func validateRequest(c echo.Context, v any) error {
+	if cv, ok := c.Echo().Validator.(contextValidator); ok {
+		return cv.ValidateContext(c.Request().Context(), v)
+	}
+	return c.Validate(v)
+}
+
+// pathID はパスパラメータ :id を数値で返す
+func pathID(c echo.Context) (int, error) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return 0, apperror.BadRequest(apperror.CodeInvalidID, "Invalid ID parameter")
+	}
+	return id, nil
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/validation"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSprintID は newTestEcho の Validator で存在するとみなすスプリントのID
+const testSprintID = 1
+
+// newTestEcho は main と同じ Validator を設定した echo を返す（スプリントは testSprintID のみ存在する）
+func newTestEcho() *echo.Echo {
+	e := echo.New()
+	e.Validator = newTestValidator(func(ctx context.Context, id int) (bool, error) {
+		return id == testSprintID, nil
+	})
+	return e
+}
+
+func newTestValidator(sprintExists validation.ExistsFunc) *validation.Validator {
+	v := validation.NewValidator(validation.DefaultPasswordPolicy())
+	v.RegisterExists("sprint", sprintExists)
+	return v
+}
+
+// assertProblem はハンドラーが返したエラーを ErrorHandler でレスポンスに変換し、ステータスを検証する
+func assertProblem(t *testing.T, c echo.Context, err error, status int) *model.Problem {
+	t.Helper()
+	require.Error(t, err)
+
+	middleware.ErrorHandler(err, c)
+	rec := c.Response().Writer.(*httptest.ResponseRecorder)
+	require.Equal(t, status, rec.Code)
+	assert.Equal(t, middleware.MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+
+	var problem model.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	return &problem
+}
+
+func TestPathID(t *testing.T) {
+	e := newTestEcho()
+	c := e.NewContext(httptest.NewRequest(http.MethodGet, "/todos/42", nil), httptest.NewRecorder())
+	c.SetParamNames("id")
+	c.SetParamValues("42")
+
+	id, err := pathID(c)
+	require.NoError(t, err)
+	assert.Equal(t, 42, id)
+
+	c.SetParamValues("abc")
+	_, err = pathID(c)
+	problem := assertProblem(t, c, err, http.StatusBadRequest)
+	assert.Equal(t, apperror.CodeInvalidID, problem.Code)
+}
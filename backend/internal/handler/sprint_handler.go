@@ -5,7 +5,7 @@ package handler
 import (
 	"backend/internal/model"
 	"backend/internal/repository"
-	"strconv"
+	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
@@ -29,37 +29,49 @@ func NewSprintHandler(repo repository.SprintRepository) SprintHandlerInterface {
 
 // GetSprints godoc
 // @Summary スプリントリストを取得
-// @Description すべてのスプリントを取得します
+// @Description すべてのスプリントを取得します。If-None-Match が ETag と一致すれば 304 を返します
 // @Tags sprints
 // @Accept json
 // @Produce json
+// @Param If-None-Match header string false "前回のレスポンスの ETag"
 // @Success 200 {array} model.Sprint
-// @Failure 500 {object} map[string]string
+// @Header 200 {string} ETag "一覧の ETag"
+// @Success 304
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /sprints [get]
 func (h *SprintHandler) GetSprints(c echo.Context) error {
-	sprints, err := h.repo.FindAll()
+	sprints, err := h.repo.FindAll(c.Request().Context())
 	if err != nil {
-		return c.JSON(500, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	return c.JSON(200, sprints)
+	return jsonWithETag(c, http.StatusOK, sprints)
 }
 
 // CreateSprint godoc
 // @Summary スプリントを作成
-// @Description 新しいスプリントを作成します
+// @Description 新しいスプリントを作成します。Idempotency-Key を指定すると、同じキーの再送では作成せずに最初のレスポンスを返します
 // @Tags sprints
 // @Accept json
 // @Produce json
 // @Param sprint body model.Sprint true "スプリント情報"
+// @Param Idempotency-Key header string false "再送で重複して作成しないためのキー（同じキーの再送には最初のレスポンスを返す）"
 // @Success 201 {object} model.Sprint
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Header 201 {string} ETag "作成したスプリントのバージョン"
+// @Header 201 {string} Idempotent-Replayed "保存したレスポンスを返した場合は true"
+// @Failure 400 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /sprints [post]
 func (h *SprintHandler) CreateSprint(c echo.Context) error {
 	s := new(model.Sprint)
-	if err := c.Bind(s); err != nil {
-		return c.JSON(400, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, s); err != nil {
+		return err
 	}
 
 	// デフォルト値を設定
@@ -67,12 +79,13 @@ func (h *SprintHandler) CreateSprint(c echo.Context) error {
 		s.Color = "bg-purple-500"
 	}
 
-	createdSprint, err := h.repo.Create(s.Name, s.Color, s.IsFavorite)
+	createdSprint, err := h.repo.Create(c.Request().Context(), s.Name, s.Color, s.IsFavorite)
 	if err != nil {
-		return c.JSON(500, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	return c.JSON(201, createdSprint)
+	setVersionETag(c, createdSprint.Version)
+	return c.JSON(http.StatusCreated, createdSprint)
 }
 
 // SearchSprints godoc
@@ -83,121 +96,145 @@ func (h *SprintHandler) CreateSprint(c echo.Context) error {
 // @Produce json
 // @Param request body model.SprintSearchRequest true "検索条件"
 // @Success 200 {array} model.Sprint
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /sprints/search [post]
 func (h *SprintHandler) SearchSprints(c echo.Context) error {
 	req := new(model.SprintSearchRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(400, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, req); err != nil {
+		return err
 	}
 
-	sprints, err := h.repo.Search(req)
+	sprints, err := h.repo.Search(c.Request().Context(), req)
 	if err != nil {
-		return c.JSON(500, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	return c.JSON(200, sprints)
+	return c.JSON(http.StatusOK, sprints)
 }
 
 // UpdateSprint godoc
 // @Summary スプリントを更新
-// @Description 指定されたIDのスプリントを更新します
+// @Description 指定されたIDのスプリントを更新し、更新後のスプリントを返します。If-Match を指定すると、バージョンが一致する場合のみ更新します
 // @Tags sprints
 // @Accept json
 // @Produce json
 // @Param id path int true "スプリント ID"
+// @Param If-Match header string false "スプリントのバージョン（取得時の version を引用符で囲んだ ETag）"
 // @Param sprint body model.Sprint true "更新内容"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} model.Sprint
+// @Header 200 {string} ETag "更新後のバージョン"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /sprints/{id} [put]
 func (h *SprintHandler) UpdateSprint(c echo.Context) error {
-	// パスパラメータからIDを取得
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	id, err := pathID(c)
 	if err != nil {
-		return c.JSON(400, map[string]string{"error": "Invalid ID parameter"})
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
 	}
 
 	// ボディから更新内容を取得
 	s := new(model.Sprint)
-	if err := c.Bind(s); err != nil {
-		return c.JSON(400, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, s); err != nil {
+		return err
 	}
 
-	rowsAffected, message, err := h.repo.Update(id, s.Name, s.Color)
+	updated, err := h.repo.Update(c.Request().Context(), id, s.Name, s.Color, version)
 	if err != nil {
-		return c.JSON(500, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	if rowsAffected == 0 {
-		return c.JSON(404, map[string]string{"error": "Sprint not found"})
-	}
-
-	return c.JSON(200, map[string]interface{}{
-		"rows_affected": rowsAffected,
-		"message":       message,
-	})
+	setVersionETag(c, updated.Version)
+	return c.JSON(http.StatusOK, updated)
 }
 
 // UpdateFavorite godoc
 // @Summary お気に入り状態を更新
-// @Description 指定されたIDのスプリントのお気に入り状態を切り替えます
+// @Description 指定されたIDのスプリントのお気に入り状態を切り替えます。If-Match を指定すると、バージョンが一致する場合のみ更新します
 // @Tags sprints
 // @Accept json
 // @Produce json
 // @Param id path int true "スプリント ID"
+// @Param If-Match header string false "スプリントのバージョン（取得時の version を引用符で囲んだ ETag）"
 // @Param request body model.UpdateFavoriteRequest true "お気に入り状態"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Header 200 {string} ETag "更新後のバージョン"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /sprints/{id}/favorite [put]
 func (h *SprintHandler) UpdateFavorite(c echo.Context) error {
-	// パスパラメータからIDを取得
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	id, err := pathID(c)
 	if err != nil {
-		return c.JSON(400, map[string]string{"error": "Invalid ID parameter"})
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
 	}
 
 	// ボディから更新内容を取得
 	req := new(model.UpdateFavoriteRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(400, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, req); err != nil {
+		return err
 	}
 
-	err = h.repo.UpdateFavorite(id, req.IsFavorite)
+	updated, err := h.repo.UpdateFavorite(c.Request().Context(), id, req.IsFavorite, version)
 	if err != nil {
-		return c.JSON(500, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	return c.JSON(200, map[string]string{"message": "Favorite status updated successfully"})
+	setVersionETag(c, updated.Version)
+	return c.JSON(http.StatusOK, map[string]string{"message": "Favorite status updated successfully"})
 }
 
 // DeleteSprint godoc
 // @Summary スプリントを削除
-// @Description 指定されたIDのスプリントを論理削除します
+// @Description 指定されたIDのスプリントを論理削除します。If-Match を指定すると、バージョンが一致する場合のみ削除します
 // @Tags sprints
 // @Accept json
 // @Produce json
 // @Param id path int true "スプリント ID"
+// @Param If-Match header string false "スプリントのバージョン（取得時の version を引用符で囲んだ ETag）"
 // @Success 200 {object} map[string]string
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /sprints/{id} [delete]
 func (h *SprintHandler) DeleteSprint(c echo.Context) error {
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		return c.JSON(400, map[string]string{"error": "Invalid sprint ID"})
+		return err
 	}
 
-	err = h.repo.Delete(id)
+	err = h.repo.Delete(c.Request().Context(), id, version)
 	if err != nil {
-		return c.JSON(500, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	return c.JSON(200, map[string]string{"message": "Sprint deleted successfully"})
+	return c.JSON(http.StatusOK, map[string]string{"message": "Sprint deleted successfully"})
 }
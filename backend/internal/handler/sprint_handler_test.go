@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"backend/internal/apperror"
 	"backend/internal/model"
+	"backend/internal/repository"
 	"backend/internal/repository/mock"
 	"backend/internal/types"
 	"encoding/json"
@@ -21,13 +23,13 @@ func TestGetSprints_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/sprints", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
-	mockRepo.EXPECT().FindAll().Return([]model.Sprint{
+	mockRepo.EXPECT().FindAll(gomock.Any()).Return([]model.Sprint{
 		{
 			ID:         1,
 			Name:       "Sprint 1",
@@ -54,34 +56,33 @@ func TestGetSprints_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/sprints", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
-	mockRepo.EXPECT().FindAll().Return(nil, errors.New("database error"))
+	mockRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("database error"))
 
 	handler := NewSprintHandler(mockRepo)
 	err := handler.GetSprints(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assertProblem(t, c, err, http.StatusInternalServerError)
 }
 
 func TestCreateSprint_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	sprintJSON := `{"name":"New Sprint","color":"bg-blue-500","is_favorite":false}`
 	req := httptest.NewRequest(http.MethodPost, "/sprints", strings.NewReader(sprintJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
-	mockRepo.EXPECT().Create("New Sprint", "bg-blue-500", false).Return(&model.Sprint{
+	mockRepo.EXPECT().Create(gomock.Any(), "New Sprint", "bg-blue-500", false).Return(&model.Sprint{
 		ID:         1,
 		Name:       "New Sprint",
 		Color:      "bg-blue-500",
@@ -105,54 +106,80 @@ func TestCreateSprint_InvalidInput(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	invalidJSON := `{invalid json}`
 	req := httptest.NewRequest(http.MethodPost, "/sprints", strings.NewReader(invalidJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
 	handler := NewSprintHandler(mockRepo)
 	err := handler.CreateSprint(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertProblem(t, c, err, http.StatusBadRequest)
+}
+
+func TestCreateSprint_InvalidColor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	sprintJSON := `{"name":"New Sprint","color":"purple"}`
+	req := httptest.NewRequest(http.MethodPost, "/sprints", strings.NewReader(sprintJSON))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	mockRepo := mock.NewMockSprintRepository(ctrl)
+	handler := NewSprintHandler(mockRepo)
+	err := handler.CreateSprint(c)
+
+	problem := assertProblem(t, c, err, http.StatusUnprocessableEntity)
+	if assert.Len(t, problem.Errors, 1) {
+		assert.Equal(t, "color", problem.Errors[0].Field)
+		assert.Equal(t, "invalid_format", problem.Errors[0].Code)
+	}
 }
 
 func TestUpdateSprint_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	sprintJSON := `{"name":"Updated Sprint","color":"bg-green-500"}`
 	req := httptest.NewRequest(http.MethodPut, "/sprints/1", strings.NewReader(sprintJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
-	mockRepo.EXPECT().Update(1, "Updated Sprint", "bg-green-500").Return(1, "Sprint updated successfully", nil)
+	mockRepo.EXPECT().Update(gomock.Any(), 1, "Updated Sprint", "bg-green-500", repository.AnyVersion).Return(&model.Sprint{ID: 1, Name: "Updated Sprint", Color: "bg-green-500", Version: 2, CreatedAt: testTime, UpdatedAt: testTime}, nil)
 
 	handler := NewSprintHandler(mockRepo)
 	err := handler.UpdateSprint(c)
 
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var sprint model.Sprint
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &sprint))
+	assert.Equal(t, "Updated Sprint", sprint.Name)
+	assert.Equal(t, 2, sprint.Version)
 }
 
 func TestUpdateSprint_InvalidID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	sprintJSON := `{"name":"Updated Sprint","color":"bg-blue-500"}`
 	req := httptest.NewRequest(http.MethodPut, "/sprints/invalid", strings.NewReader(sprintJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("invalid")
 
@@ -160,46 +187,69 @@ func TestUpdateSprint_InvalidID(t *testing.T) {
 	handler := NewSprintHandler(mockRepo)
 	err := handler.UpdateSprint(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertProblem(t, c, err, http.StatusBadRequest)
 }
 
 func TestUpdateSprint_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	sprintJSON := `{"name":"Updated Sprint","color":"bg-blue-500"}`
 	req := httptest.NewRequest(http.MethodPut, "/sprints/999", strings.NewReader(sprintJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("999")
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
-	mockRepo.EXPECT().Update(999, "Updated Sprint", "bg-blue-500").Return(0, "", nil)
+	mockRepo.EXPECT().Update(gomock.Any(), 999, "Updated Sprint", "bg-blue-500", repository.AnyVersion).Return(nil, repository.ErrSprintNotFound)
 
 	handler := NewSprintHandler(mockRepo)
 	err := handler.UpdateSprint(c)
 
+	problem := assertProblem(t, c, err, http.StatusNotFound)
+	assert.Equal(t, apperror.CodeSprintNotFound, problem.Code)
+}
+
+func TestUpdateFavorite_Success(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodPut, "/sprints/1/favorite", strings.NewReader(`{"is_favorite":true}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(headerIfMatch, `"2"`)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockRepo := mock.NewMockSprintRepository(ctrl)
+	mockRepo.EXPECT().UpdateFavorite(gomock.Any(), 1, true, 2).Return(&model.Sprint{ID: 1, Name: "Sprint", IsFavorite: true, Version: 3}, nil)
+
+	handler := NewSprintHandler(mockRepo)
+	err := handler.UpdateFavorite(c)
+
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"3"`, rec.Header().Get(headerETag))
 }
 
 func TestDeleteSprint_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodDelete, "/sprints/1", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
-	mockRepo.EXPECT().Delete(1).Return(nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, repository.AnyVersion).Return(nil)
 
 	handler := NewSprintHandler(mockRepo)
 	err := handler.DeleteSprint(c)
@@ -212,10 +262,10 @@ func TestDeleteSprint_InvalidID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodDelete, "/sprints/invalid", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("invalid")
 
@@ -223,24 +273,23 @@ func TestDeleteSprint_InvalidID(t *testing.T) {
 	handler := NewSprintHandler(mockRepo)
 	err := handler.DeleteSprint(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertProblem(t, c, err, http.StatusBadRequest)
 }
 
 func TestSearchSprints_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	searchJSON := `{"name":"sprint"}`
 	req := httptest.NewRequest(http.MethodPost, "/sprints/search", strings.NewReader(searchJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	name := "sprint"
 	mockRepo := mock.NewMockSprintRepository(ctrl)
-	mockRepo.EXPECT().Search(&model.SprintSearchRequest{
+	mockRepo.EXPECT().Search(gomock.Any(), &model.SprintSearchRequest{
 		Name: &name,
 	}).Return([]model.Sprint{
 		{
@@ -268,17 +317,16 @@ func TestSearchSprints_InvalidInput(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	invalidJSON := `{invalid json}`
 	req := httptest.NewRequest(http.MethodPost, "/sprints/search", strings.NewReader(invalidJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockSprintRepository(ctrl)
 	handler := NewSprintHandler(mockRepo)
 	err := handler.SearchSprints(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertProblem(t, c, err, http.StatusBadRequest)
 }
@@ -0,0 +1,236 @@
+package handler
+
+//go:generate mockgen -source=todo_bulk_handler.go -destination=mock/mock_todo_bulk_handler.go -package=mock
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MaxBulkTodoOperations は1回の一括操作で受け付ける操作の上限
+const MaxBulkTodoOperations = 100
+
+type TodoBulkHandlerInterface interface {
+	BulkTodos(c echo.Context) error
+}
+
+type TodoBulkHandler struct {
+	uow repository.UnitOfWork
+}
+
+func NewTodoBulkHandler(uow repository.UnitOfWork) TodoBulkHandlerInterface {
+	return &TodoBulkHandler{uow: uow}
+}
+
+// BulkTodos godoc
+// @Summary TODOを一括操作
+// @Description 複数のTODOの作成・更新・完了・スプリントへの移動・削除をまとめて実行します（最大100件）。
+// @Description mode が atomic（デフォルト）なら全ての操作を1つのトランザクションで実行し、1件でも失敗すれば全て取り消してその操作のエラーを返します。
+// @Description per_item なら操作ごとに実行し、成功した操作だけを反映して各件の結果を返します
+// @Tags todos
+// @Accept json
+// @Produce json
+// @Param Idempotency-Key header string false "再送で重複して実行しないためのキー（同じキーの再送には最初のレスポンスを返す）"
+// @Param request body model.BulkTodoRequest true "操作の一覧"
+// @Success 200 {object} model.BulkTodoResponse
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /todos/bulk [post]
+func (h *TodoBulkHandler) BulkTodos(c echo.Context) error {
+	req := new(model.BulkTodoRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+	// 空の配列は validate タグの required では検出できない
+	if len(req.Operations) == 0 {
+		return apperror.Validation(model.FieldError{Field: "operations", Code: "required", Message: "Operations is required"})
+	}
+	if len(req.Operations) > MaxBulkTodoOperations {
+		return apperror.Validation(model.FieldError{
+			Field:   "operations",
+			Code:    "too_many",
+			Message: fmt.Sprintf("Operations must contain at most %d items", MaxBulkTodoOperations),
+		})
+	}
+
+	res := &model.BulkTodoResponse{
+		Mode:    req.Mode,
+		Results: make([]model.BulkTodoResult, len(req.Operations)),
+	}
+	if res.Mode == "" {
+		res.Mode = model.BulkModeAtomic
+	}
+
+	var err error
+	if res.Mode == model.BulkModeAtomic {
+		err = h.applyAtomic(c, req.Operations, res)
+	} else {
+		err = h.applyPerItem(c, req.Operations, res)
+	}
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// applyAtomic は全ての操作を検証してから1つのトランザクションで実行する
+func (h *TodoBulkHandler) applyAtomic(c echo.Context, ops []model.BulkTodoOperation, res *model.BulkTodoResponse) error {
+	var fieldErrs []model.FieldError
+	for i := range ops {
+		err := validateBulkOperation(c, &ops[i])
+		if err == nil {
+			continue
+		}
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) || !errors.Is(err, apperror.ErrValidation) {
+			return err
+		}
+		fieldErrs = append(fieldErrs, atIndex(i, appErr).Fields...)
+	}
+	if len(fieldErrs) > 0 {
+		return apperror.Validation(fieldErrs...)
+	}
+
+	ctx := c.Request().Context()
+	err := h.uow.Do(ctx, func(repos *repository.Repositories) error {
+		for i, op := range ops {
+			todo, status, err := applyBulkOperation(ctx, repos.Todos, op)
+			if err != nil {
+				var appErr *apperror.Error
+				if errors.As(err, &appErr) {
+					return atIndex(i, appErr)
+				}
+				return err
+			}
+			res.Results[i] = model.BulkTodoResult{Index: i, Op: op.Op, Status: status, Todo: todo}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	res.Succeeded = len(ops)
+	return nil
+}
+
+// applyPerItem は操作ごとにトランザクションを分けて実行し、失敗した操作はエラーを結果に記録する
+func (h *TodoBulkHandler) applyPerItem(c echo.Context, ops []model.BulkTodoOperation, res *model.BulkTodoResponse) error {
+	ctx := c.Request().Context()
+	for i := range ops {
+		op := ops[i]
+		result := model.BulkTodoResult{Index: i, Op: op.Op}
+
+		err := validateBulkOperation(c, &op)
+		if err == nil {
+			err = h.uow.Do(ctx, func(repos *repository.Repositories) error {
+				var err error
+				result.Todo, result.Status, err = applyBulkOperation(ctx, repos.Todos, op)
+				return err
+			})
+		}
+
+		if err != nil {
+			result.Todo = nil
+			result.Error = middleware.ProblemFor(c, err)
+			result.Status = result.Error.Status
+			res.Failed++
+		} else {
+			res.Succeeded++
+		}
+		res.Results[i] = result
+	}
+	return nil
+}
+
+// validateBulkOperation は validate タグと、op ごとに必須のフィールドを検証する
+func validateBulkOperation(c echo.Context, op *model.BulkTodoOperation) error {
+	var fieldErrs []model.FieldError
+	if err := validateRequest(c, op); err != nil {
+		var appErr *apperror.Error
+		if !errors.As(err, &appErr) || !errors.Is(err, apperror.ErrValidation) {
+			return err
+		}
+		fieldErrs = append(fieldErrs, appErr.Fields...)
+	}
+
+	if op.Op != model.BulkOpCreate && op.ID <= 0 {
+		fieldErrs = append(fieldErrs, model.FieldError{Field: "id", Code: "required", Message: "ID is required"})
+	}
+	if (op.Op == model.BulkOpCreate || op.Op == model.BulkOpUpdate) && !hasField(fieldErrs, "title") && strings.TrimSpace(op.Title) == "" {
+		fieldErrs = append(fieldErrs, model.FieldError{Field: "title", Code: "required", Message: "Title is required"})
+	}
+
+	if len(fieldErrs) > 0 {
+		return apperror.Validation(fieldErrs...)
+	}
+	return nil
+}
+
+// applyBulkOperation は1件の操作を実行し、結果のTODO（delete なら nil）とステータスコードを返す
+func applyBulkOperation(ctx context.Context, repo repository.TodoRepository, op model.BulkTodoOperation) (*model.Todo, int, error) {
+	var todo *model.Todo
+	var err error
+	status := http.StatusOK
+
+	switch op.Op {
+	case model.BulkOpCreate:
+		todo, err = repo.Create(ctx, op.Title, op.Description, op.SprintID)
+		status = http.StatusCreated
+	case model.BulkOpUpdate:
+		todo, err = repo.Update(ctx, op.Title, op.Completed != nil && *op.Completed, op.ID, op.Version)
+	case model.BulkOpComplete:
+		todo, err = repo.SetCompleted(ctx, op.ID, op.Completed == nil || *op.Completed, op.Version)
+	case model.BulkOpMove:
+		todo, err = repo.MoveToSprint(ctx, op.ID, op.SprintID, op.Version)
+	case model.BulkOpDelete:
+		err = repo.Delete(ctx, op.ID, op.Version)
+		status = http.StatusNoContent
+	default:
+		return nil, 0, fmt.Errorf("unknown bulk operation %q", op.Op)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return todo, status, nil
+}
+
+// atIndex は何番目の操作のエラーかわかるよう、メッセージとフィールド名に位置を付ける
+func atIndex(i int, err *apperror.Error) *apperror.Error {
+	prefix := "operations[" + strconv.Itoa(i) + "]"
+
+	copied := *err
+	copied.Message = prefix + ": " + err.Message
+	copied.Fields = nil
+	for _, f := range err.Fields {
+		f.Field = prefix + "." + f.Field
+		copied.Fields = append(copied.Fields, f)
+	}
+	return &copied
+}
+
+func hasField(errs []model.FieldError, field string) bool {
+	for _, e := range errs {
+		if e.Field == field {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newBulkTestServer はインメモリのリポジトリ（スプリント testSprintID と TODO 2件）で /todos/bulk を登録する
+func newBulkTestServer(t *testing.T) (*echo.Echo, *repository.Repositories) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	sprint, err := repos.Sprints.Create(ctx, "Sprint", "bg-purple-500", false)
+	require.NoError(t, err)
+	require.Equal(t, testSprintID, sprint.ID)
+	for _, title := range []string{"First", "Second"} {
+		_, err := repos.Todos.Create(ctx, title, "", nil)
+		require.NoError(t, err)
+	}
+
+	e := newTestEcho()
+	e.HTTPErrorHandler = middleware.ErrorHandler
+	e.POST("/todos/bulk", NewTodoBulkHandler(memory.NewUnitOfWork(repos)).BulkTodos)
+	return e, repos
+}
+
+// postBulk は /todos/bulk を呼び出し、リクエストとレスポンスをAPI仕様と照合する
+func postBulk(t *testing.T, e *echo.Echo, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/todos/bulk", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assertContract(t, req, []byte(body), rec)
+	return rec
+}
+
+func findTodos(t *testing.T, repos *repository.Repositories) map[int]model.Todo {
+	todos, err := repos.Todos.FindAll(context.Background())
+	require.NoError(t, err)
+	byID := map[int]model.Todo{}
+	for _, todo := range todos {
+		byID[todo.ID] = todo
+	}
+	return byID
+}
+
+func TestBulkTodos_Atomic(t *testing.T) {
+	e, repos := newBulkTestServer(t)
+
+	rec := postBulk(t, e, `{"operations":[
+		{"op":"create","title":"Third","sprint_id":1},
+		{"op":"update","id":1,"title":"First (renamed)","version":1},
+		{"op":"complete","id":1},
+		{"op":"move","id":2,"sprint_id":1},
+		{"op":"delete","id":2}
+	]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var res model.BulkTodoResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, model.BulkModeAtomic, res.Mode)
+	assert.Equal(t, 5, res.Succeeded)
+	assert.Equal(t, 0, res.Failed)
+	require.Len(t, res.Results, 5)
+	assert.Equal(t, http.StatusCreated, res.Results[0].Status)
+	assert.Equal(t, http.StatusNoContent, res.Results[4].Status)
+	assert.Nil(t, res.Results[4].Todo)
+	assert.Equal(t, 3, res.Results[2].Todo.Version)
+
+	todos := findTodos(t, repos)
+	require.Len(t, todos, 2)
+	assert.Equal(t, "First (renamed)", todos[1].Title)
+	assert.True(t, todos[1].Completed)
+	assert.Equal(t, testSprintID, *todos[3].SprintID)
+}
+
+func TestBulkTodos_AtomicRollback(t *testing.T) {
+	e, repos := newBulkTestServer(t)
+
+	// 3件目が失敗すると、1・2件目も取り消される
+	rec := postBulk(t, e, `{"mode":"atomic","operations":[
+		{"op":"create","title":"Third"},
+		{"op":"complete","id":1},
+		{"op":"delete","id":99}
+	]}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"`+apperror.CodeTodoNotFound+`"`)
+	assert.Contains(t, rec.Body.String(), "operations[2]")
+
+	todos := findTodos(t, repos)
+	assert.Len(t, todos, 2)
+	assert.False(t, todos[1].Completed)
+	assert.Equal(t, 1, todos[1].Version)
+
+	// バージョンの不一致も全体を取り消す
+	rec = postBulk(t, e, `{"operations":[{"op":"complete","id":1},{"op":"complete","id":2,"version":5}]}`)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	assert.False(t, findTodos(t, repos)[1].Completed)
+}
+
+func TestBulkTodos_AtomicValidation(t *testing.T) {
+	e, repos := newBulkTestServer(t)
+
+	// 実行前に全ての操作を検証し、エラーをまとめて返す
+	rec := postBulk(t, e, `{"operations":[
+		{"op":"create","title":"Valid"},
+		{"op":"create"},
+		{"op":"rename","id":1},
+		{"op":"move","id":1,"sprint_id":42},
+		{"op":"delete"}
+	]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var problem model.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	var fields []string
+	for _, f := range problem.Errors {
+		fields = append(fields, f.Field+":"+f.Code)
+	}
+	assert.Equal(t, []string{
+		"operations[1].title:required",
+		"operations[2].op:invalid_value",
+		"operations[3].sprint_id:not_found",
+		"operations[4].id:required",
+	}, fields)
+	assert.Len(t, findTodos(t, repos), 2)
+}
+
+func TestBulkTodos_PerItem(t *testing.T) {
+	e, repos := newBulkTestServer(t)
+
+	rec := postBulk(t, e, `{"mode":"per_item","operations":[
+		{"op":"complete","id":1},
+		{"op":"delete","id":99},
+		{"op":"update","id":2},
+		{"op":"complete","id":2,"version":1}
+	]}`)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var res model.BulkTodoResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, 2, res.Succeeded)
+	assert.Equal(t, 2, res.Failed)
+
+	var statuses []int
+	for _, r := range res.Results {
+		statuses = append(statuses, r.Status)
+	}
+	assert.Equal(t, []int{http.StatusOK, http.StatusNotFound, http.StatusUnprocessableEntity, http.StatusOK}, statuses)
+	assert.Equal(t, apperror.CodeTodoNotFound, res.Results[1].Error.Code)
+	assert.Equal(t, "title", res.Results[2].Error.Errors[0].Field)
+	assert.Nil(t, res.Results[0].Error)
+
+	// 成功した操作だけが反映される
+	todos := findTodos(t, repos)
+	assert.True(t, todos[1].Completed)
+	assert.True(t, todos[2].Completed)
+}
+
+func TestBulkTodos_Limits(t *testing.T) {
+	e, _ := newBulkTestServer(t)
+
+	rec := postBulk(t, e, `{"operations":[]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	rec = postBulk(t, e, `{"mode":"sometimes","operations":[{"op":"complete","id":1}]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"mode"`)
+
+	ops := make([]string, MaxBulkTodoOperations+1)
+	for i := range ops {
+		ops[i] = fmt.Sprintf(`{"op":"create","title":"Todo %d"}`, i)
+	}
+	rec = postBulk(t, e, `{"operations":[`+strings.Join(ops, ",")+`]}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"too_many"`)
+}
@@ -6,7 +6,6 @@ import (
 	"backend/internal/model"
 	"backend/internal/repository"
 	"net/http"
-	"strconv"
 
 	"github.com/labstack/echo/v4"
 )
@@ -29,87 +28,103 @@ func NewTodoHandler(repo repository.TodoRepository) TodoHandlerInterface {
 
 // GetTodos godoc
 // @Summary TODOリストを取得
-// @Description すべてのTODOを取得します
+// @Description すべてのTODOを取得します。If-None-Match が ETag と一致すれば 304 を返します
 // @Tags todos
 // @Accept json
 // @Produce json
+// @Param If-None-Match header string false "前回のレスポンスの ETag"
 // @Success 200 {array} model.Todo
-// @Failure 500 {object} map[string]string
+// @Header 200 {string} ETag "一覧の ETag"
+// @Success 304
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /todos [get]
 func (h *TodoHandler) GetTodos(c echo.Context) error {
-	todos, err := h.repo.FindAll()
+	todos, err := h.repo.FindAll(c.Request().Context())
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	return c.JSON(http.StatusOK, todos)
+	return jsonWithETag(c, http.StatusOK, todos)
 }
 
 // CreateTodo godoc
 // @Summary TODOを作成
-// @Description 新しいTODOを作成します
+// @Description 新しいTODOを作成します。Idempotency-Key を指定すると、同じキーの再送では作成せずに最初のレスポンスを返します
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Param todo body model.Todo true "TODO情報"
+// @Param Idempotency-Key header string false "再送で重複して作成しないためのキー（同じキーの再送には最初のレスポンスを返す）"
 // @Success 201 {object} model.Todo
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Header 201 {string} ETag "作成したTODOのバージョン"
+// @Header 201 {string} Idempotent-Replayed "保存したレスポンスを返した場合は true"
+// @Failure 400 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /todos [post]
 func (h *TodoHandler) CreateTodo(c echo.Context) error {
 	t := new(model.Todo)
-	if err := c.Bind(t); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, t); err != nil {
+		return err
 	}
 
-	createdTodo, err := h.repo.Create(t.Title, t.Description, t.SprintID)
+	createdTodo, err := h.repo.Create(c.Request().Context(), t.Title, t.Description, t.SprintID)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
 
+	setVersionETag(c, createdTodo.Version)
 	return c.JSON(http.StatusCreated, createdTodo)
 }
 
 // UpdateTodo godoc
 // @Summary TODOを更新
-// @Description 指定されたIDのTODOを更新します
+// @Description 指定されたIDのTODOを更新し、更新後のTODOを返します。If-Match を指定すると、バージョンが一致する場合のみ更新します
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Param id path int true "TODO ID"
+// @Param If-Match header string false "TODOのバージョン（取得時の version を引用符で囲んだ ETag）"
 // @Param todo body model.Todo true "更新内容"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]string
-// @Failure 404 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Success 200 {object} model.Todo
+// @Header 200 {string} ETag "更新後のバージョン"
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /todos/{id} [put]
 func (h *TodoHandler) UpdateTodo(c echo.Context) error {
-	// パスパラメータからIDを取得
-	idParam := c.Param("id")
-	id, err := strconv.Atoi(idParam)
+	id, err := pathID(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid ID parameter"})
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
+	if err != nil {
+		return err
 	}
 
 	// ボディから更新内容を取得
 	t := new(model.Todo)
-	if err := c.Bind(t); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, t); err != nil {
+		return err
 	}
 
-	rowsAffected, message, err := h.repo.Update(t.Title, t.Completed, id)
+	updated, err := h.repo.Update(c.Request().Context(), t.Title, t.Completed, id, version)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
 
-	if rowsAffected == 0 {
-		return c.JSON(http.StatusNotFound, map[string]string{"error": "Todo not found"})
-	}
-
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"rows_affected": rowsAffected,
-		"message":       message,
-	})
+	setVersionETag(c, updated.Version)
+	return c.JSON(http.StatusOK, updated)
 }
 
 // SearchTodos godoc
@@ -120,18 +135,20 @@ func (h *TodoHandler) UpdateTodo(c echo.Context) error {
 // @Produce json
 // @Param request body model.TodoSearchRequest true "検索条件"
 // @Success 200 {array} model.Todo
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /todos/search [post]
 func (h *TodoHandler) SearchTodos(c echo.Context) error {
 	req := new(model.TodoSearchRequest)
-	if err := c.Bind(req); err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid input"})
+	if err := bindRequest(c, req); err != nil {
+		return err
 	}
 
-	todos, err := h.repo.Search(req)
+	todos, err := h.repo.Search(c.Request().Context(), req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
 
 	return c.JSON(http.StatusOK, todos)
@@ -139,25 +156,34 @@ func (h *TodoHandler) SearchTodos(c echo.Context) error {
 
 // DeleteTodo godoc
 // @Summary TODOを削除
-// @Description 指定されたIDのTODOを論理削除します
+// @Description 指定されたIDのTODOを論理削除します。If-Match を指定すると、バージョンが一致する場合のみ削除します
 // @Tags todos
 // @Accept json
 // @Produce json
 // @Param id path int true "TODO ID"
+// @Param If-Match header string false "TODOのバージョン（取得時の version を引用符で囲んだ ETag）"
 // @Success 204
-// @Failure 400 {object} map[string]string
-// @Failure 500 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 412 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
 // @Router /todos/{id} [delete]
 func (h *TodoHandler) DeleteTodo(c echo.Context) error {
-	idParam := c.Param("id") // パスパラメータ :id を取得
-	id, err := strconv.Atoi(idParam)
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	version, err := ifMatchVersion(c)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid ID parameter"})
+		return err
 	}
 
-	err = h.repo.Delete(id)
+	err = h.repo.Delete(c.Request().Context(), id, version)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return err
 	}
 
 	return c.NoContent(http.StatusNoContent)
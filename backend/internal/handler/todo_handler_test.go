@@ -1,13 +1,19 @@
 package handler
 
 import (
+	"backend/internal/apperror"
+	"backend/internal/middleware"
 	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
 	"backend/internal/repository/mock"
 	"backend/internal/types"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -21,13 +27,13 @@ func TestGetTodos_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/todos", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockTodoRepository(ctrl)
-	mockRepo.EXPECT().FindAll().Return([]model.Todo{
+	mockRepo.EXPECT().FindAll(gomock.Any()).Return([]model.Todo{
 		{
 			ID:          1,
 			Title:       "Test Todo",
@@ -55,34 +61,53 @@ func TestGetTodos_Error(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodGet, "/todos", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockTodoRepository(ctrl)
-	mockRepo.EXPECT().FindAll().Return(nil, errors.New("database error"))
+	mockRepo.EXPECT().FindAll(gomock.Any()).Return(nil, errors.New("database error"))
 
 	handler := NewTodoHandler(mockRepo)
 	err := handler.GetTodos(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	problem := assertProblem(t, c, err, http.StatusInternalServerError)
+	assert.Equal(t, apperror.CodeInternal, problem.Code)
+	assert.NotContains(t, rec.Body.String(), "database error")
+}
+
+func TestGetTodos_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	req := httptest.NewRequest(http.MethodGet, "/todos", nil)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	mockRepo := mock.NewMockTodoRepository(ctrl)
+	mockRepo.EXPECT().FindAll(gomock.Any()).Return(nil, context.DeadlineExceeded)
+
+	handler := NewTodoHandler(mockRepo)
+	err := handler.GetTodos(c)
+
+	assertProblem(t, c, err, http.StatusGatewayTimeout)
 }
 
 func TestCreateTodo_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	todoJSON := `{"title":"New Todo","description":"New Description"}`
 	req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(todoJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockTodoRepository(ctrl)
-	mockRepo.EXPECT().Create("New Todo", "New Description", (*int)(nil)).Return(&model.Todo{
+	mockRepo.EXPECT().Create(gomock.Any(), "New Todo", "New Description", (*int)(nil)).Return(&model.Todo{
 		ID:          1,
 		Title:       "New Todo",
 		Description: "New Description",
@@ -108,54 +133,108 @@ func TestCreateTodo_InvalidInput(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	invalidJSON := `{invalid json}`
 	req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(invalidJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	mockRepo := mock.NewMockTodoRepository(ctrl)
 	handler := NewTodoHandler(mockRepo)
 	err := handler.CreateTodo(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertProblem(t, c, err, http.StatusBadRequest)
+}
+
+func TestCreateTodo_ValidationErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	// タイトルが空、スプリントが存在しない
+	todoJSON := `{"title":"  ","sprint_id":99}`
+	req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(todoJSON))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+
+	// リポジトリは呼ばれない
+	mockRepo := mock.NewMockTodoRepository(ctrl)
+	handler := NewTodoHandler(mockRepo)
+	err := handler.CreateTodo(c)
+
+	problem := assertProblem(t, c, err, http.StatusUnprocessableEntity)
+	assert.Equal(t, apperror.CodeValidationFailed, problem.Code)
+	assert.Equal(t, []model.FieldError{
+		{Field: "title", Code: "required", Message: "Title is required"},
+		{Field: "sprint_id", Code: "not_found", Message: "Sprint not found"},
+	}, problem.Errors)
 }
 
 func TestUpdateTodo_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	todoJSON := `{"title":"Updated Todo","completed":true}`
 	req := httptest.NewRequest(http.MethodPut, "/todos/1", strings.NewReader(todoJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
 	mockRepo := mock.NewMockTodoRepository(ctrl)
-	mockRepo.EXPECT().Update("Updated Todo", true, 1).Return(1, "Todo updated successfully", nil)
+	mockRepo.EXPECT().Update(gomock.Any(), "Updated Todo", true, 1, repository.AnyVersion).Return(&model.Todo{ID: 1, Title: "Updated Todo", Completed: true, Version: 2, CreatedAt: testTime, UpdatedAt: testTime}, nil)
 
 	handler := NewTodoHandler(mockRepo)
 	err := handler.UpdateTodo(c)
 
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+	var todo model.Todo
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &todo))
+	assert.Equal(t, "Updated Todo", todo.Title)
+	assert.Equal(t, 2, todo.Version)
+}
+
+func TestUpdateTodo_VersionMismatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	e := newTestEcho()
+	todoJSON := `{"title":"Updated Todo","completed":true}`
+	req := httptest.NewRequest(http.MethodPut, "/todos/1", strings.NewReader(todoJSON))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("If-Match", `"3"`)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("1")
+
+	mockRepo := mock.NewMockTodoRepository(ctrl)
+	mockRepo.EXPECT().Update(gomock.Any(), "Updated Todo", true, 1, 3).Return(nil, repository.ErrVersionMismatch)
+
+	handler := NewTodoHandler(mockRepo)
+	err := handler.UpdateTodo(c)
+
+	problem := assertProblem(t, c, err, http.StatusPreconditionFailed)
+	assert.Equal(t, apperror.CodeVersionMismatch, problem.Code)
 }
 
 func TestUpdateTodo_InvalidID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	todoJSON := `{"title":"Updated Todo"}`
 	req := httptest.NewRequest(http.MethodPut, "/todos/invalid", strings.NewReader(todoJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("invalid")
 
@@ -163,46 +242,45 @@ func TestUpdateTodo_InvalidID(t *testing.T) {
 	handler := NewTodoHandler(mockRepo)
 	err := handler.UpdateTodo(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertProblem(t, c, err, http.StatusBadRequest)
 }
 
 func TestUpdateTodo_NotFound(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	todoJSON := `{"title":"Updated Todo"}`
 	req := httptest.NewRequest(http.MethodPut, "/todos/999", strings.NewReader(todoJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("999")
 
 	mockRepo := mock.NewMockTodoRepository(ctrl)
-	mockRepo.EXPECT().Update("Updated Todo", false, 999).Return(0, "", nil)
+	mockRepo.EXPECT().Update(gomock.Any(), "Updated Todo", false, 999, repository.AnyVersion).Return(nil, repository.ErrTodoNotFound)
 
 	handler := NewTodoHandler(mockRepo)
 	err := handler.UpdateTodo(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusNotFound, rec.Code)
+	problem := assertProblem(t, c, err, http.StatusNotFound)
+	assert.Equal(t, apperror.CodeTodoNotFound, problem.Code)
 }
 
 func TestDeleteTodo_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodDelete, "/todos/1", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("1")
 
 	mockRepo := mock.NewMockTodoRepository(ctrl)
-	mockRepo.EXPECT().Delete(1).Return(nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), 1, repository.AnyVersion).Return(nil)
 
 	handler := NewTodoHandler(mockRepo)
 	err := handler.DeleteTodo(c)
@@ -215,10 +293,10 @@ func TestDeleteTodo_InvalidID(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	req := httptest.NewRequest(http.MethodDelete, "/todos/invalid", nil)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 	c.SetParamNames("id")
 	c.SetParamValues("invalid")
 
@@ -226,24 +304,23 @@ func TestDeleteTodo_InvalidID(t *testing.T) {
 	handler := NewTodoHandler(mockRepo)
 	err := handler.DeleteTodo(c)
 
-	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assertProblem(t, c, err, http.StatusBadRequest)
 }
 
 func TestSearchTodos_Success(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	e := echo.New()
+	e := newTestEcho()
 	searchJSON := `{"title":"test"}`
 	req := httptest.NewRequest(http.MethodPost, "/todos/search", strings.NewReader(searchJSON))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
-	c := e.NewContext(req, rec)
+	c := newTestContext(t, e, req, rec)
 
 	title := "test"
 	mockRepo := mock.NewMockTodoRepository(ctrl)
-	mockRepo.EXPECT().Search(&model.TodoSearchRequest{
+	mockRepo.EXPECT().Search(gomock.Any(), &model.TodoSearchRequest{
 		Title: &title,
 	}).Return([]model.Todo{
 		{
@@ -266,3 +343,65 @@ func TestSearchTodos_Success(t *testing.T) {
 	json.Unmarshal(rec.Body.Bytes(), &todos)
 	assert.Equal(t, 1, len(todos))
 }
+
+// インメモリのリポジトリを使うと、モックの期待値なしで一連の操作を検証できる
+func TestTodoHandler_InMemory(t *testing.T) {
+	repos := memory.NewRepositories()
+	e := echo.New()
+	e.Validator = newTestValidator(repos.Sprints.Exists)
+	handler := NewTodoHandler(repos.Todos)
+
+	call := func(method, path, body string, id string, fn echo.HandlerFunc) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		c := newTestContext(t, e, req, rec)
+		if id != "" {
+			c.SetParamNames("id")
+			c.SetParamValues(id)
+		}
+		if err := fn(c); err != nil {
+			middleware.ErrorHandler(err, c)
+		}
+		return rec
+	}
+
+	rec := call(http.MethodPost, "/todos", `{"title":"Write tests","description":"in memory"}`, "", handler.CreateTodo)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	var created model.Todo
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+
+	rec = call(http.MethodPut, "/todos/"+strconv.Itoa(created.ID), `{"title":"Write more tests","completed":true}`, strconv.Itoa(created.ID), handler.UpdateTodo)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+
+	rec = call(http.MethodPost, "/todos/search", `{"completed":true}`, "", handler.SearchTodos)
+	var todos []model.Todo
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &todos))
+	if assert.Len(t, todos, 1) {
+		assert.Equal(t, "Write more tests", todos[0].Title)
+	}
+
+	rec = call(http.MethodDelete, "/todos/"+strconv.Itoa(created.ID), "", strconv.Itoa(created.ID), handler.DeleteTodo)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	// 削除済みのTODOは見つからない
+	rec = call(http.MethodDelete, "/todos/"+strconv.Itoa(created.ID), "", strconv.Itoa(created.ID), handler.DeleteTodo)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"todo_not_found"`)
+
+	rec = call(http.MethodGet, "/todos", "", "", handler.GetTodos)
+	assert.JSONEq(t, "[]", rec.Body.String())
+
+	// 削除済みのスプリントには追加できない
+	sprint, err := repos.Sprints.Create(context.Background(), "Sprint", "bg-purple-500", false)
+	assert.NoError(t, err)
+	body := `{"title":"In sprint","sprint_id":` + strconv.Itoa(sprint.ID) + `}`
+	rec = call(http.MethodPost, "/todos", body, "", handler.CreateTodo)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	assert.NoError(t, repos.Sprints.Delete(context.Background(), sprint.ID, repository.AnyVersion))
+	rec = call(http.MethodPost, "/todos", body, "", handler.CreateTodo)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"field":"sprint_id"`)
+}
@@ -0,0 +1,274 @@
+package handler
+
+//go:generate mockgen -source=workspace_handler.go -destination=mock/mock_workspace_handler.go -package=mock
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type WorkspaceHandlerInterface interface {
+	GetWorkspaces(c echo.Context) error
+	CreateWorkspace(c echo.Context) error
+	AddMember(c echo.Context) error
+	UpdateMemberRole(c echo.Context) error
+	UpdateMFARequirement(c echo.Context) error
+}
+
+type WorkspaceHandler struct {
+	repo     repository.WorkspaceRepository
+	userRepo repository.UserRepository
+	mfaRepo  repository.MFARepository
+}
+
+func NewWorkspaceHandler(repo repository.WorkspaceRepository, userRepo repository.UserRepository, mfaRepo repository.MFARepository) WorkspaceHandlerInterface {
+	return &WorkspaceHandler{repo: repo, userRepo: userRepo, mfaRepo: mfaRepo}
+}
+
+// GetWorkspaces godoc
+// @Summary ワークスペース一覧を取得
+// @Description ログインユーザーが所属するワークスペースを取得します
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Success 200 {array} model.Workspace
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /workspaces [get]
+func (h *WorkspaceHandler) GetWorkspaces(c echo.Context) error {
+	workspaces, err := h.repo.FindByUserID(c.Request().Context(), currentUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, workspaces)
+}
+
+// CreateWorkspace godoc
+// @Summary ワークスペースを作成
+// @Description 新しいワークスペースを作成し、作成者をオーナーとして登録します
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param request body model.CreateWorkspaceRequest true "ワークスペース情報"
+// @Success 201 {object} model.Workspace
+// @Failure 400 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /workspaces [post]
+func (h *WorkspaceHandler) CreateWorkspace(c echo.Context) error {
+	req := new(model.CreateWorkspaceRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	workspace, err := h.repo.Create(c.Request().Context(), strings.TrimSpace(req.Name), currentUserID(c))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, workspace)
+}
+
+// AddMember godoc
+// @Summary ワークスペースにメンバーを追加
+// @Description ユーザーをワークスペースに追加します（オーナー・管理者のみ。管理者として追加できるのはオーナーのみ）。既にメンバーの場合は 409 を返し、ロールは変更しません
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param id path int true "ワークスペース ID"
+// @Param request body model.AddWorkspaceMemberRequest true "メンバー情報"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /workspaces/{id}/members [post]
+func (h *WorkspaceHandler) AddMember(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(model.AddWorkspaceMemberRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+	if req.Role == "" {
+		req.Role = model.WorkspaceRoleMember
+	}
+
+	// 管理者の付与はオーナーのみ
+	if req.Role == model.WorkspaceRoleAdmin {
+		err = h.requireOwner(c, id)
+	} else {
+		err = h.requireAdmin(c, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	user, err := h.userRepo.FindByUsername(c.Request().Context(), req.Username)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return repository.ErrUserNotFound
+	}
+
+	if err := h.repo.AddMember(c.Request().Context(), id, user.ID, req.Role); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Member added successfully"})
+}
+
+// UpdateMemberRole godoc
+// @Summary ワークスペースのメンバーのロールを変更
+// @Description メンバーを管理者にする、または管理者をメンバーに戻します（オーナーのみ）。オーナーのロールは変更できません
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param id path int true "ワークスペース ID"
+// @Param username path string true "メンバーのユーザー名"
+// @Param request body model.UpdateWorkspaceMemberRoleRequest true "ロール"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 422 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /workspaces/{id}/members/{username}/role [put]
+func (h *WorkspaceHandler) UpdateMemberRole(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(model.UpdateWorkspaceMemberRoleRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	if err := h.requireOwner(c, id); err != nil {
+		return err
+	}
+
+	user, err := h.userRepo.FindByUsername(c.Request().Context(), c.Param("username"))
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return repository.ErrMemberNotFound
+	}
+
+	if err := h.repo.UpdateMemberRole(c.Request().Context(), id, user.ID, req.Role); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Member role updated successfully"})
+}
+
+// UpdateMFARequirement godoc
+// @Summary ワークスペースのMFA必須設定を更新
+// @Description メンバー全員にMFAを必須にするかを設定します（オーナー・管理者のみ）。有効化するには操作者自身がMFAを有効にしている必要があります
+// @Tags workspaces
+// @Accept json
+// @Produce json
+// @Param id path int true "ワークスペース ID"
+// @Param request body model.UpdateMFARequirementRequest true "MFA必須設定"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} model.Problem
+// @Failure 403 {object} model.Problem
+// @Failure 404 {object} model.Problem
+// @Failure 409 {object} model.Problem
+// @Failure 500 {object} model.Problem
+// @Failure 503 {object} model.Problem
+// @Failure 504 {object} model.Problem
+// @Router /workspaces/{id}/mfa [put]
+func (h *WorkspaceHandler) UpdateMFARequirement(c echo.Context) error {
+	id, err := pathID(c)
+	if err != nil {
+		return err
+	}
+
+	req := new(model.UpdateMFARequirementRequest)
+	if err := bindRequest(c, req); err != nil {
+		return err
+	}
+
+	if err := h.requireAdmin(c, id); err != nil {
+		return err
+	}
+
+	// 操作者自身が締め出されないよう、MFA未設定なら有効化させない
+	if req.MFARequired {
+		mfa, err := h.mfaRepo.FindByUserID(c.Request().Context(), currentUserID(c))
+		if err != nil {
+			return err
+		}
+		if mfa == nil || !mfa.Enabled {
+			return apperror.Conflict(apperror.CodeMFASetupRequired, "Enable MFA on your account before requiring it")
+		}
+	}
+
+	if err := h.repo.SetMFARequired(c.Request().Context(), id, req.MFARequired); err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "MFA requirement updated successfully"})
+}
+
+// requireAdmin は操作者がワークスペースのオーナーまたは管理者でなければエラーを返す
+func (h *WorkspaceHandler) requireAdmin(c echo.Context, workspaceID int) error {
+	role, err := h.memberRole(c, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	switch role {
+	case model.WorkspaceRoleOwner, model.WorkspaceRoleAdmin:
+		return nil
+	default:
+		return apperror.Forbidden(apperror.CodeWorkspaceAdminRequired, "Workspace admin role required")
+	}
+}
+
+// requireOwner は操作者がワークスペースのオーナーでなければエラーを返す
+func (h *WorkspaceHandler) requireOwner(c echo.Context, workspaceID int) error {
+	role, err := h.memberRole(c, workspaceID)
+	if err != nil {
+		return err
+	}
+	if role != model.WorkspaceRoleOwner {
+		return apperror.Forbidden(apperror.CodeWorkspaceOwnerRequired, "Workspace owner role required")
+	}
+	return nil
+}
+
+// memberRole は操作者のロールを返す
+// メンバーでないワークスペースは存在を明かさないよう見つからない扱いにする
+func (h *WorkspaceHandler) memberRole(c echo.Context, workspaceID int) (string, error) {
+	role, err := h.repo.GetMemberRole(c.Request().Context(), workspaceID, currentUserID(c))
+	if err != nil {
+		return "", err
+	}
+	if role == "" {
+		return "", apperror.NotFound(apperror.CodeWorkspaceNotFound, "Workspace not found")
+	}
+	return role, nil
+}
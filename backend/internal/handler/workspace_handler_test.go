@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newWorkspaceTestHandler はインメモリのリポジトリに alice（オーナー）と bob を作成する
+func newWorkspaceTestHandler(t *testing.T) (WorkspaceHandlerInterface, *repository.Repositories, *model.Workspace) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+	alice, err := repos.Users.Create(ctx, "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+	_, err = repos.Users.Create(ctx, "bob", "bob@example.com", "hash")
+	require.NoError(t, err)
+	workspace, err := repos.Workspaces.Create(ctx, "Team", alice.ID)
+	require.NoError(t, err)
+
+	return NewWorkspaceHandler(repos.Workspaces, repos.Users, repos.MFA), repos, workspace
+}
+
+// newWorkspaceContext はユーザー userID として呼び出すコンテキストを返す（id はワークスペースID、不要なら0）
+func newWorkspaceContext(t *testing.T, method, path, body string, userID, id int) (echo.Context, *httptest.ResponseRecorder) {
+	e := newTestEcho()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := newTestContext(t, e, req, rec)
+	c.Set("user_id", userID)
+	if id != 0 {
+		c.SetParamNames("id")
+		c.SetParamValues(strconv.Itoa(id))
+	}
+	return c, rec
+}
+
+func TestWorkspaceHandler_CreateAndList(t *testing.T) {
+	handler, _, _ := newWorkspaceTestHandler(t)
+
+	c, rec := newWorkspaceContext(t, http.MethodPost, "/workspaces", `{"name":"  Retro  "}`, 1, 0)
+	require.NoError(t, handler.CreateWorkspace(c))
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	var created model.Workspace
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "Retro", created.Name)
+
+	c, rec = newWorkspaceContext(t, http.MethodGet, "/workspaces", "", 1, 0)
+	require.NoError(t, handler.GetWorkspaces(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	var workspaces []model.Workspace
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &workspaces))
+	assert.Len(t, workspaces, 2)
+}
+
+func TestWorkspaceHandler_AddMember(t *testing.T) {
+	handler, repos, workspace := newWorkspaceTestHandler(t)
+	path := "/workspaces/" + strconv.Itoa(workspace.ID) + "/members"
+
+	c, rec := newWorkspaceContext(t, http.MethodPost, path, `{"username":"bob"}`, 1, workspace.ID)
+	require.NoError(t, handler.AddMember(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	role, err := repos.Workspaces.GetMemberRole(context.Background(), workspace.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkspaceRoleMember, role)
+
+	// メンバーは管理者ではないため追加できない
+	c, _ = newWorkspaceContext(t, http.MethodPost, path, `{"username":"alice"}`, 2, workspace.ID)
+	problem := assertProblem(t, c, handler.AddMember(c), http.StatusForbidden)
+	assert.Equal(t, apperror.CodeWorkspaceAdminRequired, problem.Code)
+
+	// 所属していないワークスペースは存在しない扱い
+	c, _ = newWorkspaceContext(t, http.MethodPost, "/workspaces/99/members", `{"username":"bob"}`, 1, 99)
+	assertProblem(t, c, handler.AddMember(c), http.StatusNotFound)
+
+	// 既にメンバーなら追加し直してもロールを変更しない
+	c, _ = newWorkspaceContext(t, http.MethodPost, path, `{"username":"bob","role":"admin"}`, 1, workspace.ID)
+	problem = assertProblem(t, c, handler.AddMember(c), http.StatusConflict)
+	assert.Equal(t, apperror.CodeMemberExists, problem.Code)
+	role, err = repos.Workspaces.GetMemberRole(context.Background(), workspace.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkspaceRoleMember, role)
+}
+
+func TestWorkspaceHandler_AddMember_AdminRequiresOwner(t *testing.T) {
+	handler, repos, workspace := newWorkspaceTestHandler(t)
+	ctx := context.Background()
+	carol, err := repos.Users.Create(ctx, "carol", "carol@example.com", "hash")
+	require.NoError(t, err)
+	require.NoError(t, repos.Workspaces.AddMember(ctx, workspace.ID, 2, model.WorkspaceRoleAdmin))
+	path := "/workspaces/" + strconv.Itoa(workspace.ID) + "/members"
+
+	// 管理者はメンバーとしてのみ追加できる
+	c, _ := newWorkspaceContext(t, http.MethodPost, path, `{"username":"carol","role":"admin"}`, 2, workspace.ID)
+	problem := assertProblem(t, c, handler.AddMember(c), http.StatusForbidden)
+	assert.Equal(t, apperror.CodeWorkspaceOwnerRequired, problem.Code)
+
+	c, rec := newWorkspaceContext(t, http.MethodPost, path, `{"username":"carol"}`, 2, workspace.ID)
+	require.NoError(t, handler.AddMember(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	role, err := repos.Workspaces.GetMemberRole(ctx, workspace.ID, carol.ID)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkspaceRoleMember, role)
+}
+
+// newMemberRoleContext はユーザー userID として PUT /workspaces/{id}/members/{username}/role を呼び出すコンテキストを返す
+func newMemberRoleContext(t *testing.T, workspaceID int, username, body string, userID int) echo.Context {
+	path := "/workspaces/" + strconv.Itoa(workspaceID) + "/members/" + username + "/role"
+	c, _ := newWorkspaceContext(t, http.MethodPut, path, body, userID, 0)
+	c.SetParamNames("id", "username")
+	c.SetParamValues(strconv.Itoa(workspaceID), username)
+	return c
+}
+
+func TestWorkspaceHandler_UpdateMemberRole(t *testing.T) {
+	handler, repos, workspace := newWorkspaceTestHandler(t)
+	ctx := context.Background()
+	_, err := repos.Users.Create(ctx, "carol", "carol@example.com", "hash")
+	require.NoError(t, err)
+	require.NoError(t, repos.Workspaces.AddMember(ctx, workspace.ID, 2, model.WorkspaceRoleMember))
+	require.NoError(t, repos.Workspaces.AddMember(ctx, workspace.ID, 3, model.WorkspaceRoleAdmin))
+
+	// オーナーは管理者を付与・解除できる
+	c := newMemberRoleContext(t, workspace.ID, "bob", `{"role":"admin"}`, 1)
+	require.NoError(t, handler.UpdateMemberRole(c))
+	assert.Equal(t, http.StatusOK, c.Response().Status)
+	role, err := repos.Workspaces.GetMemberRole(ctx, workspace.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkspaceRoleAdmin, role)
+
+	// 管理者は他の管理者のロールもオーナーのロールも変更できない
+	c = newMemberRoleContext(t, workspace.ID, "bob", `{"role":"member"}`, 3)
+	problem := assertProblem(t, c, handler.UpdateMemberRole(c), http.StatusForbidden)
+	assert.Equal(t, apperror.CodeWorkspaceOwnerRequired, problem.Code)
+	c = newMemberRoleContext(t, workspace.ID, "alice", `{"role":"member"}`, 3)
+	assertProblem(t, c, handler.UpdateMemberRole(c), http.StatusForbidden)
+
+	// オーナー自身のロールも変更できない
+	c = newMemberRoleContext(t, workspace.ID, "alice", `{"role":"admin"}`, 1)
+	problem = assertProblem(t, c, handler.UpdateMemberRole(c), http.StatusForbidden)
+	assert.Equal(t, apperror.CodeWorkspaceOwnerRole, problem.Code)
+	role, err = repos.Workspaces.GetMemberRole(ctx, workspace.ID, 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.WorkspaceRoleOwner, role)
+
+	// メンバーでないユーザー
+	c = newMemberRoleContext(t, workspace.ID, "dave", `{"role":"admin"}`, 1)
+	problem = assertProblem(t, c, handler.UpdateMemberRole(c), http.StatusNotFound)
+	assert.Equal(t, apperror.CodeMemberNotFound, problem.Code)
+
+	// owner には変更できない
+	c = newMemberRoleContext(t, workspace.ID, "bob", `{"role":"owner"}`, 1)
+	assertProblem(t, c, handler.UpdateMemberRole(c), http.StatusUnprocessableEntity)
+}
+
+func TestWorkspaceHandler_UpdateMFARequirement(t *testing.T) {
+	handler, repos, workspace := newWorkspaceTestHandler(t)
+	path := "/workspaces/" + strconv.Itoa(workspace.ID) + "/mfa"
+
+	// 操作者自身がMFAを有効にしていなければ必須にできない
+	c, _ := newWorkspaceContext(t, http.MethodPut, path, `{"mfa_required":true}`, 1, workspace.ID)
+	problem := assertProblem(t, c, handler.UpdateMFARequirement(c), http.StatusConflict)
+	assert.Equal(t, apperror.CodeMFASetupRequired, problem.Code)
+
+	ctx := context.Background()
+	require.NoError(t, repos.MFA.SaveSecret(ctx, 1, "SECRET"))
+	require.NoError(t, repos.MFA.Enable(ctx, 1))
+
+	c, rec := newWorkspaceContext(t, http.MethodPut, path, `{"mfa_required":true}`, 1, workspace.ID)
+	require.NoError(t, handler.UpdateMFARequirement(c))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	mfaRequired, err := repos.Workspaces.IsMFARequiredForUser(ctx, 1)
+	require.NoError(t, err)
+	assert.True(t, mfaRequired)
+}
@@ -0,0 +1,85 @@
+package idempotency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepThreshold を超えたら期限切れの記録を掃除する
+const sweepThreshold = 10000
+
+// MemoryStore はプロセス内で記録を保持するStore（単一インスタンス・デモモード向け）
+type MemoryStore struct {
+	mu      sync.Mutex
+	records map[string]*memoryRecord
+}
+
+type memoryRecord struct {
+	Record
+	expiresAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{records: make(map[string]*memoryRecord)}
+}
+
+func (s *MemoryStore) Reserve(ctx context.Context, key, fingerprint string, expiresAt, now time.Time) (*Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.records) > sweepThreshold {
+		s.sweep(now)
+	}
+
+	if r, ok := s.records[key]; ok && now.Before(r.expiresAt) {
+		rec := r.Record
+		return &rec, nil
+	}
+	s.records[key] = &memoryRecord{Record: Record{Fingerprint: fingerprint}, expiresAt: expiresAt}
+	return nil, nil
+}
+
+func (s *MemoryStore) Complete(ctx context.Context, key string, rec Record, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	r, ok := s.records[key]
+	if !ok || r.Completed() {
+		return nil
+	}
+	r.expiresAt = expiresAt
+	r.StatusCode = rec.StatusCode
+	r.Header = rec.Header.Clone()
+	r.Body = append([]byte(nil), rec.Body...)
+	return nil
+}
+
+func (s *MemoryStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r, ok := s.records[key]; ok && !r.Completed() {
+		delete(s.records, key)
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.sweep(now), nil
+}
+
+// sweep は期限切れの記録を削除する（ロック取得済みで呼ぶこと）
+func (s *MemoryStore) sweep(now time.Time) int64 {
+	var deleted int64
+	for key, r := range s.records {
+		if !now.Before(r.expiresAt) {
+			delete(s.records, key)
+			deleted++
+		}
+	}
+	return deleted
+}
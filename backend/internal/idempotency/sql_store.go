@@ -0,0 +1,120 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+)
+
+// reserveRetries は Reserve で既存の記録が読み取り前に消えた場合に登録をやり直す回数
+const reserveRetries = 3
+
+// SQLStore は idempotency_keys テーブルに記録を保存するStore（PostgreSQL / SQLite）
+// 再起動後や複数インスタンス間でも同じキーの再送を検出できる
+type SQLStore struct {
+	db *sql.DB
+}
+
+func NewSQLStore(db *sql.DB) *SQLStore {
+	return &SQLStore{db: db}
+}
+
+func (s *SQLStore) Reserve(ctx context.Context, key, fingerprint string, expiresAt, now time.Time) (*Record, error) {
+	expiresAt = expiresAt.UTC().Truncate(time.Second)
+	now = now.UTC().Truncate(time.Second)
+
+	for i := 0; i < reserveRetries; i++ {
+		// 期限切れの記録だけ上書きする。有効な記録があれば何も変更しない
+		res, err := s.db.ExecContext(ctx, `
+			INSERT INTO idempotency_keys (key, fingerprint, expires_at)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (key) DO UPDATE SET
+				fingerprint = $2, status_code = NULL, headers = NULL, body = NULL, expires_at = $3
+			WHERE idempotency_keys.expires_at <= $4
+		`, key, fingerprint, expiresAt, now)
+		if err != nil {
+			return nil, err
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		if n == 1 {
+			return nil, nil
+		}
+
+		rec, err := s.get(ctx, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			// 他のリクエストが Release した直後。登録からやり直す
+			continue
+		}
+		return rec, err
+	}
+	return nil, errors.New("idempotency: failed to reserve key")
+}
+
+func (s *SQLStore) get(ctx context.Context, key string) (*Record, error) {
+	var (
+		rec        Record
+		statusCode sql.NullInt64
+		headers    sql.NullString
+	)
+	err := s.db.QueryRowContext(ctx,
+		"SELECT fingerprint, status_code, headers, body FROM idempotency_keys WHERE key = $1",
+		key,
+	).Scan(&rec.Fingerprint, &statusCode, &headers, &rec.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	rec.StatusCode = int(statusCode.Int64)
+	if headers.Valid && headers.String != "" {
+		if err := json.Unmarshal([]byte(headers.String), &rec.Header); err != nil {
+			return nil, err
+		}
+	}
+	return &rec, nil
+}
+
+func (s *SQLStore) Complete(ctx context.Context, key string, rec Record, expiresAt time.Time) error {
+	header := rec.Header
+	if header == nil {
+		header = http.Header{}
+	}
+	headers, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	body := rec.Body
+	if body == nil {
+		body = []byte{}
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		UPDATE idempotency_keys SET status_code = $1, headers = $2, body = $3, expires_at = $4
+		WHERE key = $5 AND status_code IS NULL
+	`, rec.StatusCode, string(headers), body, expiresAt.UTC().Truncate(time.Second), key)
+	return err
+}
+
+func (s *SQLStore) Release(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE key = $1 AND status_code IS NULL",
+		key,
+	)
+	return err
+}
+
+func (s *SQLStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	res, err := s.db.ExecContext(ctx,
+		"DELETE FROM idempotency_keys WHERE expires_at <= $1",
+		now.UTC().Truncate(time.Second),
+	)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
@@ -0,0 +1,57 @@
+// Package idempotency は Idempotency-Key で受け付けたリクエストの結果を保存する
+//
+// 同じキーで再送されたリクエストには、保存したレスポンスをそのまま返す（middleware.Idempotency）。
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Record はキーごとのリクエストと処理結果
+type Record struct {
+	// Fingerprint はリクエスト（メソッド・URL・ボディ）のハッシュ
+	Fingerprint string
+	// StatusCode が0なら処理中（レスポンスはまだ保存されていない）
+	StatusCode int
+	// Header は再送時に返すレスポンスヘッダー
+	Header http.Header
+	Body   []byte
+}
+
+// Completed は処理が終わりレスポンスが保存されているかどうか
+func (r *Record) Completed() bool {
+	return r.StatusCode != 0
+}
+
+// Store は Record を保存するバックエンド
+type Store interface {
+	// Reserve はキーを expiresAt まで処理中として登録し nil を返す
+	// 有効期限内の記録が既にあれば登録せずにその記録を返す（期限切れの記録は上書きする）
+	Reserve(ctx context.Context, key, fingerprint string, expiresAt, now time.Time) (*Record, error)
+	// Complete は処理中の記録に処理結果を保存し、有効期限を expiresAt に延ばす
+	// 既に完了した記録は変更しない
+	Complete(ctx context.Context, key string, rec Record, expiresAt time.Time) error
+	// Release は処理中の登録を取り消す（同じキーでの再試行を受け付ける）
+	Release(ctx context.Context, key string) error
+	// DeleteExpired は期限切れの記録を削除し、削除した件数を返す
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Key はキーの保存名を返す。利用者・エンドポイントごとに分け、クライアントのキーはそのまま保存しない
+func Key(scope, method, path, clientKey string) string {
+	return hash(scope + "\x00" + method + "\x00" + path + "\x00" + clientKey)
+}
+
+// Fingerprint はリクエストのハッシュを返す（同じキーで内容の異なるリクエストを検出する）
+func Fingerprint(method, uri string, body []byte) string {
+	return hash(method + "\x00" + uri + "\x00" + string(body))
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,140 @@
+package idempotency
+
+import (
+	"backend/internal/storage/storagetest"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stores は MemoryStore と各DBバックエンドの SQLStore を返す
+func stores(t *testing.T) map[string]Store {
+	s := map[string]Store{"memory": NewMemoryStore()}
+	for _, b := range storagetest.Backends(t) {
+		storagetest.Truncate(t, b.DB, "idempotency_keys")
+		s[b.Name] = NewSQLStore(b.DB)
+	}
+	return s
+}
+
+func TestStore(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			expiresAt := now.Add(time.Hour)
+
+			rec, err := store.Reserve(ctx, "k1", "fp1", expiresAt, now)
+			require.NoError(t, err)
+			assert.Nil(t, rec, "new key is reserved")
+
+			// 処理中
+			rec, err = store.Reserve(ctx, "k1", "fp2", expiresAt, now)
+			require.NoError(t, err)
+			require.NotNil(t, rec)
+			assert.Equal(t, "fp1", rec.Fingerprint)
+			assert.False(t, rec.Completed())
+
+			header := http.Header{"Content-Type": {"application/json"}, "Etag": {`"1"`}}
+			require.NoError(t, store.Complete(ctx, "k1", Record{Fingerprint: "fp1", StatusCode: http.StatusCreated, Header: header, Body: []byte(`{"id":1}`)}, expiresAt))
+
+			rec, err = store.Reserve(ctx, "k1", "fp1", expiresAt, now.Add(time.Minute))
+			require.NoError(t, err)
+			require.NotNil(t, rec)
+			assert.Equal(t, http.StatusCreated, rec.StatusCode)
+			assert.Equal(t, header, rec.Header)
+			assert.Equal(t, `{"id":1}`, string(rec.Body))
+
+			// 完了した記録は Release で消えない
+			require.NoError(t, store.Release(ctx, "k1"))
+			rec, err = store.Reserve(ctx, "k1", "fp1", expiresAt, now)
+			require.NoError(t, err)
+			assert.NotNil(t, rec)
+
+			// 期限切れの記録は上書きされる
+			rec, err = store.Reserve(ctx, "k1", "fp3", expiresAt.Add(time.Hour), expiresAt)
+			require.NoError(t, err)
+			assert.Nil(t, rec)
+			rec, err = store.Reserve(ctx, "k1", "fp1", expiresAt.Add(time.Hour), expiresAt)
+			require.NoError(t, err)
+			require.NotNil(t, rec)
+			assert.Equal(t, "fp3", rec.Fingerprint)
+			assert.False(t, rec.Completed())
+
+			// 処理中の登録を取り消すと再び登録できる
+			require.NoError(t, store.Release(ctx, "k1"))
+			rec, err = store.Reserve(ctx, "k1", "fp1", expiresAt.Add(time.Hour), expiresAt)
+			require.NoError(t, err)
+			assert.Nil(t, rec)
+		})
+	}
+}
+
+func TestStore_Lease(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+			lease := now.Add(time.Minute)
+
+			// 処理中の登録は lease までで、完了しないまま期限が切れると別のリクエストが登録できる
+			rec, err := store.Reserve(ctx, "k1", "fp1", lease, now)
+			require.NoError(t, err)
+			require.Nil(t, rec)
+			rec, err = store.Reserve(ctx, "k1", "fp1", lease, lease)
+			require.NoError(t, err)
+			assert.Nil(t, rec)
+
+			// 完了すると有効期限が延びる
+			_, err = store.Reserve(ctx, "k2", "fp1", lease, now)
+			require.NoError(t, err)
+			require.NoError(t, store.Complete(ctx, "k2", Record{Fingerprint: "fp1", StatusCode: http.StatusCreated}, now.Add(time.Hour)))
+			rec, err = store.Reserve(ctx, "k2", "fp1", lease.Add(time.Minute), lease)
+			require.NoError(t, err)
+			require.NotNil(t, rec)
+			assert.Equal(t, http.StatusCreated, rec.StatusCode)
+
+			// 完了した記録は上書きしない
+			require.NoError(t, store.Complete(ctx, "k2", Record{Fingerprint: "fp1", StatusCode: http.StatusOK}, now.Add(2*time.Hour)))
+			rec, err = store.Reserve(ctx, "k2", "fp1", lease.Add(time.Minute), lease)
+			require.NoError(t, err)
+			require.NotNil(t, rec)
+			assert.Equal(t, http.StatusCreated, rec.StatusCode)
+		})
+	}
+}
+
+func TestStore_DeleteExpired(t *testing.T) {
+	for name, store := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+			_, err := store.Reserve(ctx, "old", "fp", now.Add(time.Minute), now)
+			require.NoError(t, err)
+			_, err = store.Reserve(ctx, "new", "fp", now.Add(time.Hour), now)
+			require.NoError(t, err)
+
+			deleted, err := store.DeleteExpired(ctx, now.Add(time.Minute))
+			require.NoError(t, err)
+			assert.Equal(t, int64(1), deleted)
+
+			rec, err := store.Reserve(ctx, "new", "fp", now.Add(time.Hour), now)
+			require.NoError(t, err)
+			assert.NotNil(t, rec, "unexpired key is kept")
+		})
+	}
+}
+
+func TestKey(t *testing.T) {
+	base := Key("1", http.MethodPost, "/todos", "abc")
+	assert.Len(t, base, 64)
+	assert.Equal(t, base, Key("1", http.MethodPost, "/todos", "abc"))
+	// 利用者・エンドポイントが異なれば別のキー
+	assert.NotEqual(t, base, Key("2", http.MethodPost, "/todos", "abc"))
+	assert.NotEqual(t, base, Key("1", http.MethodPost, "/sprints", "abc"))
+}
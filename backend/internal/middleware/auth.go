@@ -1,45 +1,100 @@
 package middleware
 
 import (
-	"backend/internal/utils"
-	"net/http"
+	"backend/internal/apperror"
+	"backend/internal/auth"
+	"backend/internal/repository"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 )
 
 // AuthMiddleware はJWTトークンを検証するミドルウェア
-func AuthMiddleware() echo.MiddlewareFunc {
+// 無効化されたユーザーや、強制ログアウトで失効したトークンは拒否する
+func AuthMiddleware(tokens *auth.TokenService, users repository.UserRepository) echo.MiddlewareFunc {
+	return authMiddleware(tokens, users)
+}
+
+// MFAEnrollmentMiddleware は通常のトークンに加えて、MFA登録用トークンも受け付ける
+// MFA必須のワークスペースに所属する未登録ユーザーがTOTPを登録するために使う
+func MFAEnrollmentMiddleware(tokens *auth.TokenService, users repository.UserRepository) echo.MiddlewareFunc {
+	return authMiddleware(tokens, users, auth.TokenPurposeMFAEnroll)
+}
+
+// EventStreamAuthMiddleware は通常のトークンに加えて、クエリの ticket（イベント購読用のチケット）も受け付ける
+// ブラウザの EventSource / WebSocket は Authorization ヘッダーを設定できないため。
+// URL はアクセスログに残るので、クエリではチケット以外のトークンを受け付けない
+func EventStreamAuthMiddleware(tokens *auth.TokenService, users repository.UserRepository) echo.MiddlewareFunc {
+	authenticate := authMiddleware(tokens, users, auth.TokenPurposeEventStream)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		withHeader := authenticate(next)
+		withTicket := authenticate(func(c echo.Context) error {
+			if purpose, _ := c.Get("token_purpose").(string); purpose != auth.TokenPurposeEventStream {
+				return apperror.Unauthorized(apperror.CodeInvalidToken, "ticket must be an event stream ticket")
+			}
+			return next(c)
+		})
+		return func(c echo.Context) error {
+			ticket := c.QueryParam("ticket")
+			if ticket == "" || c.Request().Header.Get("Authorization") != "" {
+				return withHeader(c)
+			}
+			c.Request().Header.Set("Authorization", "Bearer "+ticket)
+			return withTicket(c)
+		}
+	}
+}
+
+// authMiddleware は通常のトークンと、allowedPurposes に含まれる用途のトークンを受け付ける
+func authMiddleware(tokens *auth.TokenService, users repository.UserRepository, allowedPurposes ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Authorization header required",
-				})
+				return apperror.Unauthorized(apperror.CodeAuthorizationRequired, "Authorization header required")
 			}
 
 			// "Bearer <token>" の形式を想定
 			parts := strings.Split(authHeader, " ")
 			if len(parts) != 2 || parts[0] != "Bearer" {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Invalid authorization header format",
-				})
+				return apperror.Unauthorized(apperror.CodeAuthorizationRequired, "Invalid authorization header format")
 			}
 
 			token := parts[1]
-			claims, err := utils.ValidateJWT(token)
+			claims, err := tokens.ValidateJWT(token)
+			if err != nil || !purposeAllowed(claims.Purpose, allowedPurposes) {
+				return apperror.Unauthorized(apperror.CodeInvalidToken, "Invalid or expired token")
+			}
+
+			// ロールや有効状態はトークン発行後に変わり得るので毎回DBから取得する
+			user, err := users.FindByID(c.Request().Context(), claims.UserID)
 			if err != nil {
-				return c.JSON(http.StatusUnauthorized, map[string]string{
-					"error": "Invalid or expired token",
-				})
+				return err
+			}
+			if user == nil || user.TokenVersion != claims.TokenVersion {
+				return apperror.Unauthorized(apperror.CodeInvalidToken, "Invalid or expired token")
 			}
 
 			// クレーム情報をコンテキストに保存
-			c.Set("user_id", claims.UserID)
-			c.Set("username", claims.Username)
+			c.Set("user_id", user.ID)
+			c.Set("username", user.Username)
+			c.Set("role", user.Role)
+			c.Set("token_purpose", claims.Purpose)
+			c.Set("token_version", user.TokenVersion)
 
 			return next(c)
 		}
 	}
 }
+
+func purposeAllowed(purpose string, allowed []string) bool {
+	if purpose == "" {
+		return true
+	}
+	for _, p := range allowed {
+		if p == purpose {
+			return true
+		}
+	}
+	return false
+}
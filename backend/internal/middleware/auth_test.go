@@ -0,0 +1,141 @@
+package middleware
+
+import (
+	"backend/internal/auth"
+	"backend/internal/model"
+	"backend/internal/repository/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestTokenService(t *testing.T) *auth.TokenService {
+	keys, err := auth.NewKeyManager(auth.Config{DevMode: true})
+	require.NoError(t, err)
+	return auth.NewTokenService(keys)
+}
+
+func serve(e *echo.Echo, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := newTestTokenService(t)
+	user := &model.User{ID: 1, Username: "alice", Role: model.RoleUser, TokenVersion: 2}
+	token, err := tokens.GenerateJWT(user)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		stored *model.User
+		want   int
+	}{
+		{"valid", user, http.StatusOK},
+		{"deactivated", nil, http.StatusUnauthorized},
+		{"force logged out", &model.User{ID: 1, Username: "alice", TokenVersion: 3}, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := mock.NewMockUserRepository(ctrl)
+			users.EXPECT().FindByID(gomock.Any(), 1).Return(tt.stored, nil)
+
+			e := newTestEcho()
+			e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, AuthMiddleware(tokens, users))
+
+			assert.Equal(t, tt.want, serve(e, token).Code)
+		})
+	}
+}
+
+func TestAuthMiddleware_RejectsMFAToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := newTestTokenService(t)
+	token, err := tokens.GenerateMFAToken(&model.User{ID: 1, Username: "alice"}, auth.TokenPurposeMFAChallenge)
+	require.NoError(t, err)
+
+	e := newTestEcho()
+	e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, AuthMiddleware(tokens, mock.NewMockUserRepository(ctrl)))
+
+	assert.Equal(t, http.StatusUnauthorized, serve(e, token).Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(e, "").Code)
+}
+
+func TestEventStreamAuthMiddleware(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tokens := newTestTokenService(t)
+	user := &model.User{ID: 1, Username: "alice", Role: model.RoleUser}
+	accessToken, err := tokens.GenerateJWT(user)
+	require.NoError(t, err)
+	ticket, err := tokens.GenerateEventStreamTicket(user)
+	require.NoError(t, err)
+
+	users := mock.NewMockUserRepository(ctrl)
+	users.EXPECT().FindByID(gomock.Any(), 1).Return(user, nil).AnyTimes()
+
+	e := newTestEcho()
+	e.GET("/events", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, EventStreamAuthMiddleware(tokens, users))
+	e.GET("/todos", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, AuthMiddleware(tokens, users))
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		want   int
+	}{
+		{"ticket in query", "/events?ticket=" + ticket, "", http.StatusOK},
+		{"access token in header", "/events", accessToken, http.StatusOK},
+		{"access token in query", "/events?ticket=" + accessToken, "", http.StatusUnauthorized},
+		{"no credentials", "/events", "", http.StatusUnauthorized},
+		{"ticket for other routes", "/todos", ticket, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.header)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tt.want, rec.Code)
+		})
+	}
+}
+
+func TestRequirePermissions(t *testing.T) {
+	for role, want := range map[string]int{
+		model.RoleAdmin: http.StatusOK,
+		model.RoleUser:  http.StatusForbidden,
+		"":              http.StatusForbidden,
+	} {
+		e := newTestEcho()
+		setRole := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.Set("role", role)
+				return next(c)
+			}
+		}
+		e.GET("/", func(c echo.Context) error { return c.NoContent(http.StatusOK) },
+			setRole, RequirePermissions(auth.PermissionUsersRead, auth.PermissionStatsRead))
+
+		assert.Equal(t, want, serve(e, "").Code, "role %q", role)
+	}
+}
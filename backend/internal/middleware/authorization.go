@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/auth"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RequirePermissions は AuthMiddleware の後に置き、ロールが全ての権限を持つ場合のみ通すミドルウェア
+//
+//	admin := e.Group("/admin", AuthMiddleware(tokens, users), RequirePermissions(auth.PermissionUsersRead))
+func RequirePermissions(permissions ...auth.Permission) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			role, _ := c.Get("role").(string)
+			for _, p := range permissions {
+				if !auth.HasPermission(role, p) {
+					return apperror.Forbidden(apperror.CodeInsufficientPermissions, "Insufficient permissions")
+				}
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 廃止予定を知らせるヘッダー（RFC 9745 / RFC 8594）
+const (
+	HeaderDeprecation = "Deprecation"
+	HeaderSunset      = "Sunset"
+	HeaderLink        = "Link"
+)
+
+// DeprecationConfig は廃止予定のルートの設定
+type DeprecationConfig struct {
+	// Since は廃止予定になった日時
+	Since time.Time
+	// Sunset は提供を終了する予定の日時（ゼロ値なら Sunset ヘッダーを付けない）
+	Sunset time.Time
+	// SuccessorPrefix は移行先のパスの接頭辞（例: /api/v1）。設定すると移行先を Link ヘッダーで示す
+	SuccessorPrefix string
+}
+
+// Deprecated は廃止予定のルートのレスポンスに Deprecation / Sunset / Link ヘッダーを付けるミドルウェア
+// ルートの動作は変えない（提供終了までは従来どおり応答する）
+func Deprecated(cfg DeprecationConfig) echo.MiddlewareFunc {
+	deprecation := "@" + strconv.FormatInt(cfg.Since.Unix(), 10)
+	var sunset string
+	if !cfg.Sunset.IsZero() {
+		sunset = cfg.Sunset.UTC().Format(http.TimeFormat)
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			header := c.Response().Header()
+			header.Set(HeaderDeprecation, deprecation)
+			if sunset != "" {
+				header.Set(HeaderSunset, sunset)
+			}
+			if cfg.SuccessorPrefix != "" {
+				successor := cfg.SuccessorPrefix + c.Request().URL.Path
+				if q := c.Request().URL.RawQuery; q != "" {
+					successor += "?" + q
+				}
+				header.Add(HeaderLink, "<"+successor+`>; rel="successor-version"`)
+			}
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecated(t *testing.T) {
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	sunset := time.Date(2026, 7, 1, 9, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+
+	tests := []struct {
+		name       string
+		cfg        DeprecationConfig
+		target     string
+		wantSunset string
+		wantLink   string
+	}{
+		{"headers", DeprecationConfig{Since: since, Sunset: sunset, SuccessorPrefix: "/api/v1"}, "/old", "Wed, 01 Jul 2026 00:00:00 GMT", `</api/v1/old>; rel="successor-version"`},
+		{"query", DeprecationConfig{Since: since, SuccessorPrefix: "/api/v1"}, "/old?sprint_id=1&page=2", "", `</api/v1/old?sprint_id=1&page=2>; rel="successor-version"`},
+		{"without sunset", DeprecationConfig{Since: since}, "/old", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEcho()
+			e.GET("/old", func(c echo.Context) error { return c.NoContent(http.StatusOK) }, Deprecated(tt.cfg))
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, "@1767225600", rec.Header().Get(HeaderDeprecation))
+			assert.Equal(t, tt.wantSunset, rec.Header().Get(HeaderSunset))
+			assert.Equal(t, tt.wantLink, rec.Header().Get(HeaderLink))
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// MIMEApplicationProblemJSON はエラーレスポンスの Content-Type（RFC 7807）
+const MIMEApplicationProblemJSON = "application/problem+json"
+
+// ErrorHandler はハンドラーが返したエラーを application/problem+json のレスポンスに変換する
+// e.HTTPErrorHandler に設定する。クライアントには安定したエラーコードと説明だけを返し、
+// DBのエラーなどの詳細はリクエストIDとともにログにのみ出力する
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	problem := ProblemFor(c, err)
+
+	c.Response().Header().Set(echo.HeaderContentType, MIMEApplicationProblemJSON)
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(problem.Status)
+	} else {
+		err = c.JSON(problem.Status, problem)
+	}
+	if err != nil {
+		log.Printf("[HTTP] Failed to write error response: %v", err)
+	}
+}
+
+// ProblemFor はエラーを application/problem+json の本文に変換し、必要なら原因をログに出力する
+// 一括操作の各件の結果など、ErrorHandler を通さずにエラーを返す場合にも使う
+func ProblemFor(c echo.Context, err error) *model.Problem {
+	problem, cause := toProblem(c, err)
+	if cause != nil {
+		log.Printf("[HTTP] %d %s %s (request_id=%s): %v",
+			problem.Status, c.Request().Method, c.Request().URL.Path, problem.RequestID, cause)
+	}
+	return problem
+}
+
+// toProblem はエラーをレスポンスに変換し、ログに出力すべき原因を返す（不要なら nil）
+func toProblem(c echo.Context, err error) (*model.Problem, error) {
+	problem := &model.Problem{
+		Type:      "about:blank",
+		Instance:  c.Request().URL.Path,
+		RequestID: requestID(c),
+	}
+	var cause error
+
+	// lib/pq はキャンセル時にサーバーのエラーを返すことがあるため、コンテキストの状態も確認する
+	ctxErr := c.Request().Context().Err()
+
+	var appErr *apperror.Error
+	var httpErr *echo.HTTPError
+	switch {
+	case errors.As(err, &appErr):
+		problem.Status = statusFor(appErr.Kind)
+		problem.Code = appErr.Code
+		problem.Detail = appErr.Message
+		problem.Errors = appErr.Fields
+		cause = appErr.Err
+	case errors.As(err, &httpErr):
+		problem.Status = httpErr.Code
+		problem.Code = codeForStatus(httpErr.Code)
+		problem.Detail = fmt.Sprint(httpErr.Message)
+		if problem.Status >= http.StatusInternalServerError {
+			problem.Detail = "Internal server error"
+		}
+		cause = httpErr.Internal
+	case errors.Is(err, context.DeadlineExceeded) || errors.Is(ctxErr, context.DeadlineExceeded):
+		problem.Status = http.StatusGatewayTimeout
+		problem.Code = apperror.CodeTimeout
+		problem.Detail = "Request timed out"
+		cause = err
+	case errors.Is(err, context.Canceled) || errors.Is(ctxErr, context.Canceled):
+		problem.Status = http.StatusServiceUnavailable
+		problem.Code = apperror.CodeRequestCanceled
+		problem.Detail = "Request canceled"
+		cause = err
+	default:
+		problem.Status = http.StatusInternalServerError
+		problem.Code = apperror.CodeInternal
+		problem.Detail = "Internal server error"
+		cause = err
+	}
+
+	// 5xx は原因がなくても記録する
+	if cause == nil && problem.Status >= http.StatusInternalServerError {
+		cause = err
+	}
+	problem.Title = http.StatusText(problem.Status)
+	return problem, cause
+}
+
+// statusFor はエラーの種類に対応するステータスコードを返す
+func statusFor(kind error) int {
+	switch kind {
+	case apperror.ErrBadRequest:
+		return http.StatusBadRequest
+	case apperror.ErrValidation:
+		return http.StatusUnprocessableEntity
+	case apperror.ErrUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.ErrForbidden:
+		return http.StatusForbidden
+	case apperror.ErrNotFound:
+		return http.StatusNotFound
+	case apperror.ErrConflict:
+		return http.StatusConflict
+	case apperror.ErrRateLimited:
+		return http.StatusTooManyRequests
+	case apperror.ErrPreconditionFailed:
+		return http.StatusPreconditionFailed
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// codeForStatus は echo が返すエラー（ルーティングやボディサイズ制限など）のエラーコードを返す
+func codeForStatus(status int) string {
+	switch {
+	case status == http.StatusNotFound:
+		return apperror.CodeNotFound
+	case status == http.StatusMethodNotAllowed:
+		return apperror.CodeMethodNotAllowed
+	case status == http.StatusRequestEntityTooLarge:
+		return apperror.CodeRequestTooLarge
+	case status == http.StatusUnauthorized:
+		return apperror.CodeAuthorizationRequired
+	case status == http.StatusForbidden:
+		return apperror.CodeInsufficientPermissions
+	case status == http.StatusTooManyRequests:
+		return apperror.CodeRateLimited
+	case status == http.StatusServiceUnavailable:
+		return apperror.CodeRequestCanceled
+	case status == http.StatusGatewayTimeout:
+		return apperror.CodeTimeout
+	case status >= http.StatusInternalServerError:
+		return apperror.CodeInternal
+	default:
+		return apperror.CodeInvalidInput
+	}
+}
+
+// requestID は RequestID ミドルウェアが設定したリクエストIDを返す
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
@@ -0,0 +1,149 @@
+package middleware
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestEcho は ErrorHandler を設定した echo を返す
+func newTestEcho() *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	return e
+}
+
+func TestErrorHandler(t *testing.T) {
+	tests := []struct {
+		name       string
+		ctxErr     func(context.Context) (context.Context, context.CancelFunc)
+		err        error
+		wantStatus int
+		wantCode   string
+		wantDetail string
+	}{
+		{
+			name:       "ドメインエラー",
+			err:        apperror.NotFound(apperror.CodeTodoNotFound, "Todo not found"),
+			wantStatus: http.StatusNotFound,
+			wantCode:   apperror.CodeTodoNotFound,
+			wantDetail: "Todo not found",
+		},
+		{
+			name:       "ラップされたドメインエラーの原因は返さない",
+			err:        fmt.Errorf("update: %w", apperror.Conflict(apperror.CodeConflict, "Already exists").WithCause(errors.New("pq: duplicate key"))),
+			wantStatus: http.StatusConflict,
+			wantCode:   apperror.CodeConflict,
+			wantDetail: "Already exists",
+		},
+		{
+			name:       "echo のエラー",
+			err:        echo.ErrMethodNotAllowed,
+			wantStatus: http.StatusMethodNotAllowed,
+			wantCode:   apperror.CodeMethodNotAllowed,
+			wantDetail: "Method Not Allowed",
+		},
+		{
+			name:       "タイムアウトは504",
+			err:        context.DeadlineExceeded,
+			wantStatus: http.StatusGatewayTimeout,
+			wantCode:   apperror.CodeTimeout,
+			wantDetail: "Request timed out",
+		},
+		{
+			name: "キャンセル済みのリクエストは503",
+			ctxErr: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(ctx)
+				cancel()
+				return ctx, cancel
+			},
+			// lib/pq はキャンセル時にサーバーのエラーを返す
+			err:        errors.New("pq: canceling statement due to user request"),
+			wantStatus: http.StatusServiceUnavailable,
+			wantCode:   apperror.CodeRequestCanceled,
+			wantDetail: "Request canceled",
+		},
+		{
+			name:       "その他のエラーは詳細を返さない",
+			err:        errors.New("pq: relation \"todos\" does not exist"),
+			wantStatus: http.StatusInternalServerError,
+			wantCode:   apperror.CodeInternal,
+			wantDetail: "Internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestEcho()
+			e.Use(middleware.RequestID())
+			e.GET("/todos/:id", func(c echo.Context) error {
+				if tt.ctxErr != nil {
+					ctx, cancel := tt.ctxErr(c.Request().Context())
+					defer cancel()
+					c.SetRequest(c.Request().WithContext(ctx))
+				}
+				return tt.err
+			})
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/todos/1", nil))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, MIMEApplicationProblemJSON, rec.Header().Get(echo.HeaderContentType))
+			assert.NotContains(t, rec.Body.String(), "pq:")
+
+			var problem model.Problem
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+			assert.Equal(t, "about:blank", problem.Type)
+			assert.Equal(t, http.StatusText(tt.wantStatus), problem.Title)
+			assert.Equal(t, tt.wantStatus, problem.Status)
+			assert.Equal(t, tt.wantCode, problem.Code)
+			assert.Equal(t, tt.wantDetail, problem.Detail)
+			assert.Equal(t, "/todos/1", problem.Instance)
+			assert.NotEmpty(t, problem.RequestID)
+			assert.Equal(t, rec.Header().Get(echo.HeaderXRequestID), problem.RequestID)
+		})
+	}
+}
+
+func TestErrorHandler_ValidationFields(t *testing.T) {
+	e := newTestEcho()
+	e.POST("/register", func(c echo.Context) error {
+		return apperror.Validation(model.FieldError{Field: "email", Code: "invalid_format", Message: "Email address is invalid"})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/register", nil)
+	req.Header.Set(echo.HeaderXRequestID, "client-id")
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var problem model.Problem
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, apperror.CodeValidationFailed, problem.Code)
+	assert.Equal(t, "client-id", problem.RequestID)
+	require.Len(t, problem.Errors, 1)
+	assert.Equal(t, "email", problem.Errors[0].Field)
+}
+
+func TestErrorHandler_RouteNotFound(t *testing.T) {
+	e := newTestEcho()
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/missing", nil))
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"not_found"`)
+}
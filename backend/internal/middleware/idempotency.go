@@ -0,0 +1,167 @@
+package middleware
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/idempotency"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderIdempotencyKey はクライアントがリクエストごとに生成する一意なキー
+	HeaderIdempotencyKey = "Idempotency-Key"
+	// HeaderIdempotentReplayed は保存したレスポンスを返したことを示す
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	// idempotencyRetryAfter は処理中のキーで再送されたときに待たせる時間
+	idempotencyRetryAfter = time.Second
+	// idempotencyLease は処理中として登録したキーの有効期限（完了すると ttl に延ばす）
+	// プロセスが処理中に落ちても、この時間が過ぎれば同じキーで再試行できる。リクエストの処理時間（WriteTimeout）より長くする
+	idempotencyLease = time.Minute
+)
+
+// replayedHeaders は再送時に返すレスポンスヘッダー
+var replayedHeaders = []string{echo.HeaderContentType, echo.HeaderLocation, "ETag"}
+
+// Idempotency は Idempotency-Key ヘッダー付きのリクエストの結果を ttl の間保存し、
+// 同じキー・同じ内容で再送されたリクエストには処理をせず保存したレスポンスを返すミドルウェア
+//   - 同じキーで内容（メソッド・URL・ボディ）が異なる: 422
+//   - 同じキーのリクエストが処理中: 409（Retry-After 付き）
+//
+// キーは利用者・エンドポイントごとに分かれるため、認証ミドルウェアの後に設定する
+// apiPrefix（例: /api/v1）はパスから除いて扱い、旧ルートと現行ルートへの再送を同じリクエストとみなす
+// エラーや 5xx のレスポンス、panic したリクエストは保存せず、同じキーで再試行できる
+func Idempotency(store idempotency.Store, ttl time.Duration, apiPrefix string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			clientKey := c.Request().Header.Get(HeaderIdempotencyKey)
+			if clientKey == "" {
+				return next(c)
+			}
+			if len(clientKey) > maxIdempotencyKeyLength {
+				return apperror.BadRequest(apperror.CodeInvalidIdempotencyKey,
+					fmt.Sprintf("Idempotency-Key must be at most %d characters", maxIdempotencyKeyLength))
+			}
+
+			req := c.Request()
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				return err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			ctx := req.Context()
+			key := idempotency.Key(fmt.Sprint(c.Get("user_id")), req.Method, trimAPIPrefix(c.Path(), apiPrefix), clientKey)
+			fingerprint := idempotency.Fingerprint(req.Method, trimAPIPrefix(req.URL.RequestURI(), apiPrefix), body)
+			now := time.Now()
+
+			existing, err := store.Reserve(ctx, key, fingerprint, now.Add(min(idempotencyLease, ttl)), now)
+			if err != nil {
+				return fmt.Errorf("failed to reserve idempotency key: %w", err)
+			}
+			if existing != nil {
+				return replay(c, existing, fingerprint)
+			}
+
+			// レスポンスの保存はクライアントの切断に影響されないようにする
+			ctx = context.WithoutCancel(ctx)
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = recorder
+
+			// 保存しなかった場合（エラー・panic を含む）は登録を取り消す。panic は Recover ミドルウェアに任せる
+			completed := false
+			defer func() {
+				c.Response().Writer = recorder.ResponseWriter
+				if completed {
+					return
+				}
+				r := recover()
+				if releaseErr := store.Release(ctx, key); releaseErr != nil {
+					log.Printf("[IDEMPOTENCY] Failed to release key: %v", releaseErr)
+				}
+				if r != nil {
+					panic(r)
+				}
+			}()
+
+			if err := next(c); err != nil {
+				return err
+			}
+			res := c.Response()
+			if !res.Committed || res.Status >= http.StatusInternalServerError {
+				return nil
+			}
+
+			rec := idempotency.Record{
+				Fingerprint: fingerprint,
+				StatusCode:  res.Status,
+				Header:      http.Header{},
+				Body:        recorder.body.Bytes(),
+			}
+			for _, name := range replayedHeaders {
+				if v := res.Header().Values(name); len(v) > 0 {
+					rec.Header[http.CanonicalHeaderKey(name)] = v
+				}
+			}
+			completed = true
+			if err := store.Complete(ctx, key, rec, time.Now().Add(ttl)); err != nil {
+				log.Printf("[IDEMPOTENCY] Failed to save response: %v", err)
+			}
+			return nil
+		}
+	}
+}
+
+// trimAPIPrefix は現行ルートのパスから prefix を除き、旧ルートと同じパスにする
+func trimAPIPrefix(path, prefix string) string {
+	if prefix != "" && strings.HasPrefix(path, prefix+"/") {
+		return path[len(prefix):]
+	}
+	return path
+}
+
+// replay は既存の記録に応じて保存したレスポンスかエラーを返す
+func replay(c echo.Context, rec *idempotency.Record, fingerprint string) error {
+	if rec.Fingerprint != fingerprint {
+		return apperror.Unprocessable(apperror.CodeIdempotencyKeyReused,
+			"Idempotency-Key was already used for a different request")
+	}
+	if !rec.Completed() {
+		SetRetryAfter(c, idempotencyRetryAfter)
+		return apperror.Conflict(apperror.CodeIdempotencyInProgress,
+			"A request with this Idempotency-Key is still being processed")
+	}
+
+	header := c.Response().Header()
+	for name, values := range rec.Header {
+		header[http.CanonicalHeaderKey(name)] = values
+	}
+	header.Set(HeaderIdempotentReplayed, "true")
+	c.Response().WriteHeader(rec.StatusCode)
+	_, err := c.Response().Write(rec.Body)
+	return err
+}
+
+// responseRecorder はクライアントに書き込んだレスポンスボディを記録する
+type responseRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
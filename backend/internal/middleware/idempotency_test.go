@@ -0,0 +1,172 @@
+package middleware
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/idempotency"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newIdempotencyEcho は作成のたびに ID を採番するハンドラーを Idempotency 付きで登録する
+func newIdempotencyEcho(store idempotency.Store, handler echo.HandlerFunc) (*echo.Echo, *int) {
+	e := newTestEcho()
+	created := 0
+	if handler == nil {
+		handler = func(c echo.Context) error {
+			body, _ := io.ReadAll(c.Request().Body)
+			created++
+			c.Response().Header().Set("ETag", `"1"`)
+			return c.JSONBlob(http.StatusCreated, []byte(`{"id":`+strconv.Itoa(created)+`,"req":`+string(body)+`}`))
+		}
+	}
+	setUser := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", c.Request().Header.Get("X-User"))
+			return next(c)
+		}
+	}
+	idem := Idempotency(store, time.Hour, "/api/v1")
+	e.POST("/todos", handler, setUser, idem)
+	e.POST("/api/v1/todos", handler, setUser, idem)
+	return e, &created
+}
+
+func postTodo(e *echo.Echo, user, key, body string) *httptest.ResponseRecorder {
+	return postTodoTo(e, "/todos", user, key, body)
+}
+
+func postTodoTo(e *echo.Echo, path, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-User", user)
+	if key != "" {
+		req.Header.Set(HeaderIdempotencyKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	e, created := newIdempotencyEcho(idempotency.NewMemoryStore(), nil)
+
+	first := postTodo(e, "1", "key-1", `{"title":"a"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(HeaderIdempotentReplayed))
+
+	// 同じキー・同じ内容の再送は処理せず同じレスポンスを返す
+	retry := postTodo(e, "1", "key-1", `{"title":"a"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+	assert.Equal(t, echo.MIMEApplicationJSON, retry.Header().Get(echo.HeaderContentType))
+	assert.Equal(t, "true", retry.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, 1, *created)
+
+	// キーなし・別のキー・別の利用者は新しいリクエスト
+	assert.Equal(t, http.StatusCreated, postTodo(e, "1", "", `{"title":"a"}`).Code)
+	assert.Equal(t, http.StatusCreated, postTodo(e, "1", "key-2", `{"title":"a"}`).Code)
+	assert.Equal(t, http.StatusCreated, postTodo(e, "2", "key-1", `{"title":"a"}`).Code)
+	assert.Equal(t, 4, *created)
+}
+
+func TestIdempotency_LegacyRoute(t *testing.T) {
+	e, created := newIdempotencyEcho(idempotency.NewMemoryStore(), nil)
+
+	first := postTodoTo(e, "/todos", "1", "key-1", `{"title":"a"}`)
+	require.Equal(t, http.StatusCreated, first.Code)
+
+	// 旧ルートと /api/v1 のルートへの再送は同じリクエストとして扱う
+	retry := postTodoTo(e, "/api/v1/todos", "1", "key-1", `{"title":"a"}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotency_ReusedKey(t *testing.T) {
+	e, created := newIdempotencyEcho(idempotency.NewMemoryStore(), nil)
+
+	require.Equal(t, http.StatusCreated, postTodo(e, "1", "key-1", `{"title":"a"}`).Code)
+
+	rec := postTodo(e, "1", "key-1", `{"title":"b"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"code":"`+apperror.CodeIdempotencyKeyReused+`"`)
+	assert.Equal(t, 1, *created)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	var e *echo.Echo
+	var inner *httptest.ResponseRecorder
+	e, _ = newIdempotencyEcho(store, func(c echo.Context) error {
+		// 処理中に同じキーで再送された
+		inner = postTodo(e, "1", "key-1", `{}`)
+		return c.NoContent(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusCreated, postTodo(e, "1", "key-1", `{}`).Code)
+	require.NotNil(t, inner)
+	assert.Equal(t, http.StatusConflict, inner.Code)
+	assert.Contains(t, inner.Body.String(), apperror.CodeIdempotencyInProgress)
+	assert.Equal(t, "1", inner.Header().Get("Retry-After"))
+}
+
+func TestIdempotency_ErrorReleasesKey(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	fail := true
+	e, _ := newIdempotencyEcho(store, func(c echo.Context) error {
+		if fail {
+			return errors.New("db down")
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, postTodo(e, "1", "key-1", `{}`).Code)
+
+	// 失敗したリクエストは保存されず、同じキーで再試行できる
+	fail = false
+	rec := postTodo(e, "1", "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	store := idempotency.NewMemoryStore()
+	fail := true
+	e, _ := newIdempotencyEcho(store, func(c echo.Context) error {
+		if fail {
+			panic("unexpected")
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+	e.Use(echomw.Recover())
+
+	assert.Equal(t, http.StatusInternalServerError, postTodo(e, "1", "key-1", `{}`).Code)
+
+	// panic したリクエストも登録を取り消し、同じキーで再試行できる
+	fail = false
+	rec := postTodo(e, "1", "key-1", `{}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(HeaderIdempotentReplayed))
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	e, created := newIdempotencyEcho(idempotency.NewMemoryStore(), nil)
+
+	rec := postTodo(e, "1", strings.Repeat("k", 256), `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), apperror.CodeInvalidIdempotencyKey)
+	assert.Equal(t, 0, *created)
+}
@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/ratelimit"
+	"errors"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RateLimit はIPごとのリクエスト数を制限するミドルウェア
+func RateLimit(limiter *ratelimit.Limiter) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := limiter.AllowRequest(c.Request().Context(), c.RealIP())
+
+			var limitErr *ratelimit.LimitError
+			if errors.As(err, &limitErr) {
+				SetRetryAfter(c, limitErr.RetryAfter)
+				return apperror.RateLimited(apperror.CodeRateLimited, "Too many requests")
+			}
+			if err != nil {
+				// ストアの障害で認証エンドポイント全体を止めないよう、ログだけ残して通す
+				log.Printf("[RATELIMIT] Failed to check request limit: %v", err)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// SetRetryAfter は Retry-After ヘッダーを秒単位で設定する
+func SetRetryAfter(c echo.Context, d time.Duration) {
+	seconds := int(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	c.Response().Header().Set("Retry-After", strconv.Itoa(seconds))
+}
@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// DBTimeout はリクエストのコンテキストにタイムアウトを設定する
+// リポジトリはこのコンテキストでクエリを実行するため、クライアントの切断や
+// タイムアウトで実行中のクエリがキャンセルされる
+func DBTimeout(timeout time.Duration) echo.MiddlewareFunc {
+	return DBTimeoutWithSkipper(timeout, nil)
+}
+
+// DBTimeoutWithSkipper は skip が true を返すリクエスト（変更イベントの購読などの長時間の接続）を除いて DBTimeout を適用する
+func DBTimeoutWithSkipper(timeout time.Duration, skip func(c echo.Context) bool) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if skip != nil && skip(c) {
+				return next(c)
+			}
+			ctx, cancel := context.WithTimeout(c.Request().Context(), timeout)
+			defer cancel()
+
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDBTimeout_SetsDeadline(t *testing.T) {
+	e := echo.New()
+	e.Use(DBTimeout(time.Second))
+
+	var deadline time.Time
+	var ok bool
+	e.GET("/", func(c echo.Context) error {
+		deadline, ok = c.Request().Context().Deadline()
+		return c.NoContent(http.StatusOK)
+	})
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Second), deadline, 100*time.Millisecond)
+}
+
+func TestDBTimeoutWithSkipper(t *testing.T) {
+	e := echo.New()
+	e.Use(DBTimeoutWithSkipper(time.Second, func(c echo.Context) bool { return c.Path() == "/stream" }))
+
+	deadlines := map[string]bool{}
+	handler := func(c echo.Context) error {
+		_, deadlines[c.Path()] = c.Request().Context().Deadline()
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/", handler)
+	e.GET("/stream", handler)
+
+	for _, path := range []string{"/", "/stream"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+	assert.Equal(t, map[string]bool{"/": true, "/stream": false}, deadlines)
+}
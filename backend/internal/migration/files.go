@@ -0,0 +1,95 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// filePattern は "0001_initial_schema.up.sql" 形式のファイル名
+var filePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Migration はバージョンごとの up/down ファイルの組
+type Migration struct {
+	Version  int
+	Name     string
+	UpFile   string
+	DownFile string
+}
+
+// Load は fsys 直下のマイグレーションをバージョン順に返す
+func Load(fsys fs.FS) ([]Migration, error) {
+	files, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("could not find migration files: %w", err)
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, file := range files {
+		version, name, direction, err := ParseFileName(file)
+		if err != nil {
+			log.Printf("[MIGRATION] Skipping file with invalid version format: %s", file)
+			continue
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: name}
+			byVersion[version] = m
+		}
+
+		// 同じバージョン番号に別のマイグレーションがある場合はエラー
+		if m.Name != name || (direction == "up" && m.UpFile != "") || (direction == "down" && m.DownFile != "") {
+			return nil, fmt.Errorf("duplicate migration version %d: %s conflicts with %04d_%s",
+				version, file, m.Version, m.Name)
+		}
+
+		if direction == "up" {
+			m.UpFile = file
+		} else {
+			m.DownFile = file
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.UpFile == "" {
+			return nil, fmt.Errorf("migration %04d has a down file but no up file", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// checksum はマイグレーションファイル（up）の SHA-256 を返す
+func checksum(fsys fs.FS, file string) (string, error) {
+	content, err := fs.ReadFile(fsys, file)
+	if err != nil {
+		return "", fmt.Errorf("error reading migration file %s: %w", file, err)
+	}
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// ParseFileName はファイル名からバージョン・名前・方向を取り出す
+func ParseFileName(file string) (int, string, string, error) {
+	base := path.Base(file)
+	matches := filePattern.FindStringSubmatch(base)
+	if matches == nil {
+		return 0, "", "", fmt.Errorf("invalid migration file name format: %s", base)
+	}
+
+	version, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, "", "", err
+	}
+	return version, matches[2], matches[3], nil
+}
@@ -0,0 +1,83 @@
+package migration
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"backend/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mapFS(names ...string) fstest.MapFS {
+	fsys := fstest.MapFS{}
+	for _, name := range names {
+		fsys[name] = &fstest.MapFile{Data: []byte("SELECT 1;")}
+	}
+	return fsys
+}
+
+func TestLoad(t *testing.T) {
+	fsys := mapFS(
+		"0002_create_users_table.up.sql",
+		"0001_initial_schema.up.sql",
+		"0001_initial_schema.down.sql",
+		"README.sql",
+	)
+
+	migrations, err := Load(fsys)
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	assert.Equal(t, 1, migrations[0].Version)
+	assert.Equal(t, "initial_schema", migrations[0].Name)
+	assert.NotEmpty(t, migrations[0].DownFile)
+	assert.Equal(t, 2, migrations[1].Version)
+	assert.Empty(t, migrations[1].DownFile)
+}
+
+func TestLoad_DownWithoutUp(t *testing.T) {
+	_, err := Load(mapFS("0001_initial_schema.down.sql"))
+	assert.Error(t, err)
+}
+
+func TestLoad_DuplicateVersion(t *testing.T) {
+	_, err := Load(mapFS(
+		"0002_create_users_table.up.sql",
+		"0002_add_user_roles.up.sql",
+	))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate migration version 2")
+}
+
+func TestLoad_DuplicateVersionWithDifferentPadding(t *testing.T) {
+	_, err := Load(mapFS(
+		"0002_create_users_table.up.sql",
+		"2_create_users_table.up.sql",
+	))
+	assert.Error(t, err)
+}
+
+// 埋め込まれたマイグレーションが全て up/down の組になっていること
+// PostgreSQL 用と SQLite 用が同じバージョン・名前で揃っていること
+func TestLoad_Embedded(t *testing.T) {
+	pg, err := Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, pg)
+
+	sqlite, err := Load(migrations.SQLiteFS)
+	require.NoError(t, err)
+
+	for _, loaded := range [][]Migration{pg, sqlite} {
+		for i, mig := range loaded {
+			assert.Equal(t, i+1, mig.Version, "versions should be sequential")
+			assert.NotEmpty(t, mig.DownFile, "migration %04d_%s has no down file", mig.Version, mig.Name)
+		}
+	}
+
+	require.Len(t, sqlite, len(pg), "sqlite migrations should mirror the postgres set")
+	for i := range pg {
+		assert.Equal(t, pg[i].Name, sqlite[i].Name)
+	}
+}
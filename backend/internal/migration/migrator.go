@@ -0,0 +1,438 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"text/tabwriter"
+	"time"
+
+	"backend/internal/config"
+)
+
+// ErrUnknownVersion は存在しないバージョンが指定された場合のエラー
+var ErrUnknownVersion = errors.New("unknown migration version")
+
+// migrationLockKey は pg_advisory_lock に使うキー（同時実行の防止）
+const migrationLockKey int64 = 7245390183
+
+// dbtx はロックを取得したコネクションと、その上のトランザクションの共通インターフェース（repository.DBTX と同じ）
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// appliedMigration は schema_migrations の1行
+type appliedMigration struct {
+	AppliedAt time.Time
+	Checksum  string
+}
+
+// Migrator は schema_migrations を使ってマイグレーションの適用状態を管理する
+type Migrator struct {
+	db         *sql.DB
+	driver     string
+	fsys       fs.FS
+	migrations []Migration
+	checksums  map[int]string
+}
+
+// New は fsys のマイグレーションを読み込む。履歴テーブルは各操作でロックを取得してから準備する
+// driver は db のドライバ（config.DriverPostgres / config.DriverSQLite）
+func New(db *sql.DB, driver string, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[int]string, len(migrations))
+	for _, mig := range migrations {
+		sum, err := checksum(fsys, mig.UpFile)
+		if err != nil {
+			return nil, err
+		}
+		checksums[mig.Version] = sum
+	}
+
+	return &Migrator{db: db, driver: driver, fsys: fsys, migrations: migrations, checksums: checksums}, nil
+}
+
+func (m *Migrator) ensureMigrationTable(ctx context.Context, db dbtx) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version INT PRIMARY KEY
+		);
+		ALTER TABLE schema_migrations
+			ADD COLUMN IF NOT EXISTS applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			ADD COLUMN IF NOT EXISTS checksum TEXT;
+	`
+	if m.driver == config.DriverSQLite {
+		// SQLite 版は checksum 導入後に追加されたため、最初から全ての列を持つ
+		query = `
+			CREATE TABLE IF NOT EXISTS schema_migrations (
+				version INTEGER PRIMARY KEY,
+				applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+				checksum TEXT
+			);
+		`
+	}
+	if _, err := db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("error creating migration table: %w", err)
+	}
+	return nil
+}
+
+// withLock は advisory lock を取得し、履歴テーブルを準備した状態で fn を実行する
+// advisory lock はセッション単位のため、専用のコネクションで取得し、全ての操作をそのコネクションで行う
+// （プールから別のコネクションを取らないため、DB_MAX_OPEN_CONNS=1 でも待ち合わない）
+// SQLite はファイルロックで書き込みが直列化されるため、ロックを取らない
+func (m *Migrator) withLock(fn func(ctx context.Context, conn *sql.Conn) error) error {
+	ctx := context.Background()
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if m.driver == config.DriverSQLite {
+		if err := m.ensureMigrationTable(ctx, conn); err != nil {
+			return err
+		}
+		return fn(ctx, conn)
+	}
+
+	log.Println("[MIGRATION] Acquiring migration lock...")
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", migrationLockKey); err != nil {
+		return fmt.Errorf("error acquiring migration lock: %w", err)
+	}
+	defer func() {
+		if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", migrationLockKey); err != nil {
+			log.Printf("[MIGRATION] Failed to release migration lock: %v", err)
+		}
+	}()
+
+	// 複数のインスタンスが同時に起動しても、履歴テーブルの作成・列の追加が競合しないようロック中に行う
+	if err := m.ensureMigrationTable(ctx, conn); err != nil {
+		return err
+	}
+	// 古いバージョンで作成された履歴には checksum がないため、現在のファイルで補完する
+	if err := m.backfillChecksums(ctx, conn); err != nil {
+		return err
+	}
+	return fn(ctx, conn)
+}
+
+func (m *Migrator) backfillChecksums(ctx context.Context, db dbtx) error {
+	applied, err := m.applied(ctx, db)
+	if err != nil {
+		return err
+	}
+	for version, a := range applied {
+		sum, ok := m.checksums[version]
+		if a.Checksum != "" || !ok {
+			continue
+		}
+		if _, err := db.ExecContext(ctx,
+			"UPDATE schema_migrations SET checksum = $1 WHERE version = $2 AND checksum IS NULL",
+			sum, version,
+		); err != nil {
+			return fmt.Errorf("error recording checksum: %w", err)
+		}
+		log.Printf("[MIGRATION] Recorded checksum for version %d", version)
+	}
+	return nil
+}
+
+// applied は適用済みバージョンごとの適用日時と checksum を返す
+func (m *Migrator) applied(ctx context.Context, db dbtx) (map[int]appliedMigration, error) {
+	rows, err := db.QueryContext(ctx, "SELECT version, applied_at, COALESCE(checksum, '') FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("error reading migration history: %w", err)
+	}
+	defer rows.Close()
+
+	applied := map[int]appliedMigration{}
+	for rows.Next() {
+		var version int
+		var a appliedMigration
+		if err := rows.Scan(&version, &a.AppliedAt, &a.Checksum); err != nil {
+			return nil, err
+		}
+		applied[version] = a
+	}
+	return applied, rows.Err()
+}
+
+// drift は適用後に変更・削除されたマイグレーションを返す
+func (m *Migrator) drift(applied map[int]appliedMigration) []string {
+	var problems []string
+	for _, mig := range m.migrations {
+		a, ok := applied[mig.Version]
+		if !ok || a.Checksum == "" {
+			continue
+		}
+		if a.Checksum != m.checksums[mig.Version] {
+			problems = append(problems, fmt.Sprintf("%s was modified after it was applied", mig.UpFile))
+		}
+	}
+	for version := range applied {
+		if _, ok := m.checksums[version]; !ok {
+			problems = append(problems, fmt.Sprintf("version %04d is applied but its file is missing", version))
+		}
+	}
+	return problems
+}
+
+// Verify は適用済みのファイルが変更されていないことを確認する
+func (m *Migrator) Verify() error {
+	return m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		problems := m.drift(applied)
+		for _, p := range problems {
+			log.Printf("[MIGRATION] ✗ %s", p)
+		}
+		if len(problems) > 0 {
+			return fmt.Errorf("verification failed: %d problem(s) found", len(problems))
+		}
+
+		log.Printf("[MIGRATION] ✓ %d applied migration(s) match their files", len(applied))
+		return nil
+	})
+}
+
+// checkDrift は変更が検出された場合に up / goto を中止する
+func (m *Migrator) checkDrift(applied map[int]appliedMigration) error {
+	if problems := m.drift(applied); len(problems) > 0 {
+		return fmt.Errorf("applied migrations have changed (%s); run `verify` for details", problems[0])
+	}
+	return nil
+}
+
+// Up は未適用のマイグレーションを古い順に適用する（n <= 0 で全件）
+func (m *Migrator) Up(n int) error {
+	return m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkDrift(applied); err != nil {
+			return err
+		}
+
+		count := 0
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; ok {
+				continue
+			}
+			if n > 0 && count >= n {
+				break
+			}
+			if err := m.apply(ctx, conn, mig, mig.UpFile, true); err != nil {
+				return err
+			}
+			count++
+		}
+
+		if count == 0 {
+			log.Println("[MIGRATION] No pending migrations")
+		} else {
+			log.Printf("[MIGRATION] ✓ Applied %d migration(s)", count)
+		}
+		return nil
+	})
+}
+
+// Down は適用済みのマイグレーションを新しい順に n 件ロールバックする
+func (m *Migrator) Down(n int) error {
+	return m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for i := len(m.migrations) - 1; i >= 0 && count < n; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; !ok {
+				continue
+			}
+			if err := m.rollback(ctx, conn, mig); err != nil {
+				return err
+			}
+			count++
+		}
+
+		if count == 0 {
+			log.Println("[MIGRATION] No applied migrations to roll back")
+		} else {
+			log.Printf("[MIGRATION] ✓ Rolled back %d migration(s)", count)
+		}
+		return nil
+	})
+}
+
+// Goto は version 以下を適用済み、それより新しいものを未適用の状態にする
+func (m *Migrator) Goto(version int) error {
+	if err := m.checkVersion(version); err != nil {
+		return err
+	}
+
+	return m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+		if err := m.checkDrift(applied); err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			mig := m.migrations[i]
+			if _, ok := applied[mig.Version]; ok && mig.Version > version {
+				if err := m.rollback(ctx, conn, mig); err != nil {
+					return err
+				}
+			}
+		}
+		for _, mig := range m.migrations {
+			if _, ok := applied[mig.Version]; !ok && mig.Version <= version {
+				if err := m.apply(ctx, conn, mig, mig.UpFile, true); err != nil {
+					return err
+				}
+			}
+		}
+
+		log.Printf("[MIGRATION] ✓ Database is at version %d", version)
+		return nil
+	})
+}
+
+// Force はSQLを実行せずに履歴だけを書き換える（手動で修復した後に使う）
+// 適用済みとして記録するバージョンの checksum も現在のファイルで更新する
+func (m *Migrator) Force(version int) error {
+	if err := m.checkVersion(version); err != nil {
+		return err
+	}
+
+	return m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version > $1", version); err != nil {
+			return fmt.Errorf("error updating migration history: %w", err)
+		}
+		for _, mig := range m.migrations {
+			if mig.Version > version {
+				break
+			}
+			if _, err := tx.ExecContext(ctx, `
+				INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)
+				ON CONFLICT (version) DO UPDATE SET checksum = EXCLUDED.checksum
+			`, mig.Version, m.checksums[mig.Version]); err != nil {
+				return fmt.Errorf("error updating migration history: %w", err)
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		log.Printf("[MIGRATION] ✓ Forced version %d", version)
+		return nil
+	})
+}
+
+// Status は各マイグレーションの適用状態を表示する
+func (m *Migrator) Status(w io.Writer) error {
+	var applied map[int]appliedMigration
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn) (err error) {
+		applied, err = m.applied(ctx, conn)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, mig := range m.migrations {
+		status, appliedAt := "pending", "-"
+		if a, ok := applied[mig.Version]; ok {
+			status, appliedAt = "applied", a.AppliedAt.Format(time.RFC3339)
+			if a.Checksum != "" && a.Checksum != m.checksums[mig.Version] {
+				status = "modified"
+			}
+			delete(applied, mig.Version)
+		}
+		fmt.Fprintf(tw, "%04d\t%s\t%s\t%s\n", mig.Version, mig.Name, status, appliedAt)
+	}
+	// ファイルが存在しない適用済みバージョン
+	for version, a := range applied {
+		fmt.Fprintf(tw, "%04d\t%s\t%s\t%s\n", version, "(missing file)", "applied", a.AppliedAt.Format(time.RFC3339))
+	}
+	return tw.Flush()
+}
+
+func (m *Migrator) checkVersion(version int) error {
+	if version == 0 {
+		return nil
+	}
+	if _, ok := m.checksums[version]; ok {
+		return nil
+	}
+	return fmt.Errorf("%w %d", ErrUnknownVersion, version)
+}
+
+func (m *Migrator) rollback(ctx context.Context, conn *sql.Conn, mig Migration) error {
+	if mig.DownFile == "" {
+		return fmt.Errorf("migration %04d_%s has no down file", mig.Version, mig.Name)
+	}
+	return m.apply(ctx, conn, mig, mig.DownFile, false)
+}
+
+// apply はマイグレーションファイルを実行し、履歴を同じトランザクションで更新する
+func (m *Migrator) apply(ctx context.Context, conn *sql.Conn, mig Migration, file string, up bool) error {
+	content, err := fs.ReadFile(m.fsys, file)
+	if err != nil {
+		return fmt.Errorf("error reading migration file %s: %w", file, err)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+		return fmt.Errorf("error executing migration %s: %w", file, err)
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, checksum) VALUES ($1, $2)",
+			mig.Version, m.checksums[mig.Version],
+		)
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = $1", mig.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("error updating migration history: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing migration %s: %w", file, err)
+	}
+
+	log.Printf("[MIGRATION] ✓ %s", file)
+	return nil
+}
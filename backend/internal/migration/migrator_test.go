@@ -0,0 +1,177 @@
+package migration
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/storage"
+	"backend/migrations"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigratorDrift(t *testing.T) {
+	m := &Migrator{
+		migrations: []Migration{
+			{Version: 1, Name: "initial_schema", UpFile: "0001_initial_schema.up.sql"},
+			{Version: 2, Name: "create_users_table", UpFile: "0002_create_users_table.up.sql"},
+		},
+		checksums: map[int]string{1: "aaa", 2: "bbb"},
+	}
+
+	t.Run("一致", func(t *testing.T) {
+		applied := map[int]appliedMigration{
+			1: {AppliedAt: time.Now(), Checksum: "aaa"},
+			2: {AppliedAt: time.Now(), Checksum: "bbb"},
+		}
+		assert.Empty(t, m.drift(applied))
+	})
+
+	t.Run("変更されたファイル", func(t *testing.T) {
+		applied := map[int]appliedMigration{
+			1: {AppliedAt: time.Now(), Checksum: "changed"},
+		}
+		assert.Len(t, m.drift(applied), 1)
+	})
+
+	t.Run("ファイルが存在しない", func(t *testing.T) {
+		applied := map[int]appliedMigration{
+			3: {AppliedAt: time.Now(), Checksum: "ccc"},
+		}
+		assert.Len(t, m.drift(applied), 1)
+	})
+
+	t.Run("checksum未記録は対象外", func(t *testing.T) {
+		applied := map[int]appliedMigration{
+			1: {AppliedAt: time.Now()},
+		}
+		assert.Empty(t, m.drift(applied))
+	})
+}
+
+// newSQLiteStore は一時ファイルの SQLite データベースを開く
+func newSQLiteStore(t *testing.T) *storage.Store {
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.Path = filepath.Join(t.TempDir(), "migrate.db")
+	store, err := storage.New(context.Background(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+// SQLite 用マイグレーションの up / down が一通り実行できること
+func TestMigrator_SQLite(t *testing.T) {
+	store := newSQLiteStore(t)
+
+	m, err := New(store.DB, config.DriverSQLite, migrations.SQLiteFS)
+	require.NoError(t, err)
+
+	appliedCount := func() int {
+		var n int
+		require.NoError(t, store.DB.QueryRow("SELECT COUNT(*) FROM schema_migrations").Scan(&n))
+		return n
+	}
+
+	require.NoError(t, m.Up(0))
+	assert.Equal(t, len(m.migrations), appliedCount())
+	require.NoError(t, m.Verify())
+
+	require.NoError(t, m.Down(1))
+	assert.Equal(t, len(m.migrations)-1, appliedCount())
+
+	require.NoError(t, m.Goto(0))
+	assert.Equal(t, 0, appliedCount())
+
+	require.NoError(t, m.Up(0))
+	require.NoError(t, m.Force(2))
+	assert.Equal(t, 2, appliedCount())
+
+	var out bytes.Buffer
+	require.NoError(t, m.Status(&out))
+	assert.Contains(t, out.String(), "pending")
+}
+
+// 全ての操作をロックを取得したコネクションで行うため、接続数が1でも待ち合わない
+func TestMigrator_SingleConnection(t *testing.T) {
+	store := newSQLiteStore(t)
+	store.DB.SetMaxOpenConns(1)
+
+	m, err := New(store.DB, config.DriverSQLite, migrations.SQLiteFS)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		if err := m.Up(0); err != nil {
+			done <- err
+			return
+		}
+		done <- m.Force(2)
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("migration is waiting for a second connection")
+	}
+}
+
+// 未作成の履歴テーブルは最初の操作で作成する（New ではDBを変更しない）
+func TestMigrator_CreatesHistoryTableOnFirstUse(t *testing.T) {
+	store := newSQLiteStore(t)
+
+	m, err := New(store.DB, config.DriverSQLite, migrations.SQLiteFS)
+	require.NoError(t, err)
+	var n int
+	require.NoError(t, store.DB.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE name = 'schema_migrations'").Scan(&n))
+	assert.Equal(t, 0, n)
+
+	var out bytes.Buffer
+	require.NoError(t, m.Status(&out))
+	assert.Contains(t, out.String(), "pending")
+	assert.NotContains(t, out.String(), "applied")
+}
+
+// 0009 は投入時のままのサンプルデータだけを削除する
+func TestMigration_RemoveSampleData(t *testing.T) {
+	store := newSQLiteStore(t)
+	m, err := New(store.DB, config.DriverSQLite, migrations.SQLiteFS)
+	require.NoError(t, err)
+	require.NoError(t, m.Goto(8))
+
+	const seededHash = "$2a$10$uoZqVUeLQWgDuFO24r5Eo.5v63qs0gq0W03brjMccY9rT8kXTzGS2"
+	exec := func(query string, args ...any) {
+		t.Helper()
+		_, err := store.DB.Exec(query, args...)
+		require.NoError(t, err)
+	}
+	count := func(query string, args ...any) int {
+		t.Helper()
+		var n int
+		require.NoError(t, store.DB.QueryRow(query, args...).Scan(&n))
+		return n
+	}
+	exec("INSERT INTO users (username, email, password_hash) VALUES ('testuser', 'test@example.com', $1)", seededHash)
+	exec("INSERT INTO users (username, email, password_hash) VALUES ('alice', 'alice@example.com', $1)", seededHash)
+	exec(`INSERT INTO sprints (name, color, is_favorite) VALUES
+		('バックログ', 'bg-blue-500', false), ('2510-4', 'bg-purple-500', false), ('Personal Sprint', 'bg-green-500', true)`)
+	exec("INSERT INTO todos (title, sprint_id) SELECT 'In use', id FROM sprints WHERE name = '2510-4'")
+
+	require.NoError(t, m.Up(0))
+	assert.Zero(t, count("SELECT COUNT(*) FROM users WHERE username = 'testuser'"))
+	assert.Equal(t, 1, count("SELECT COUNT(*) FROM users WHERE username = 'alice'"))
+	// TODO があるスプリントと、色を変更したスプリントは残す
+	assert.Zero(t, count("SELECT COUNT(*) FROM sprints WHERE name = 'バックログ'"))
+	assert.Equal(t, 2, count("SELECT COUNT(*) FROM sprints"))
+
+	// パスワードを変更した testuser は残す
+	require.NoError(t, m.Goto(8))
+	exec("INSERT INTO users (username, email, password_hash) VALUES ('testuser', 'test@example.com', 'changed')")
+	require.NoError(t, m.Up(0))
+	assert.Equal(t, 1, count("SELECT COUNT(*) FROM users WHERE username = 'testuser'"))
+}
@@ -0,0 +1,22 @@
+package model
+
+import "backend/internal/types"
+
+// 認証失敗の理由
+const (
+	AuthFailureUnknownUser     = "unknown_user"
+	AuthFailureInvalidPassword = "invalid_password"
+	AuthFailureRateLimited     = "rate_limited"
+	AuthFailureLocked          = "account_locked"
+	AuthFailureInvalidMFACode  = "invalid_mfa_code"
+)
+
+// AuthAuditLog は認証試行の監査ログ
+type AuthAuditLog struct {
+	ID        int              `json:"id"`
+	Username  string           `json:"username"`
+	IPAddress string           `json:"ip_address"`
+	UserAgent string           `json:"user_agent"`
+	Reason    string           `json:"reason"`
+	CreatedAt types.CustomTime `json:"created_at"`
+}
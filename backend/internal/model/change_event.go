@@ -0,0 +1,27 @@
+package model
+
+// ChangeEvent.Type の値（"todo.created" などの対象と種類の組み合わせ以外）
+const (
+	// ChangeEventReset は Last-Event-ID から再開できなかったことを表す。一覧を読み直す
+	ChangeEventReset = "reset"
+)
+
+// ChangeEvent は GET /events（SSE / WebSocket）で送る TODO・スプリントの変更
+type ChangeEvent struct {
+	// ID はイベントID。再接続するときに Last-Event-ID（last_event_id）で送り返す
+	ID int64 `json:"id"`
+	// Type は "todo.created" / "todo.updated" / "todo.deleted" / "sprint.created" など、または "reset"
+	Type string `json:"type"`
+	// EntityID は変更された TODO・スプリントのID
+	EntityID int `json:"entity_id,omitempty"`
+	// Todo / Sprint は作成・更新後の値（削除では省略）
+	Todo   *Todo   `json:"todo,omitempty"`
+	Sprint *Sprint `json:"sprint,omitempty"`
+}
+
+// EventStreamTicketResponse は GET /events に接続するためのチケット
+type EventStreamTicketResponse struct {
+	Ticket string `json:"ticket"`
+	// ExpiresIn はチケットの有効期限（秒）。接続した後は期限が過ぎても購読を続けられる
+	ExpiresIn int `json:"expires_in"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import "backend/internal/types"
+
+// UserMFA はユーザーのTOTP設定
+type UserMFA struct {
+	UserID       int               `json:"user_id"`
+	Secret       string            `json:"-"`
+	Enabled      bool              `json:"enabled"`
+	LastUsedStep *int64            `json:"-"`
+	ConfirmedAt  *types.CustomTime `json:"confirmed_at,omitempty"`
+	CreatedAt    types.CustomTime  `json:"created_at"`
+	UpdatedAt    types.CustomTime  `json:"updated_at"`
+}
+
+type MFAEnrollResponse struct {
+	Secret string `json:"secret"`
+	// OTPAuthURI は認証アプリに登録するためのURI（QRコードのペイロード）
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code"`
+}
+
+type MFAConfirmResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+	// 登録用トークンで確認した場合のみ、最終的なJWTを返す
+	Token string `json:"token,omitempty"`
+	User  *User  `json:"user,omitempty"`
+}
+
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
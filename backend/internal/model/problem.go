@@ -0,0 +1,13 @@
+package model
+
+// Problem はエラーレスポンス（RFC 7807 application/problem+json）
+type Problem struct {
+	Type      string       `json:"type" example:"about:blank"`
+	Title     string       `json:"title" example:"Not Found"`
+	Status    int          `json:"status" example:"404"`
+	Detail    string       `json:"detail,omitempty" example:"Todo not found"`
+	Instance  string       `json:"instance,omitempty" example:"/todos/1"`
+	Code      string       `json:"code" example:"todo_not_found"`
+	RequestID string       `json:"request_id,omitempty" example:"3f2b9c1e8a7d4e6f"`
+	Errors    []FieldError `json:"errors,omitempty"`
+}
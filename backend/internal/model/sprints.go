@@ -4,9 +4,10 @@ import "backend/internal/types"
 
 type Sprint struct {
 	ID         int              `json:"id" gorm:"primaryKey"`
-	Name       string           `json:"name" gorm:"not null"`
-	Color      string           `json:"color"`
+	Name       string           `json:"name" gorm:"not null" validate:"required,max=255"`
+	Color      string           `json:"color" validate:"color"`
 	IsFavorite bool             `json:"is_favorite" gorm:"default:false"`
+	Version    int              `json:"version"` // 更新のたびに増える行バージョン（ETag）
 	CreatedAt  types.CustomTime `json:"created_at"`
 	UpdatedAt  types.CustomTime `json:"updated_at"`
 }
@@ -4,10 +4,11 @@ import "backend/internal/types"
 
 type Todo struct {
 	ID          int              `json:"id"`
-	Title       string           `json:"title"`
+	Title       string           `json:"title" validate:"required,max=255"`
 	Description string           `json:"description"`
 	Completed   bool             `json:"completed"`
-	SprintID    *int             `json:"sprint_id"`
+	SprintID    *int             `json:"sprint_id" validate:"exists=sprint" extensions:"x-nullable"`
+	Version     int              `json:"version"` // 更新のたびに増える行バージョン（ETag）
 	CreatedAt   types.CustomTime `json:"created_at"`
 	UpdatedAt   types.CustomTime `json:"updated_at"`
 }
@@ -0,0 +1,58 @@
+package model
+
+// 一括操作の種類
+const (
+	BulkOpCreate   = "create"
+	BulkOpUpdate   = "update"
+	BulkOpComplete = "complete"
+	BulkOpMove     = "move"
+	BulkOpDelete   = "delete"
+)
+
+// 一括操作の結果の扱い
+const (
+	// BulkModeAtomic は1件でも失敗すれば全ての操作を取り消す
+	BulkModeAtomic = "atomic"
+	// BulkModePerItem は成功した操作だけを反映し、各件の結果を返す
+	BulkModePerItem = "per_item"
+)
+
+// BulkTodoRequest は POST /todos/bulk のリクエスト
+type BulkTodoRequest struct {
+	Mode       string              `json:"mode" validate:"oneof=atomic per_item" example:"atomic"` // 省略時は atomic
+	Operations []BulkTodoOperation `json:"operations" validate:"required"`
+}
+
+// BulkTodoOperation は一括操作の1件。op によって使うフィールドが異なる
+//   - create:   title（必須）, description, sprint_id
+//   - update:   id, title（必須）, completed, version
+//   - complete: id, completed（省略時は true）, version
+//   - move:     id, sprint_id（null ならスプリントから外す）, version
+//   - delete:   id, version
+type BulkTodoOperation struct {
+	Op          string `json:"op" validate:"required,oneof=create update complete move delete" example:"complete"`
+	ID          int    `json:"id" example:"1"`
+	Title       string `json:"title" validate:"max=255"`
+	Description string `json:"description"`
+	Completed   *bool  `json:"completed"`
+	SprintID    *int   `json:"sprint_id" validate:"exists=sprint" extensions:"x-nullable"`
+	Version     int    `json:"version"` // 0 ならバージョンを確認しない（If-Match を省略した場合と同じ）
+}
+
+// BulkTodoResult は一括操作の1件の結果
+type BulkTodoResult struct {
+	Index int    `json:"index"`
+	Op    string `json:"op"`
+	// Status は同じ操作を個別のAPIで実行した場合のステータスコード
+	Status int      `json:"status" example:"200"`
+	Todo   *Todo    `json:"todo,omitempty"`  // 成功した create / update / complete / move の結果
+	Error  *Problem `json:"error,omitempty"` // 失敗した場合
+}
+
+// BulkTodoResponse は POST /todos/bulk のレスポンス
+type BulkTodoResponse struct {
+	Mode      string           `json:"mode"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkTodoResult `json:"results"`
+}
@@ -9,23 +9,60 @@ type User struct {
 	PasswordHash string           `json:"-"` // JSONには含めない
 	ExternalID   *string          `json:"external_id,omitempty"`
 	Provider     string           `json:"provider"`
+	Role         string           `json:"role"`
+	TokenVersion int              `json:"-"` // 強制ログアウト時にインクリメント
 	IsActive     bool             `json:"is_active"`
 	CreatedAt    types.CustomTime `json:"created_at"`
 	UpdatedAt    types.CustomTime `json:"updated_at"`
 }
 
+// ユーザーのロール
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 type LoginRequest struct {
-	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username string `json:"username"`
+	Password string `json:"password"`
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
-	User  User   `json:"user"`
+	Token string `json:"token,omitempty"`
+	User  *User  `json:"user,omitempty"`
+	// MFAが必要な場合は Token の代わりに短命の MFAToken を返す
+	MFARequired           bool   `json:"mfa_required,omitempty"`
+	MFAEnrollmentRequired bool   `json:"mfa_enrollment_required,omitempty"`
+	MFAToken              string `json:"mfa_token,omitempty"`
 }
 
 type RegisterRequest struct {
-	Username string `json:"username" binding:"required"`
-	Email    string `json:"email" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	Username string `json:"username" validate:"required,username"`
+	Email    string `json:"email" validate:"required,email"`
+	Password string `json:"password" validate:"required,password=username"`
+}
+
+type UserSearchRequest struct {
+	Query    *string `json:"query"`                            // ユーザー名・メールアドレスの部分一致（任意）
+	IsActive *bool   `json:"is_active"`                        // 有効・無効でフィルタ（任意）
+	Role     *string `json:"role" validate:"oneof=user admin"` // ロールでフィルタ（任意）
+	Limit    int     `json:"limit"`
+	Offset   int     `json:"offset"`
+}
+
+type UpdateRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user admin"`
+}
+
+// SystemStats は管理者向けのシステム統計
+type SystemStats struct {
+	Users           int `json:"users"`
+	ActiveUsers     int `json:"active_users"`
+	Admins          int `json:"admins"`
+	MFAEnabled      int `json:"mfa_enabled"`
+	Todos           int `json:"todos"`
+	CompletedTodos  int `json:"completed_todos"`
+	Sprints         int `json:"sprints"`
+	Workspaces      int `json:"workspaces"`
+	FailedLogins24h int `json:"failed_logins_24h"`
 }
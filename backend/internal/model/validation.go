@@ -0,0 +1,8 @@
+package model
+
+// FieldError はフィールド単位の検証エラー
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
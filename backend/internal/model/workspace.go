@@ -0,0 +1,37 @@
+package model
+
+import "backend/internal/types"
+
+// ワークスペース内のロール
+const (
+	WorkspaceRoleOwner  = "owner"
+	WorkspaceRoleAdmin  = "admin"
+	WorkspaceRoleMember = "member"
+)
+
+type Workspace struct {
+	ID          int              `json:"id"`
+	Name        string           `json:"name"`
+	MFARequired bool             `json:"mfa_required"`
+	Role        string           `json:"role,omitempty"` // 取得したユーザーのロール
+	CreatedAt   types.CustomTime `json:"created_at"`
+	UpdatedAt   types.CustomTime `json:"updated_at"`
+}
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type AddWorkspaceMemberRequest struct {
+	Username string `json:"username" validate:"required"`
+	Role     string `json:"role" validate:"oneof=admin member"` // 省略時は member
+}
+
+// UpdateWorkspaceMemberRoleRequest はメンバーのロールの変更（オーナーのみ。オーナーのロールは変更できない）
+type UpdateWorkspaceMemberRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=admin member"`
+}
+
+type UpdateMFARequirementRequest struct {
+	MFARequired bool `json:"mfa_required"`
+}
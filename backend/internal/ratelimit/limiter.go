@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Config はレートリミッタの設定
+type Config struct {
+	// IPごとのリクエスト数上限（RequestWindow あたり）
+	RequestLimit  int
+	RequestWindow time.Duration
+
+	// ログイン失敗を数える期間
+	FailureWindow time.Duration
+	// バックオフなしで許容する失敗回数
+	FreeAttempts int
+	// バックオフの初期値と上限（失敗ごとに2倍）
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+
+	// この回数失敗したユーザー名は LockoutDuration の間ロックする
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+}
+
+// DefaultConfig はデフォルト設定を返す
+func DefaultConfig() Config {
+	return Config{
+		RequestLimit:     20,
+		RequestWindow:    time.Minute,
+		FailureWindow:    15 * time.Minute,
+		FreeAttempts:     3,
+		BaseDelay:        time.Second,
+		MaxDelay:         5 * time.Minute,
+		LockoutThreshold: 10,
+		LockoutDuration:  15 * time.Minute,
+	}
+}
+
+// LimitError は制限に達したことを表すエラー
+type LimitError struct {
+	RetryAfter time.Duration
+	// Locked はユーザー名がロックアウトされている場合に true
+	Locked bool
+}
+
+func (e *LimitError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("account temporarily locked, retry after %s", e.RetryAfter)
+	}
+	return fmt.Sprintf("too many attempts, retry after %s", e.RetryAfter)
+}
+
+// Limiter はIPとユーザー名をキーにログイン試行を制限する
+type Limiter struct {
+	store Store
+	cfg   Config
+	now   func() time.Time
+}
+
+func NewLimiter(store Store, cfg Config) *Limiter {
+	return &Limiter{store: store, cfg: cfg, now: time.Now}
+}
+
+// AllowRequest はIPごとのリクエスト数を数え、上限を超えていれば LimitError を返す
+func (l *Limiter) AllowRequest(ctx context.Context, ip string) error {
+	now := l.now()
+	e, err := l.store.Increment(ctx, requestKey(ip), l.cfg.RequestWindow, now)
+	if err != nil {
+		return err
+	}
+
+	if e.Count > l.cfg.RequestLimit {
+		return &LimitError{RetryAfter: e.WindowStart.Add(l.cfg.RequestWindow).Sub(now)}
+	}
+	return nil
+}
+
+// CheckLogin はIPまたはユーザー名がブロック中なら LimitError を返す
+func (l *Limiter) CheckLogin(ctx context.Context, ip, username string) error {
+	now := l.now()
+
+	userEntry, err := l.store.Get(ctx, userKey(username))
+	if err != nil {
+		return err
+	}
+	if now.Before(userEntry.BlockedUntil) {
+		return &LimitError{
+			RetryAfter: userEntry.BlockedUntil.Sub(now),
+			Locked:     userEntry.Count >= l.cfg.LockoutThreshold,
+		}
+	}
+
+	ipEntry, err := l.store.Get(ctx, ipKey(ip))
+	if err != nil {
+		return err
+	}
+	if now.Before(ipEntry.BlockedUntil) {
+		return &LimitError{RetryAfter: ipEntry.BlockedUntil.Sub(now)}
+	}
+
+	return nil
+}
+
+// RecordFailure はログイン失敗を記録し、必要に応じてバックオフ・ロックアウトを設定する
+// ロックアウトが発生した場合は true を返す
+func (l *Limiter) RecordFailure(ctx context.Context, ip, username string) (bool, error) {
+	now := l.now()
+
+	ipEntry, err := l.store.Increment(ctx, ipKey(ip), l.cfg.FailureWindow, now)
+	if err != nil {
+		return false, err
+	}
+	if delay := l.backoff(ipEntry.Count); delay > 0 {
+		if err := l.store.Block(ctx, ipKey(ip), now.Add(delay)); err != nil {
+			return false, err
+		}
+	}
+
+	userEntry, err := l.store.Increment(ctx, userKey(username), l.cfg.FailureWindow, now)
+	if err != nil {
+		return false, err
+	}
+
+	if userEntry.Count >= l.cfg.LockoutThreshold {
+		return true, l.store.Block(ctx, userKey(username), now.Add(l.cfg.LockoutDuration))
+	}
+	if delay := l.backoff(userEntry.Count); delay > 0 {
+		if err := l.store.Block(ctx, userKey(username), now.Add(delay)); err != nil {
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// RecordSuccess はログイン成功時にユーザー名の失敗カウンタをリセットする
+// IPの失敗カウンタはリセットしない（1つのアカウントでログインに成功しても、同じIPからの他のアカウントへの試行は数え続ける）。FailureWindow が過ぎると数え直す
+func (l *Limiter) RecordSuccess(ctx context.Context, username string) error {
+	return l.store.Reset(ctx, userKey(username))
+}
+
+// backoff は失敗回数に応じた待ち時間を返す（FreeAttempts 以下なら0）
+func (l *Limiter) backoff(failures int) time.Duration {
+	over := failures - l.cfg.FreeAttempts
+	if over <= 0 {
+		return 0
+	}
+
+	delay := float64(l.cfg.BaseDelay) * math.Pow(2, float64(over-1))
+	if delay > float64(l.cfg.MaxDelay) {
+		return l.cfg.MaxDelay
+	}
+	return time.Duration(delay)
+}
+
+func requestKey(ip string) string {
+	return "req:ip:" + ip
+}
+
+func ipKey(ip string) string {
+	return "fail:ip:" + ip
+}
+
+func userKey(username string) string {
+	return "fail:user:" + strings.ToLower(strings.TrimSpace(username))
+}
@@ -0,0 +1,156 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLimiter(cfg Config) (*Limiter, *time.Time) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	l := NewLimiter(NewMemoryStore(), cfg)
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestLimiter_AllowRequest(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.RequestLimit = 2
+	l, now := newTestLimiter(cfg)
+	ctx := context.Background()
+
+	assert.NoError(t, l.AllowRequest(ctx, "10.0.0.1"))
+	assert.NoError(t, l.AllowRequest(ctx, "10.0.0.1"))
+
+	err := l.AllowRequest(ctx, "10.0.0.1")
+	var limitErr *LimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, time.Minute, limitErr.RetryAfter)
+
+	// 別IPは影響を受けない
+	assert.NoError(t, l.AllowRequest(ctx, "10.0.0.2"))
+
+	// ウィンドウが切れたらリセット
+	*now = now.Add(time.Minute)
+	assert.NoError(t, l.AllowRequest(ctx, "10.0.0.1"))
+}
+
+func TestLimiter_Backoff(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FreeAttempts = 2
+	l, now := newTestLimiter(cfg)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := l.RecordFailure(ctx, "10.0.0.1", "alice")
+		require.NoError(t, err)
+		assert.NoError(t, l.CheckLogin(ctx, "10.0.0.1", "alice"))
+	}
+
+	// 3回目の失敗で1秒、4回目で2秒のバックオフ
+	_, err := l.RecordFailure(ctx, "10.0.0.1", "alice")
+	require.NoError(t, err)
+	err = l.CheckLogin(ctx, "10.0.0.1", "alice")
+	var limitErr *LimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, time.Second, limitErr.RetryAfter)
+	assert.False(t, limitErr.Locked)
+
+	*now = now.Add(time.Second)
+	_, err = l.RecordFailure(ctx, "10.0.0.1", "alice")
+	require.NoError(t, err)
+	err = l.CheckLogin(ctx, "10.0.0.1", "alice")
+	require.True(t, errors.As(err, &limitErr))
+	assert.Equal(t, 2*time.Second, limitErr.RetryAfter)
+}
+
+func TestLimiter_Lockout(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FreeAttempts = 100
+	cfg.LockoutThreshold = 3
+	l, now := newTestLimiter(cfg)
+	ctx := context.Background()
+
+	var locked bool
+	// IPを変えてもユーザー名単位でロックされる
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		var err error
+		locked, err = l.RecordFailure(ctx, ip, "Alice")
+		require.NoError(t, err)
+	}
+	assert.True(t, locked)
+
+	err := l.CheckLogin(ctx, "10.0.0.9", "alice")
+	var limitErr *LimitError
+	require.True(t, errors.As(err, &limitErr))
+	assert.True(t, limitErr.Locked)
+	assert.Equal(t, cfg.LockoutDuration, limitErr.RetryAfter)
+
+	*now = now.Add(cfg.LockoutDuration)
+	assert.NoError(t, l.CheckLogin(ctx, "10.0.0.9", "alice"))
+}
+
+func TestLimiter_RecordSuccessResets(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FreeAttempts = 1
+	l, _ := newTestLimiter(cfg)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := l.RecordFailure(ctx, "10.0.0.1", "alice")
+		require.NoError(t, err)
+	}
+	require.Error(t, l.CheckLogin(ctx, "10.0.0.1", "alice"))
+
+	// ユーザー名の失敗はリセットされる
+	require.NoError(t, l.RecordSuccess(ctx, "alice"))
+	assert.NoError(t, l.CheckLogin(ctx, "10.0.0.2", "alice"))
+	_, err := l.RecordFailure(ctx, "10.0.0.2", "alice")
+	require.NoError(t, err)
+	assert.NoError(t, l.CheckLogin(ctx, "10.0.0.2", "alice"))
+}
+
+func TestLimiter_RecordSuccessKeepsIPFailures(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.FreeAttempts = 1
+	l, now := newTestLimiter(cfg)
+	ctx := context.Background()
+
+	// 同じIPから複数のアカウントを試す
+	for _, username := range []string{"alice", "bob"} {
+		_, err := l.RecordFailure(ctx, "10.0.0.1", username)
+		require.NoError(t, err)
+	}
+	require.NoError(t, l.RecordSuccess(ctx, "carol"))
+
+	// 1つのアカウントでログインに成功しても、IPのバックオフは続く
+	var limitErr *LimitError
+	require.ErrorAs(t, l.CheckLogin(ctx, "10.0.0.1", "carol"), &limitErr)
+	assert.False(t, limitErr.Locked)
+
+	// IPの失敗は FailureWindow が過ぎるまで数え続ける
+	*now = now.Add(cfg.BaseDelay)
+	require.NoError(t, l.CheckLogin(ctx, "10.0.0.1", "dave"))
+	_, err := l.RecordFailure(ctx, "10.0.0.1", "dave")
+	require.NoError(t, err)
+	require.ErrorAs(t, l.CheckLogin(ctx, "10.0.0.1", "erin"), &limitErr)
+	assert.Equal(t, 2*cfg.BaseDelay, limitErr.RetryAfter)
+
+	*now = now.Add(cfg.FailureWindow)
+	_, err = l.RecordFailure(ctx, "10.0.0.1", "frank")
+	require.NoError(t, err)
+	assert.NoError(t, l.CheckLogin(ctx, "10.0.0.1", "grace"))
+}
+
+func TestLimiter_BackoffIsCapped(t *testing.T) {
+	cfg := DefaultConfig()
+	l, _ := newTestLimiter(cfg)
+
+	assert.Equal(t, time.Duration(0), l.backoff(cfg.FreeAttempts))
+	assert.Equal(t, cfg.BaseDelay, l.backoff(cfg.FreeAttempts+1))
+	assert.Equal(t, cfg.MaxDelay, l.backoff(cfg.FreeAttempts+50))
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepThreshold を超えたら期限切れのエントリを掃除する
+const sweepThreshold = 10000
+
+// MemoryStore はプロセス内で状態を保持するStore（単一インスタンス向け）
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*memoryEntry
+}
+
+type memoryEntry struct {
+	Entry
+	expiresAt time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*memoryEntry)}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		return Entry{}, nil
+	}
+	return e.Entry, nil
+}
+
+func (s *MemoryStore) Increment(ctx context.Context, key string, window time.Duration, now time.Time) (Entry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.entries) > sweepThreshold {
+		s.sweep(now)
+	}
+
+	e, ok := s.entries[key]
+	if !ok {
+		e = &memoryEntry{}
+		s.entries[key] = e
+	}
+
+	// ウィンドウが切れていればカウンタをリセット
+	if e.WindowStart.IsZero() || !now.Before(e.WindowStart.Add(window)) {
+		e.Count = 0
+		e.WindowStart = now
+	}
+	e.Count++
+	e.expiresAt = later(e.WindowStart.Add(window), e.BlockedUntil)
+
+	return e.Entry, nil
+}
+
+func (s *MemoryStore) Block(ctx context.Context, key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, ok := s.entries[key]
+	if !ok {
+		e = &memoryEntry{}
+		s.entries[key] = e
+	}
+	e.BlockedUntil = until
+	e.expiresAt = later(e.expiresAt, until)
+	return nil
+}
+
+func (s *MemoryStore) Reset(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.entries, key)
+	return nil
+}
+
+// sweep は期限切れのエントリを削除する（ロック取得済みで呼ぶこと）
+func (s *MemoryStore) sweep(now time.Time) {
+	for key, e := range s.entries {
+		if now.After(e.expiresAt) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
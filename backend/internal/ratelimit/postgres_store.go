@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// PostgresStore は rate_limits テーブルに状態を保存するStore
+// 再起動後や複数インスタンス間でも制限が維持される
+type PostgresStore struct {
+	db *sql.DB
+}
+
+func NewPostgresStore(db *sql.DB) *PostgresStore {
+	return &PostgresStore{db: db}
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (Entry, error) {
+	var e Entry
+	var blockedUntil sql.NullTime
+	err := s.db.QueryRowContext(ctx,
+		"SELECT count, window_start, blocked_until FROM rate_limits WHERE key = $1",
+		key,
+	).Scan(&e.Count, &e.WindowStart, &blockedUntil)
+
+	if err == sql.ErrNoRows {
+		return Entry{}, nil
+	}
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e.BlockedUntil = blockedUntil.Time
+	return e, nil
+}
+
+func (s *PostgresStore) Increment(ctx context.Context, key string, window time.Duration, now time.Time) (Entry, error) {
+	now = now.UTC()
+	windowExpired := now.Add(-window)
+
+	var e Entry
+	var blockedUntil sql.NullTime
+	err := s.db.QueryRowContext(ctx, `
+		INSERT INTO rate_limits (key, count, window_start)
+		VALUES ($1, 1, $2)
+		ON CONFLICT (key) DO UPDATE SET
+			count = CASE WHEN rate_limits.window_start <= $3 THEN 1 ELSE rate_limits.count + 1 END,
+			window_start = CASE WHEN rate_limits.window_start <= $3 THEN $2 ELSE rate_limits.window_start END
+		RETURNING count, window_start, blocked_until
+	`, key, now, windowExpired).Scan(&e.Count, &e.WindowStart, &blockedUntil)
+
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e.BlockedUntil = blockedUntil.Time
+	return e, nil
+}
+
+func (s *PostgresStore) Block(ctx context.Context, key string, until time.Time) error {
+	until = until.UTC()
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO rate_limits (key, count, window_start, blocked_until)
+		VALUES ($1, 0, CURRENT_TIMESTAMP AT TIME ZONE 'UTC', $2)
+		ON CONFLICT (key) DO UPDATE SET blocked_until = $2
+	`, key, until)
+	return err
+}
+
+func (s *PostgresStore) Reset(ctx context.Context, key string) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM rate_limits WHERE key = $1", key)
+	return err
+}
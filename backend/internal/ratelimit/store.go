@@ -0,0 +1,25 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Entry はキーごとのカウンタ状態
+type Entry struct {
+	Count        int
+	WindowStart  time.Time
+	BlockedUntil time.Time
+}
+
+// Store はレートリミッタの状態を保存するバックエンド
+type Store interface {
+	// Get はキーの現在の状態を返す（存在しない場合はゼロ値）
+	Get(ctx context.Context, key string) (Entry, error)
+	// Increment はカウントを1増やして更新後の状態を返す。ウィンドウが切れている場合は1から数え直す
+	Increment(ctx context.Context, key string, window time.Duration, now time.Time) (Entry, error)
+	// Block は指定時刻までキーをブロックする
+	Block(ctx context.Context, key string, until time.Time) error
+	// Reset はキーの状態を削除する
+	Reset(ctx context.Context, key string) error
+}
@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/config"
+	"backend/internal/storage/storagetest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testStore は Store の実装に共通のテスト（key は他のテストと重ならないよう呼び出し側で決める）
+func testStore(t *testing.T, s Store, key string) {
+	ctx := context.Background()
+	// PostgreSQL の TIMESTAMP はマイクロ秒まで
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	window := time.Minute
+
+	// 存在しないキーはゼロ値
+	e, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, Entry{}, e)
+
+	// ウィンドウ内は数え続ける
+	for i := 1; i <= 3; i++ {
+		e, err = s.Increment(ctx, key, window, now.Add(time.Duration(i)*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, i, e.Count)
+		assert.True(t, e.WindowStart.Equal(now.Add(time.Second)), e.WindowStart)
+	}
+
+	// 別のキーは影響を受けない
+	other, err := s.Increment(ctx, key+":other", window, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, other.Count)
+
+	// ブロックはカウントを変えず、以降の Increment でも残る
+	until := now.Add(time.Hour)
+	require.NoError(t, s.Block(ctx, key, until))
+	e, err = s.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, 3, e.Count)
+	assert.True(t, e.BlockedUntil.Equal(until), e.BlockedUntil)
+
+	// ウィンドウが切れたら1から数え直す
+	later := now.Add(time.Second + window)
+	e, err = s.Increment(ctx, key, window, later)
+	require.NoError(t, err)
+	assert.Equal(t, 1, e.Count)
+	assert.True(t, e.WindowStart.Equal(later), e.WindowStart)
+	assert.True(t, e.BlockedUntil.Equal(until), e.BlockedUntil)
+
+	// 存在しないキーもブロックできる
+	require.NoError(t, s.Block(ctx, key+":blocked", until))
+	e, err = s.Get(ctx, key+":blocked")
+	require.NoError(t, err)
+	assert.Equal(t, 0, e.Count)
+	assert.True(t, e.BlockedUntil.Equal(until), e.BlockedUntil)
+
+	// リセットすると状態がなくなる
+	require.NoError(t, s.Reset(ctx, key))
+	e, err = s.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, Entry{}, e)
+	other, err = s.Get(ctx, key+":other")
+	require.NoError(t, err)
+	assert.Equal(t, 1, other.Count)
+}
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore(), "fail:user:alice")
+}
+
+// TestPostgresStore は TEST_DB_CONN を設定した場合のみ実行する（SQLite では使わない）
+func TestPostgresStore(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		if b.Name != config.DriverPostgres {
+			continue
+		}
+		storagetest.Truncate(t, b.DB, "rate_limits")
+		testStore(t, NewPostgresStore(b.DB), "fail:user:alice")
+		return
+	}
+	t.Skip("TEST_DB_CONN not set")
+}
+
+func TestLimiter_PostgresStore(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		if b.Name != config.DriverPostgres {
+			continue
+		}
+		storagetest.Truncate(t, b.DB, "rate_limits")
+
+		cfg := DefaultConfig()
+		cfg.FreeAttempts = 1
+		l := NewLimiter(NewPostgresStore(b.DB), cfg)
+		ctx := context.Background()
+
+		for i := 0; i < 2; i++ {
+			_, err := l.RecordFailure(ctx, "10.0.0.1", "alice")
+			require.NoError(t, err)
+		}
+		var limitErr *LimitError
+		require.ErrorAs(t, l.CheckLogin(ctx, "10.0.0.9", "alice"), &limitErr)
+		require.ErrorAs(t, l.CheckLogin(ctx, "10.0.0.1", "bob"), &limitErr)
+
+		// ユーザー名のカウンタだけをリセットする
+		require.NoError(t, l.RecordSuccess(ctx, "alice"))
+		assert.NoError(t, l.CheckLogin(ctx, "10.0.0.9", "alice"))
+		require.ErrorAs(t, l.CheckLogin(ctx, "10.0.0.1", "bob"), &limitErr)
+		return
+	}
+	t.Skip("TEST_DB_CONN not set")
+}
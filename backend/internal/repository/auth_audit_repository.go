@@ -0,0 +1,27 @@
+package repository
+
+//go:generate mockgen -source=auth_audit_repository.go -destination=mock/mock_auth_audit_repository.go -package=mock
+
+import (
+	"backend/internal/model"
+	"context"
+)
+
+type AuthAuditRepository interface {
+	Create(ctx context.Context, entry *model.AuthAuditLog) error
+}
+
+type authAuditRepository struct {
+	db DBTX
+}
+
+func NewAuthAuditRepository(db DBTX) AuthAuditRepository {
+	return &authAuditRepository{db: db}
+}
+
+func (r *authAuditRepository) Create(ctx context.Context, entry *model.AuthAuditLog) error {
+	return r.db.QueryRowContext(ctx,
+		"INSERT INTO auth_audit_logs (username, ip_address, user_agent, reason) VALUES ($1, $2, $3, $4) RETURNING id, created_at",
+		entry.Username, entry.IPAddress, entry.UserAgent, entry.Reason,
+	).Scan(&entry.ID, &entry.CreatedAt)
+}
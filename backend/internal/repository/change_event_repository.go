@@ -0,0 +1,32 @@
+package repository
+
+//go:generate mockgen -source=change_event_repository.go -destination=mock/mock_change_event_repository.go -package=mock
+
+import (
+	"context"
+	"time"
+)
+
+// ChangeEventRepository はTODO・スプリントの変更イベントを change_events テーブルに記録する
+// 変更と同じトランザクションで記録し（アウトボックス）、配信の番号（seq）はコミット後に changefeed.Broker が振る
+type ChangeEventRepository interface {
+	// Record はイベントを記録する。payload は変更後の TODO・スプリントの JSON（削除では nil）
+	Record(ctx context.Context, kind, action string, entityID int, payload *string) error
+}
+
+type changeEventRepository struct {
+	db DBTX
+}
+
+func NewChangeEventRepository(db DBTX) ChangeEventRepository {
+	return &changeEventRepository{db: db}
+}
+
+func (r *changeEventRepository) Record(ctx context.Context, kind, action string, entityID int, payload *string) error {
+	// 保持期間の判定（changefeed.Broker.Prune）と揃えるため UTC で記録する
+	_, err := r.db.ExecContext(ctx,
+		"INSERT INTO change_events (kind, action, entity_id, payload, created_at) VALUES ($1, $2, $3, $4, $5)",
+		kind, action, entityID, payload, time.Now().UTC(),
+	)
+	return err
+}
@@ -0,0 +1,77 @@
+package repository_test
+
+import (
+	"testing"
+
+	"backend/internal/repository"
+	"backend/internal/repository/repositorytest"
+	"backend/internal/storage/storagetest"
+)
+
+// SQL実装の契約テスト（SQLite と、TEST_DB_CONN があれば PostgreSQL）
+
+func TestTodoRepository_Contract(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			repositorytest.TodoRepository(t, func(t *testing.T) repository.TodoRepository {
+				storagetest.Truncate(t, b.DB, "todos")
+				return repository.NewTodoRepository(b.DB)
+			})
+		})
+	}
+}
+
+func TestSprintRepository_Contract(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			repositorytest.SprintRepository(t, func(t *testing.T) repository.SprintRepository {
+				storagetest.Truncate(t, b.DB, "todos", "sprints")
+				return repository.NewSprintRepository(b.DB)
+			})
+		})
+	}
+}
+
+func TestUserRepository_Contract(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			repositorytest.UserRepository(t, func(t *testing.T) repository.UserRepository {
+				storagetest.Truncate(t, b.DB, "users")
+				return repository.NewUserRepository(b.DB)
+			})
+		})
+	}
+}
+
+func TestWorkspaceRepository_Contract(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			repositorytest.WorkspaceRepository(t, func(t *testing.T) *repository.Repositories {
+				storagetest.Truncate(t, b.DB, "workspace_members", "workspaces", "users")
+				return repository.NewRepositories(b.DB)
+			})
+		})
+	}
+}
+
+func TestUnitOfWork_Contract(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			repositorytest.UnitOfWork(t, func(t *testing.T) (repository.UnitOfWork, *repository.Repositories) {
+				storagetest.Truncate(t, b.DB, "todos", "sprints")
+				return repository.NewUnitOfWork(b.DB, repository.DefaultUnitOfWorkConfig()), repository.NewRepositories(b.DB)
+			})
+		})
+	}
+}
+
+func TestBatchLoading_Contract(t *testing.T) {
+	for _, b := range storagetest.Backends(t) {
+		t.Run(b.Name, func(t *testing.T) {
+			repositorytest.BatchLoading(t, func(t *testing.T) *repository.Repositories {
+				storagetest.Truncate(t, b.DB, "todos", "sprints")
+				return repository.NewRepositories(b.DB)
+			})
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"strings"
+)
+
+// DBTX は *sql.DB と *sql.Tx の共通インターフェース
+// リポジトリはどちらでも動作するため、UnitOfWork でトランザクションに束縛できる
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// withTx は fn をトランザクション内で実行する
+// db が既にトランザクションなら、そのトランザクションに参加する（コミットは呼び出し元が行う）
+func withTx(ctx context.Context, db DBTX, fn func(tx DBTX) error) error {
+	conn, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// inPlaceholders は IN 句の "$1, $2, ..." と引数を返す（PostgreSQL・SQLite 共通）
+func inPlaceholders(ids []int) (string, []any) {
+	placeholders := make([]string, len(ids))
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		placeholders[i] = "$" + strconv.Itoa(i+1)
+		args[i] = id
+	}
+	return strings.Join(placeholders, ", "), args
+}
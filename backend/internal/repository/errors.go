@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// リポジトリが返すドメインエラー（インメモリ実装とも共通）
+var (
+	ErrTodoNotFound   = apperror.NotFound(apperror.CodeTodoNotFound, "Todo not found")
+	ErrSprintNotFound = apperror.NotFound(apperror.CodeSprintNotFound, "Sprint not found")
+	ErrUserNotFound   = apperror.NotFound(apperror.CodeUserNotFound, "User not found")
+	ErrUsernameTaken  = apperror.Conflict(apperror.CodeUsernameTaken, "Username already exists")
+	ErrEmailTaken     = apperror.Conflict(apperror.CodeEmailTaken, "Email already exists")
+	// ErrMemberExists は既にワークスペースのメンバーであるユーザーを追加したとき（ロールの変更は UpdateMemberRole）
+	ErrMemberExists   = apperror.Conflict(apperror.CodeMemberExists, "User is already a member of the workspace")
+	ErrMemberNotFound = apperror.NotFound(apperror.CodeMemberNotFound, "Workspace member not found")
+	// ErrOwnerRoleImmutable はオーナーのロールを変更しようとしたとき
+	ErrOwnerRoleImmutable = apperror.Forbidden(apperror.CodeWorkspaceOwnerRole, "The workspace owner's role cannot be changed")
+	ErrUnknownSprint      = apperror.Validation(model.FieldError{Field: "sprint_id", Code: "not_found", Message: "Sprint not found"})
+	// ErrVersionMismatch は指定したバージョン（If-Match）が現在の行のバージョンと異なるとき
+	ErrVersionMismatch = apperror.PreconditionFailed(apperror.CodeVersionMismatch, "Resource has been modified")
+)
+
+// AnyVersion は Update / Delete でバージョンを確認しないときに指定する
+const AnyVersion = 0
+
+// PostgreSQL のエラーコード
+const (
+	pqUniqueViolation     = "23505"
+	pqForeignKeyViolation = "23503"
+)
+
+// uniqueViolation は一意制約違反なら、違反した制約の説明（制約名やカラム名を含む）を返す
+func uniqueViolation(err error) (string, bool) {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Constraint, pqErr.Code == pqUniqueViolation
+	}
+	// go-sqlite3 のエラー型は cgo 必須のため、SQLite はメッセージで判定する
+	if msg := err.Error(); strings.Contains(msg, "UNIQUE constraint failed") {
+		return msg, true
+	}
+	return "", false
+}
+
+// foreignKeyViolation は外部キー制約違反かどうか
+func foreignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return pqErr.Code == pqForeignKeyViolation
+	}
+	return strings.Contains(err.Error(), "FOREIGN KEY constraint failed")
+}
+
+// notFoundOrMismatch はバージョン付きの更新・削除が0件だったときの原因を判定する
+// 行が存在すればバージョンの不一致（ErrVersionMismatch）、存在しなければ notFound を返す
+func notFoundOrMismatch(ctx context.Context, db DBTX, table string, id, version int, notFound error) error {
+	if version == AnyVersion {
+		return notFound
+	}
+	var exists bool
+	err := db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1 AND is_deleted = false)",
+		id,
+	).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return ErrVersionMismatch
+	}
+	return notFound
+}
@@ -0,0 +1,23 @@
+package memory
+
+import (
+	"context"
+
+	"backend/internal/model"
+)
+
+type authAuditRepository struct {
+	s *store
+}
+
+func (r *authAuditRepository) Create(ctx context.Context, entry *model.AuthAuditLog) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	entry.ID = r.s.newID("auth_audit_logs")
+	entry.CreatedAt = now()
+	r.s.authAuditLogs = append(r.s.authAuditLogs, *entry)
+	return nil
+}
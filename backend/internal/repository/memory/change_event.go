@@ -0,0 +1,10 @@
+package memory
+
+import "context"
+
+// changeEventRepository は何も記録しない（インメモリでは changefeed.Hub に直接配信する）
+type changeEventRepository struct{}
+
+func (r *changeEventRepository) Record(ctx context.Context, kind, action string, entityID int, payload *string) error {
+	return nil
+}
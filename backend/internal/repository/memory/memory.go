@@ -0,0 +1,122 @@
+// Package memory はデータベースを使わないインメモリのリポジトリ実装
+//
+// テストやデモ用（プロセスを終了するとデータは失われる）。
+// SQL実装と同じ振る舞いであることを repositorytest の契約テストで検証している。
+package memory
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/types"
+)
+
+// store は全てのリポジトリで共有するデータ（SQL実装のテーブルに相当）
+type store struct {
+	mu sync.Mutex
+
+	todos         map[int]*todoRow
+	sprints       map[int]*sprintRow
+	users         map[int]*model.User
+	mfa           map[int]*model.UserMFA
+	recoveryCodes []recoveryCode
+	workspaces    map[int]*model.Workspace
+	members       map[memberKey]string
+	authAuditLogs []model.AuthAuditLog
+
+	// 採番（SERIAL に相当）
+	nextID map[string]int
+}
+
+type todoRow struct {
+	model.Todo
+	deleted bool
+}
+
+type sprintRow struct {
+	model.Sprint
+	deleted bool
+}
+
+type recoveryCode struct {
+	userID   int
+	codeHash string
+	used     bool
+}
+
+type memberKey struct {
+	workspaceID int
+	userID      int
+}
+
+func newStore() *store {
+	return &store{
+		todos:      map[int]*todoRow{},
+		sprints:    map[int]*sprintRow{},
+		users:      map[int]*model.User{},
+		mfa:        map[int]*model.UserMFA{},
+		workspaces: map[int]*model.Workspace{},
+		members:    map[memberKey]string{},
+		nextID:     map[string]int{},
+	}
+}
+
+// NewRepositories はデータを共有する全てのリポジトリを返す（デモモード用）
+func NewRepositories() *repository.Repositories {
+	s := newStore()
+	return &repository.Repositories{
+		Todos:      &todoRepository{s: s},
+		Sprints:    &sprintRepository{s: s},
+		Users:      &userRepository{s: s},
+		AuthAudit:  &authAuditRepository{s: s},
+		MFA:        &mfaRepository{s: s},
+		Workspaces: &workspaceRepository{s: s},
+		Stats:      &statsRepository{s: s},
+
+		ChangeEvents: &changeEventRepository{},
+	}
+}
+
+// lock は ctx が有効であればロックを取得する（DBと同様にキャンセル済みのリクエストは処理しない）
+func (s *store) lock(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	return nil
+}
+
+func (s *store) newID(table string) int {
+	s.nextID[table]++
+	return s.nextID[table]
+}
+
+// now は現在時刻を返す（PostgreSQL の TIMESTAMP と同じくマイクロ秒精度）
+func now() types.CustomTime {
+	return types.CustomTime(time.Now().UTC().Truncate(time.Microsecond))
+}
+
+// containsFold は ILIKE '%substr%' に相当する（大文字小文字を区別しない部分一致）
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+func copyIntPtr(p *int) *int {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// checkVersion はSQL実装と同じく、version が AnyVersion 以外で現在のバージョンと異なれば ErrVersionMismatch を返す
+func checkVersion(current, version int) error {
+	if version != repository.AnyVersion && current != version {
+		return repository.ErrVersionMismatch
+	}
+	return nil
+}
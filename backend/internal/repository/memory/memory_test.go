@@ -0,0 +1,75 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/repository/repositorytest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoRepository_Contract(t *testing.T) {
+	repositorytest.TodoRepository(t, func(*testing.T) repository.TodoRepository { return NewTodoRepository() })
+}
+
+func TestSprintRepository_Contract(t *testing.T) {
+	repositorytest.SprintRepository(t, func(*testing.T) repository.SprintRepository { return NewSprintRepository() })
+}
+
+func TestUserRepository_Contract(t *testing.T) {
+	repositorytest.UserRepository(t, func(*testing.T) repository.UserRepository { return NewUserRepository() })
+}
+
+func TestWorkspaceRepository_Contract(t *testing.T) {
+	repositorytest.WorkspaceRepository(t, func(*testing.T) *repository.Repositories { return NewRepositories() })
+}
+
+func TestUnitOfWork_Contract(t *testing.T) {
+	repositorytest.UnitOfWork(t, func(*testing.T) (repository.UnitOfWork, *repository.Repositories) {
+		repos := NewRepositories()
+		return NewUnitOfWork(repos), repos
+	})
+}
+
+func TestBatchLoading_Contract(t *testing.T) {
+	repositorytest.BatchLoading(t, func(*testing.T) *repository.Repositories { return NewRepositories() })
+}
+
+// NewRepositories のリポジトリはデータを共有する
+func TestNewRepositories_SharedStore(t *testing.T) {
+	ctx := context.Background()
+	repos := NewRepositories()
+
+	user, err := repos.Users.Create(ctx, "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+	_, err = repos.Todos.Create(ctx, "Todo", "", nil)
+	require.NoError(t, err)
+	require.NoError(t, repos.MFA.SaveSecret(ctx, user.ID, "secret"))
+	require.NoError(t, repos.MFA.Enable(ctx, user.ID))
+	workspace, err := repos.Workspaces.Create(ctx, "Team", user.ID)
+	require.NoError(t, err)
+	require.NoError(t, repos.Workspaces.SetMFARequired(ctx, workspace.ID, true))
+	require.NoError(t, repos.AuthAudit.Create(ctx, &model.AuthAuditLog{Username: "bob", Reason: model.AuthFailureUnknownUser}))
+
+	required, err := repos.Workspaces.IsMFARequiredForUser(ctx, user.ID)
+	require.NoError(t, err)
+	assert.True(t, required)
+
+	stats, err := repos.Stats.GetSystemStats(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, model.SystemStats{
+		Users: 1, ActiveUsers: 1, MFAEnabled: 1, Todos: 1, Workspaces: 1, FailedLogins24h: 1,
+	}, *stats)
+}
+
+func TestCanceledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := NewTodoRepository().FindAll(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
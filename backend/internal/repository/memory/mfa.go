@@ -0,0 +1,137 @@
+package memory
+
+import (
+	"context"
+
+	"backend/internal/model"
+)
+
+type mfaRepository struct {
+	s *store
+}
+
+func (r *mfaRepository) FindByUserID(ctx context.Context, userID int) (*model.UserMFA, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	m, ok := r.s.mfa[userID]
+	if !ok {
+		return nil, nil
+	}
+	c := *m
+	if m.LastUsedStep != nil {
+		step := *m.LastUsedStep
+		c.LastUsedStep = &step
+	}
+	if m.ConfirmedAt != nil {
+		confirmedAt := *m.ConfirmedAt
+		c.ConfirmedAt = &confirmedAt
+	}
+	return &c, nil
+}
+
+// SaveSecret は未確認のシークレットを保存する（有効化済みの設定は上書きしない）
+func (r *mfaRepository) SaveSecret(ctx context.Context, userID int, secret string) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	if m, ok := r.s.mfa[userID]; ok {
+		if !m.Enabled {
+			m.Secret = secret
+			m.LastUsedStep = nil
+			m.UpdatedAt = now()
+		}
+		return nil
+	}
+
+	createdAt := now()
+	r.s.mfa[userID] = &model.UserMFA{UserID: userID, Secret: secret, CreatedAt: createdAt, UpdatedAt: createdAt}
+	return nil
+}
+
+func (r *mfaRepository) Enable(ctx context.Context, userID int) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	if m, ok := r.s.mfa[userID]; ok {
+		confirmedAt := now()
+		m.Enabled = true
+		m.ConfirmedAt = &confirmedAt
+		m.UpdatedAt = confirmedAt
+	}
+	return nil
+}
+
+// MarkStepUsed は使用済みのタイムステップを記録する
+// 同じかそれ以前のステップが既に使われていれば false を返す（コードの再利用防止）
+func (r *mfaRepository) MarkStepUsed(ctx context.Context, userID int, step int64) (bool, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return false, err
+	}
+	defer r.s.mu.Unlock()
+
+	m, ok := r.s.mfa[userID]
+	if !ok || (m.LastUsedStep != nil && *m.LastUsedStep >= step) {
+		return false, nil
+	}
+	m.LastUsedStep = &step
+	m.UpdatedAt = now()
+	return true, nil
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, userID int) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	r.s.deleteRecoveryCodes(userID)
+	delete(r.s.mfa, userID)
+	return nil
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	r.s.deleteRecoveryCodes(userID)
+	for _, hash := range codeHashes {
+		r.s.recoveryCodes = append(r.s.recoveryCodes, recoveryCode{userID: userID, codeHash: hash})
+	}
+	return nil
+}
+
+// UseRecoveryCode は未使用のリカバリーコードを使用済みにする。見つからなければ false
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return false, err
+	}
+	defer r.s.mu.Unlock()
+
+	for i := range r.s.recoveryCodes {
+		c := &r.s.recoveryCodes[i]
+		if c.userID == userID && c.codeHash == codeHash && !c.used {
+			c.used = true
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *store) deleteRecoveryCodes(userID int) {
+	kept := s.recoveryCodes[:0]
+	for _, c := range s.recoveryCodes {
+		if c.userID != userID {
+			kept = append(kept, c)
+		}
+	}
+	s.recoveryCodes = kept
+}
@@ -0,0 +1,147 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"backend/internal/model"
+	"backend/internal/repository"
+)
+
+type sprintRepository struct {
+	s *store
+}
+
+// NewSprintRepository は空のインメモリ SprintRepository を返す
+func NewSprintRepository() repository.SprintRepository {
+	return &sprintRepository{s: newStore()}
+}
+
+func (r *sprintRepository) FindAll(ctx context.Context) ([]model.Sprint, error) {
+	return r.Search(ctx, &model.SprintSearchRequest{})
+}
+
+// Search はSQL実装と同じく、お気に入り・作成日時の新しい順に返す
+func (r *sprintRepository) Search(ctx context.Context, req *model.SprintSearchRequest) ([]model.Sprint, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	sprints := []model.Sprint{}
+	for _, row := range r.s.sprints {
+		if row.deleted ||
+			(req.Name != nil && !containsFold(row.Name, *req.Name)) ||
+			(req.IsFavorite != nil && row.IsFavorite != *req.IsFavorite) {
+			continue
+		}
+		sprints = append(sprints, row.Sprint)
+	}
+	sort.Slice(sprints, func(i, j int) bool {
+		a, b := sprints[i], sprints[j]
+		if a.IsFavorite != b.IsFavorite {
+			return a.IsFavorite
+		}
+		if !time.Time(a.CreatedAt).Equal(time.Time(b.CreatedAt)) {
+			return time.Time(a.CreatedAt).After(time.Time(b.CreatedAt))
+		}
+		return a.ID > b.ID
+	})
+
+	return sprints, nil
+}
+
+// FindByIDs はSQL実装と同じく ID 順に返す
+func (r *sprintRepository) FindByIDs(ctx context.Context, ids []int) ([]model.Sprint, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	sprints := []model.Sprint{}
+	seen := map[int]bool{}
+	for _, id := range ids {
+		if row, ok := r.s.sprints[id]; ok && !row.deleted && !seen[id] {
+			seen[id] = true
+			sprints = append(sprints, row.Sprint)
+		}
+	}
+	sort.Slice(sprints, func(i, j int) bool { return sprints[i].ID < sprints[j].ID })
+
+	return sprints, nil
+}
+
+func (r *sprintRepository) Create(ctx context.Context, name, color string, isFavorite bool) (*model.Sprint, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	createdAt := now()
+	row := &sprintRow{Sprint: model.Sprint{
+		ID:         r.s.newID("sprints"),
+		Name:       name,
+		Color:      color,
+		IsFavorite: isFavorite,
+		Version:    1,
+		CreatedAt:  createdAt,
+		UpdatedAt:  createdAt,
+	}}
+	r.s.sprints[row.ID] = row
+
+	s := row.Sprint
+	return &s, nil
+}
+
+func (r *sprintRepository) Update(ctx context.Context, id int, name, color string, version int) (*model.Sprint, error) {
+	return r.update(ctx, id, version, func(row *sprintRow) {
+		row.Name = name
+		row.Color = color
+	})
+}
+
+func (r *sprintRepository) UpdateFavorite(ctx context.Context, id int, isFavorite bool, version int) (*model.Sprint, error) {
+	return r.update(ctx, id, version, func(row *sprintRow) {
+		row.IsFavorite = isFavorite
+	})
+}
+
+func (r *sprintRepository) Delete(ctx context.Context, id int, version int) error {
+	_, err := r.update(ctx, id, version, func(row *sprintRow) {
+		row.deleted = true
+	})
+	return err
+}
+
+// update は削除されていないスプリントに fn を適用し、バージョンを上げる
+func (r *sprintRepository) update(ctx context.Context, id, version int, fn func(row *sprintRow)) (*model.Sprint, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.sprints[id]
+	if !ok || row.deleted {
+		return nil, repository.ErrSprintNotFound
+	}
+	if err := checkVersion(row.Version, version); err != nil {
+		return nil, err
+	}
+	fn(row)
+	row.Version++
+	row.UpdatedAt = now()
+
+	s := row.Sprint
+	return &s, nil
+}
+
+func (r *sprintRepository) Exists(ctx context.Context, id int) (bool, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return false, err
+	}
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.sprints[id]
+	return ok && !row.deleted, nil
+}
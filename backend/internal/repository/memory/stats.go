@@ -0,0 +1,55 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"backend/internal/model"
+)
+
+type statsRepository struct {
+	s *store
+}
+
+func (r *statsRepository) GetSystemStats(ctx context.Context) (*model.SystemStats, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	stats := &model.SystemStats{Users: len(r.s.users), Workspaces: len(r.s.workspaces)}
+	for _, u := range r.s.users {
+		if u.IsActive {
+			stats.ActiveUsers++
+		}
+		if u.Role == model.RoleAdmin {
+			stats.Admins++
+		}
+	}
+	for _, m := range r.s.mfa {
+		if m.Enabled {
+			stats.MFAEnabled++
+		}
+	}
+	for _, t := range r.s.todos {
+		if !t.deleted {
+			stats.Todos++
+			if t.Completed {
+				stats.CompletedTodos++
+			}
+		}
+	}
+	for _, s := range r.s.sprints {
+		if !s.deleted {
+			stats.Sprints++
+		}
+	}
+	since := time.Now().Add(-24 * time.Hour)
+	for _, l := range r.s.authAuditLogs {
+		if time.Time(l.CreatedAt).After(since) {
+			stats.FailedLogins24h++
+		}
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,163 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"backend/internal/model"
+	"backend/internal/repository"
+)
+
+type todoRepository struct {
+	s *store
+}
+
+// NewTodoRepository は空のインメモリ TodoRepository を返す
+func NewTodoRepository() repository.TodoRepository {
+	return &todoRepository{s: newStore()}
+}
+
+func (r *todoRepository) FindAll(ctx context.Context) ([]model.Todo, error) {
+	return r.Search(ctx, &model.TodoSearchRequest{})
+}
+
+func (r *todoRepository) Search(ctx context.Context, req *model.TodoSearchRequest) ([]model.Todo, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	todos := []model.Todo{}
+	for _, row := range r.s.todos {
+		if row.deleted ||
+			(req.Title != nil && !containsFold(row.Title, *req.Title)) ||
+			(req.Description != nil && !containsFold(row.Description, *req.Description)) ||
+			(req.Completed != nil && row.Completed != *req.Completed) ||
+			(req.SprintID != nil && (row.SprintID == nil || *row.SprintID != *req.SprintID)) {
+			continue
+		}
+		t := row.Todo
+		t.SprintID = copyIntPtr(t.SprintID)
+		todos = append(todos, t)
+	}
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID < todos[j].ID })
+
+	return todos, nil
+}
+
+// FindBySprintIDs はSQL実装と同じく ID 順に返す
+func (r *todoRepository) FindBySprintIDs(ctx context.Context, sprintIDs []int) ([]model.Todo, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	wanted := map[int]bool{}
+	for _, id := range sprintIDs {
+		wanted[id] = true
+	}
+
+	todos := []model.Todo{}
+	for _, row := range r.s.todos {
+		if row.deleted || row.SprintID == nil || !wanted[*row.SprintID] {
+			continue
+		}
+		t := row.Todo
+		t.SprintID = copyIntPtr(t.SprintID)
+		todos = append(todos, t)
+	}
+	sort.Slice(todos, func(i, j int) bool { return todos[i].ID < todos[j].ID })
+
+	return todos, nil
+}
+
+// Create はTODOを作成する。SQL実装の外部キー制約と同じく、存在しないスプリントは指定できない
+func (r *todoRepository) Create(ctx context.Context, title string, description string, sprintID *int) (*model.Todo, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	if sprintID != nil {
+		if _, ok := r.s.sprints[*sprintID]; !ok {
+			return nil, repository.ErrUnknownSprint
+		}
+	}
+
+	createdAt := now()
+	row := &todoRow{Todo: model.Todo{
+		ID:          r.s.newID("todos"),
+		Title:       title,
+		Description: description,
+		SprintID:    copyIntPtr(sprintID),
+		Version:     1,
+		CreatedAt:   createdAt,
+		UpdatedAt:   createdAt,
+	}}
+	r.s.todos[row.ID] = row
+
+	t := row.Todo
+	t.SprintID = copyIntPtr(t.SprintID)
+	return &t, nil
+}
+
+func (r *todoRepository) Update(ctx context.Context, title string, completed bool, id int, version int) (*model.Todo, error) {
+	return r.update(ctx, id, version, func(row *todoRow) error {
+		row.Title = title
+		row.Completed = completed
+		return nil
+	})
+}
+
+func (r *todoRepository) SetCompleted(ctx context.Context, id int, completed bool, version int) (*model.Todo, error) {
+	return r.update(ctx, id, version, func(row *todoRow) error {
+		row.Completed = completed
+		return nil
+	})
+}
+
+// MoveToSprint はSQL実装の外部キー制約と同じく、存在しないスプリントには移動できない
+func (r *todoRepository) MoveToSprint(ctx context.Context, id int, sprintID *int, version int) (*model.Todo, error) {
+	return r.update(ctx, id, version, func(row *todoRow) error {
+		if sprintID != nil {
+			if _, ok := r.s.sprints[*sprintID]; !ok {
+				return repository.ErrUnknownSprint
+			}
+		}
+		row.SprintID = copyIntPtr(sprintID)
+		return nil
+	})
+}
+
+func (r *todoRepository) Delete(ctx context.Context, id int, version int) error {
+	_, err := r.update(ctx, id, version, func(row *todoRow) error {
+		row.deleted = true
+		return nil
+	})
+	return err
+}
+
+// update は削除されていないTODOに fn を適用し、バージョンを上げる（fn がエラーを返せば変更しない）
+func (r *todoRepository) update(ctx context.Context, id, version int, fn func(row *todoRow) error) (*model.Todo, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	row, ok := r.s.todos[id]
+	if !ok || row.deleted {
+		return nil, repository.ErrTodoNotFound
+	}
+	if err := checkVersion(row.Version, version); err != nil {
+		return nil, err
+	}
+	if err := fn(row); err != nil {
+		return nil, err
+	}
+	row.Version++
+	row.UpdatedAt = now()
+
+	t := row.Todo
+	t.SprintID = copyIntPtr(t.SprintID)
+	return &t, nil
+}
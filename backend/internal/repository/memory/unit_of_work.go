@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"backend/internal/repository"
+)
+
+// unitOfWork は NewRepositories のリポジトリで fn を実行し、エラーならTODOとスプリントを元に戻す
+//
+// Do どうしは直列に実行されるが、Do の外からの同時の変更とは分離されない（デモ用）。
+type unitOfWork struct {
+	mu    sync.Mutex
+	s     *store
+	repos *repository.Repositories
+}
+
+// NewUnitOfWork は NewRepositories が返したリポジトリの UnitOfWork を返す
+func NewUnitOfWork(repos *repository.Repositories) repository.UnitOfWork {
+	todos, ok := repos.Todos.(*todoRepository)
+	if !ok {
+		panic("memory: NewUnitOfWork requires repositories created by NewRepositories")
+	}
+	return &unitOfWork{s: todos.s, repos: repos}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *repository.Repositories) error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	snap, err := u.snapshot(ctx)
+	if err != nil {
+		return err
+	}
+
+	committed := false
+	defer func() {
+		if !committed {
+			u.s.mu.Lock()
+			snap.restore(u.s)
+			u.s.mu.Unlock()
+		}
+	}()
+
+	if err := fn(u.repos); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// snapshot はロールバック用にTODOとスプリントの状態を複製する
+type snapshot struct {
+	todos   map[int]*todoRow
+	sprints map[int]*sprintRow
+}
+
+func (u *unitOfWork) snapshot(ctx context.Context) (*snapshot, error) {
+	if err := u.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer u.s.mu.Unlock()
+
+	snap := &snapshot{
+		todos:   make(map[int]*todoRow, len(u.s.todos)),
+		sprints: make(map[int]*sprintRow, len(u.s.sprints)),
+	}
+	for id, row := range u.s.todos {
+		copied := *row
+		copied.SprintID = copyIntPtr(row.SprintID)
+		snap.todos[id] = &copied
+	}
+	for id, row := range u.s.sprints {
+		copied := *row
+		snap.sprints[id] = &copied
+	}
+	return snap, nil
+}
+
+// restore はTODOとスプリントを snapshot の状態に戻す（ロック取得済みで呼ぶこと）
+// 採番は戻さない（SQL実装の SERIAL もロールバックで戻らない）
+func (snap *snapshot) restore(s *store) {
+	s.todos = snap.todos
+	s.sprints = snap.sprints
+}
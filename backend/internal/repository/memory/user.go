@@ -0,0 +1,146 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"backend/internal/model"
+	"backend/internal/repository"
+)
+
+type userRepository struct {
+	s *store
+}
+
+// NewUserRepository は空のインメモリ UserRepository を返す
+func NewUserRepository() repository.UserRepository {
+	return &userRepository{s: newStore()}
+}
+
+// findActive は条件に一致する有効なユーザーを返す。見つからなければ nil, nil
+func (r *userRepository) findActive(ctx context.Context, match func(u *model.User) bool) (*model.User, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	for _, u := range r.s.users {
+		if u.IsActive && match(u) {
+			return copyUser(u), nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	return r.findActive(ctx, func(u *model.User) bool { return u.Username == username })
+}
+
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	return r.findActive(ctx, func(u *model.User) bool { return u.Email == email })
+}
+
+func (r *userRepository) FindByID(ctx context.Context, id int) (*model.User, error) {
+	return r.findActive(ctx, func(u *model.User) bool { return u.ID == id })
+}
+
+// Create はユーザーを作成する。ユーザー名・メールアドレスはSQL実装と同じく一意
+func (r *userRepository) Create(ctx context.Context, username, email, passwordHash string) (*model.User, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	for _, u := range r.s.users {
+		if u.Username == username {
+			return nil, repository.ErrUsernameTaken
+		}
+		if u.Email == email {
+			return nil, repository.ErrEmailTaken
+		}
+	}
+
+	createdAt := now()
+	u := &model.User{
+		ID:           r.s.newID("users"),
+		Username:     username,
+		Email:        email,
+		PasswordHash: passwordHash,
+		Provider:     "local",
+		Role:         model.RoleUser,
+		IsActive:     true,
+		CreatedAt:    createdAt,
+		UpdatedAt:    createdAt,
+	}
+	r.s.users[u.ID] = u
+
+	return copyUser(u), nil
+}
+
+// Search は管理者向けのユーザー検索（無効化されたユーザーも含む）
+func (r *userRepository) Search(ctx context.Context, req *model.UserSearchRequest) ([]model.User, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	users := []model.User{}
+	for _, u := range r.s.users {
+		if (req.Query != nil && !containsFold(u.Username, *req.Query) && !containsFold(u.Email, *req.Query)) ||
+			(req.IsActive != nil && u.IsActive != *req.IsActive) ||
+			(req.Role != nil && u.Role != *req.Role) {
+			continue
+		}
+		users = append(users, *copyUser(u))
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+
+	// LIMIT / OFFSET
+	start := min(max(req.Offset, 0), len(users))
+	end := min(start+max(req.Limit, 0), len(users))
+	return users[start:end], nil
+}
+
+// SetActive はユーザーを有効化・無効化する。無効化時は発行済みトークンも失効させる
+func (r *userRepository) SetActive(ctx context.Context, id int, active bool) (int, error) {
+	return r.update(ctx, id, func(u *model.User) {
+		u.IsActive = active
+		if !active {
+			u.TokenVersion++
+		}
+	})
+}
+
+func (r *userRepository) SetRole(ctx context.Context, id int, role string) (int, error) {
+	return r.update(ctx, id, func(u *model.User) { u.Role = role })
+}
+
+// RevokeTokens はトークンバージョンを上げて、発行済みのトークンをすべて失効させる
+func (r *userRepository) RevokeTokens(ctx context.Context, id int) (int, error) {
+	return r.update(ctx, id, func(u *model.User) { u.TokenVersion++ })
+}
+
+// update は無効化されたユーザーも含めて更新し、更新件数を返す。見つからなければ ErrUserNotFound
+func (r *userRepository) update(ctx context.Context, id int, fn func(u *model.User)) (int, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return 0, err
+	}
+	defer r.s.mu.Unlock()
+
+	u, ok := r.s.users[id]
+	if !ok {
+		return 0, repository.ErrUserNotFound
+	}
+	fn(u)
+	u.UpdatedAt = now()
+	return 1, nil
+}
+
+func copyUser(u *model.User) *model.User {
+	c := *u
+	if u.ExternalID != nil {
+		id := *u.ExternalID
+		c.ExternalID = &id
+	}
+	return &c
+}
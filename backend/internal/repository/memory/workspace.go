@@ -0,0 +1,126 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"backend/internal/model"
+	"backend/internal/repository"
+)
+
+type workspaceRepository struct {
+	s *store
+}
+
+func (r *workspaceRepository) FindByUserID(ctx context.Context, userID int) ([]model.Workspace, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	workspaces := []model.Workspace{}
+	for key, role := range r.s.members {
+		if key.userID != userID {
+			continue
+		}
+		w := *r.s.workspaces[key.workspaceID]
+		w.Role = role
+		workspaces = append(workspaces, w)
+	}
+	sort.Slice(workspaces, func(i, j int) bool {
+		a, b := workspaces[i], workspaces[j]
+		if !time.Time(a.CreatedAt).Equal(time.Time(b.CreatedAt)) {
+			return time.Time(a.CreatedAt).Before(time.Time(b.CreatedAt))
+		}
+		return a.ID < b.ID
+	})
+
+	return workspaces, nil
+}
+
+// Create はワークスペースを作成し、作成者をオーナーとして登録する
+func (r *workspaceRepository) Create(ctx context.Context, name string, ownerID int) (*model.Workspace, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return nil, err
+	}
+	defer r.s.mu.Unlock()
+
+	createdAt := now()
+	w := &model.Workspace{ID: r.s.newID("workspaces"), Name: name, CreatedAt: createdAt, UpdatedAt: createdAt}
+	r.s.workspaces[w.ID] = w
+	r.s.members[memberKey{w.ID, ownerID}] = model.WorkspaceRoleOwner
+
+	result := *w
+	result.Role = model.WorkspaceRoleOwner
+	return &result, nil
+}
+
+// GetMemberRole はユーザーのロールを返す。メンバーでなければ空文字
+func (r *workspaceRepository) GetMemberRole(ctx context.Context, workspaceID, userID int) (string, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return "", err
+	}
+	defer r.s.mu.Unlock()
+
+	return r.s.members[memberKey{workspaceID, userID}], nil
+}
+
+func (r *workspaceRepository) AddMember(ctx context.Context, workspaceID, userID int, role string) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	key := memberKey{workspaceID, userID}
+	if _, ok := r.s.members[key]; ok {
+		return repository.ErrMemberExists
+	}
+	r.s.members[key] = role
+	return nil
+}
+
+func (r *workspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID int, role string) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	key := memberKey{workspaceID, userID}
+	switch r.s.members[key] {
+	case "":
+		return repository.ErrMemberNotFound
+	case model.WorkspaceRoleOwner:
+		return repository.ErrOwnerRoleImmutable
+	}
+	r.s.members[key] = role
+	return nil
+}
+
+func (r *workspaceRepository) SetMFARequired(ctx context.Context, workspaceID int, required bool) error {
+	if err := r.s.lock(ctx); err != nil {
+		return err
+	}
+	defer r.s.mu.Unlock()
+
+	if w, ok := r.s.workspaces[workspaceID]; ok {
+		w.MFARequired = required
+		w.UpdatedAt = now()
+	}
+	return nil
+}
+
+// IsMFARequiredForUser はユーザーがMFA必須のワークスペースに所属しているかを返す
+func (r *workspaceRepository) IsMFARequiredForUser(ctx context.Context, userID int) (bool, error) {
+	if err := r.s.lock(ctx); err != nil {
+		return false, err
+	}
+	defer r.s.mu.Unlock()
+
+	for key := range r.s.members {
+		if key.userID == userID && r.s.workspaces[key.workspaceID].MFARequired {
+			return true, nil
+		}
+	}
+	return false, nil
+}
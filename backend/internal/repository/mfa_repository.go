@@ -0,0 +1,136 @@
+package repository
+
+//go:generate mockgen -source=mfa_repository.go -destination=mock/mock_mfa_repository.go -package=mock
+
+import (
+	"backend/internal/model"
+	"context"
+	"database/sql"
+)
+
+type MFARepository interface {
+	FindByUserID(ctx context.Context, userID int) (*model.UserMFA, error)
+	SaveSecret(ctx context.Context, userID int, secret string) error
+	Enable(ctx context.Context, userID int) error
+	MarkStepUsed(ctx context.Context, userID int, step int64) (bool, error)
+	Delete(ctx context.Context, userID int) error
+	ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error
+	UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error)
+}
+
+type mfaRepository struct {
+	db DBTX
+}
+
+func NewMFARepository(db DBTX) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+func (r *mfaRepository) FindByUserID(ctx context.Context, userID int) (*model.UserMFA, error) {
+	mfa := &model.UserMFA{}
+	err := r.db.QueryRowContext(ctx, `
+		SELECT user_id, secret, enabled, last_used_step, confirmed_at, created_at, updated_at
+		FROM user_mfa
+		WHERE user_id = $1
+	`, userID).Scan(
+		&mfa.UserID,
+		&mfa.Secret,
+		&mfa.Enabled,
+		&mfa.LastUsedStep,
+		&mfa.ConfirmedAt,
+		&mfa.CreatedAt,
+		&mfa.UpdatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return mfa, nil
+}
+
+// SaveSecret は未確認のシークレットを保存する（有効化済みの設定は上書きしない）
+func (r *mfaRepository) SaveSecret(ctx context.Context, userID int, secret string) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO user_mfa (user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE SET secret = $2, last_used_step = NULL, updated_at = NOW()
+		WHERE user_mfa.enabled = false
+	`, userID, secret)
+	return err
+}
+
+func (r *mfaRepository) Enable(ctx context.Context, userID int) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE user_mfa SET enabled = true, confirmed_at = NOW(), updated_at = NOW() WHERE user_id = $1",
+		userID,
+	)
+	return err
+}
+
+// MarkStepUsed は使用済みのタイムステップを記録する
+// 同じかそれ以前のステップが既に使われていれば false を返す（コードの再利用防止）
+func (r *mfaRepository) MarkStepUsed(ctx context.Context, userID int, step int64) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE user_mfa SET last_used_step = $2, updated_at = NOW()
+		WHERE user_id = $1 AND (last_used_step IS NULL OR last_used_step < $2)
+	`, userID, step)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, userID int) error {
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		_, err := tx.ExecContext(ctx, "DELETE FROM user_mfa WHERE user_id = $1", userID)
+		return err
+	})
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID int, codeHashes []string) error {
+	return withTx(ctx, r.db, func(tx DBTX) error {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM user_recovery_codes WHERE user_id = $1", userID); err != nil {
+			return err
+		}
+		for _, hash := range codeHashes {
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO user_recovery_codes (user_id, code_hash) VALUES ($1, $2)",
+				userID, hash,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// UseRecoveryCode は未使用のリカバリーコードを使用済みにする。見つからなければ false
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userID int, codeHash string) (bool, error) {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE user_recovery_codes SET used_at = NOW() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL",
+		userID, codeHash,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rowsAffected == 1, nil
+}
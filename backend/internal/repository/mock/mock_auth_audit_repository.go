@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: auth_audit_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=auth_audit_repository.go -destination=mock/mock_auth_audit_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "backend/internal/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthAuditRepository is a mock of AuthAuditRepository interface.
+type MockAuthAuditRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthAuditRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockAuthAuditRepositoryMockRecorder is the mock recorder for MockAuthAuditRepository.
+type MockAuthAuditRepositoryMockRecorder struct {
+	mock *MockAuthAuditRepository
+}
+
+// NewMockAuthAuditRepository creates a new mock instance.
+func NewMockAuthAuditRepository(ctrl *gomock.Controller) *MockAuthAuditRepository {
+	mock := &MockAuthAuditRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthAuditRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthAuditRepository) EXPECT() *MockAuthAuditRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAuthAuditRepository) Create(ctx context.Context, entry *model.AuthAuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuthAuditRepositoryMockRecorder) Create(ctx, entry any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAuthAuditRepository)(nil).Create), ctx, entry)
+}
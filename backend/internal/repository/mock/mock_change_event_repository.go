@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: change_event_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=change_event_repository.go -destination=mock/mock_change_event_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockChangeEventRepository is a mock of ChangeEventRepository interface.
+type MockChangeEventRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockChangeEventRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockChangeEventRepositoryMockRecorder is the mock recorder for MockChangeEventRepository.
+type MockChangeEventRepositoryMockRecorder struct {
+	mock *MockChangeEventRepository
+}
+
+// NewMockChangeEventRepository creates a new mock instance.
+func NewMockChangeEventRepository(ctrl *gomock.Controller) *MockChangeEventRepository {
+	mock := &MockChangeEventRepository{ctrl: ctrl}
+	mock.recorder = &MockChangeEventRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChangeEventRepository) EXPECT() *MockChangeEventRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Record mocks base method.
+func (m *MockChangeEventRepository) Record(ctx context.Context, kind, action string, entityID int, payload *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, kind, action, entityID, payload)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockChangeEventRepositoryMockRecorder) Record(ctx, kind, action, entityID, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockChangeEventRepository)(nil).Record), ctx, kind, action, entityID, payload)
+}
@@ -11,6 +11,7 @@ package mock
 
 import (
 	model "backend/internal/model"
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,76 +42,120 @@ func (m *MockSprintRepository) EXPECT() *MockSprintRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockSprintRepository) Create(title string) (*model.Sprint, error) {
+func (m *MockSprintRepository) Create(ctx context.Context, name, color string, isFavorite bool) (*model.Sprint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", title)
+	ret := m.ctrl.Call(m, "Create", ctx, name, color, isFavorite)
 	ret0, _ := ret[0].(*model.Sprint)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockSprintRepositoryMockRecorder) Create(title any) *gomock.Call {
+func (mr *MockSprintRepositoryMockRecorder) Create(ctx, name, color, isFavorite any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSprintRepository)(nil).Create), title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSprintRepository)(nil).Create), ctx, name, color, isFavorite)
 }
 
 // Delete mocks base method.
-func (m *MockSprintRepository) Delete(id int) error {
+func (m *MockSprintRepository) Delete(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockSprintRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockSprintRepositoryMockRecorder) Delete(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSprintRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSprintRepository)(nil).Delete), ctx, id, version)
+}
+
+// Exists mocks base method.
+func (m *MockSprintRepository) Exists(ctx context.Context, id int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Exists", ctx, id)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Exists indicates an expected call of Exists.
+func (mr *MockSprintRepositoryMockRecorder) Exists(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Exists", reflect.TypeOf((*MockSprintRepository)(nil).Exists), ctx, id)
 }
 
 // FindAll mocks base method.
-func (m *MockSprintRepository) FindAll() ([]model.Sprint, error) {
+func (m *MockSprintRepository) FindAll(ctx context.Context) ([]model.Sprint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindAll", ctx)
 	ret0, _ := ret[0].([]model.Sprint)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockSprintRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockSprintRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSprintRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockSprintRepository)(nil).FindAll), ctx)
+}
+
+// FindByIDs mocks base method.
+func (m *MockSprintRepository) FindByIDs(ctx context.Context, ids []int) ([]model.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIDs", ctx, ids)
+	ret0, _ := ret[0].([]model.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIDs indicates an expected call of FindByIDs.
+func (mr *MockSprintRepositoryMockRecorder) FindByIDs(ctx, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIDs", reflect.TypeOf((*MockSprintRepository)(nil).FindByIDs), ctx, ids)
 }
 
 // Search mocks base method.
-func (m *MockSprintRepository) Search(req *model.SprintSearchRequest) ([]model.Sprint, error) {
+func (m *MockSprintRepository) Search(ctx context.Context, req *model.SprintSearchRequest) ([]model.Sprint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", req)
+	ret := m.ctrl.Call(m, "Search", ctx, req)
 	ret0, _ := ret[0].([]model.Sprint)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockSprintRepositoryMockRecorder) Search(req any) *gomock.Call {
+func (mr *MockSprintRepositoryMockRecorder) Search(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSprintRepository)(nil).Search), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSprintRepository)(nil).Search), ctx, req)
 }
 
 // Update mocks base method.
-func (m *MockSprintRepository) Update(id int, title string, completed bool) (int, string, error) {
+func (m *MockSprintRepository) Update(ctx context.Context, id int, name, color string, version int) (*model.Sprint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", id, title, completed)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "Update", ctx, id, name, color, version)
+	ret0, _ := ret[0].(*model.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockSprintRepositoryMockRecorder) Update(id, title, completed any) *gomock.Call {
+func (mr *MockSprintRepositoryMockRecorder) Update(ctx, id, name, color, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSprintRepository)(nil).Update), ctx, id, name, color, version)
+}
+
+// UpdateFavorite mocks base method.
+func (m *MockSprintRepository) UpdateFavorite(ctx context.Context, id int, isFavorite bool, version int) (*model.Sprint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFavorite", ctx, id, isFavorite, version)
+	ret0, _ := ret[0].(*model.Sprint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFavorite indicates an expected call of UpdateFavorite.
+func (mr *MockSprintRepositoryMockRecorder) UpdateFavorite(ctx, id, isFavorite, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSprintRepository)(nil).Update), id, title, completed)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorite", reflect.TypeOf((*MockSprintRepository)(nil).UpdateFavorite), ctx, id, isFavorite, version)
 }
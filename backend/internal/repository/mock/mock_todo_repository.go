@@ -11,6 +11,7 @@ package mock
 
 import (
 	model "backend/internal/model"
+	context "context"
 	reflect "reflect"
 
 	gomock "go.uber.org/mock/gomock"
@@ -41,76 +42,120 @@ func (m *MockTodoRepository) EXPECT() *MockTodoRepositoryMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockTodoRepository) Create(title, description string, sprintID *int) (*model.Todo, error) {
+func (m *MockTodoRepository) Create(ctx context.Context, title, description string, sprintID *int) (*model.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", title, description, sprintID)
+	ret := m.ctrl.Call(m, "Create", ctx, title, description, sprintID)
 	ret0, _ := ret[0].(*model.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTodoRepositoryMockRecorder) Create(title, description, sprintID any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Create(ctx, title, description, sprintID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoRepository)(nil).Create), title, description, sprintID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoRepository)(nil).Create), ctx, title, description, sprintID)
 }
 
 // Delete mocks base method.
-func (m *MockTodoRepository) Delete(id int) error {
+func (m *MockTodoRepository) Delete(ctx context.Context, id, version int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", id)
+	ret := m.ctrl.Call(m, "Delete", ctx, id, version)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoRepositoryMockRecorder) Delete(id any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Delete(ctx, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoRepository)(nil).Delete), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoRepository)(nil).Delete), ctx, id, version)
 }
 
 // FindAll mocks base method.
-func (m *MockTodoRepository) FindAll() ([]model.Todo, error) {
+func (m *MockTodoRepository) FindAll(ctx context.Context) ([]model.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "FindAll")
+	ret := m.ctrl.Call(m, "FindAll", ctx)
 	ret0, _ := ret[0].([]model.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // FindAll indicates an expected call of FindAll.
-func (mr *MockTodoRepositoryMockRecorder) FindAll() *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) FindAll(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTodoRepository)(nil).FindAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockTodoRepository)(nil).FindAll), ctx)
+}
+
+// FindBySprintIDs mocks base method.
+func (m *MockTodoRepository) FindBySprintIDs(ctx context.Context, sprintIDs []int) ([]model.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindBySprintIDs", ctx, sprintIDs)
+	ret0, _ := ret[0].([]model.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindBySprintIDs indicates an expected call of FindBySprintIDs.
+func (mr *MockTodoRepositoryMockRecorder) FindBySprintIDs(ctx, sprintIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindBySprintIDs", reflect.TypeOf((*MockTodoRepository)(nil).FindBySprintIDs), ctx, sprintIDs)
+}
+
+// MoveToSprint mocks base method.
+func (m *MockTodoRepository) MoveToSprint(ctx context.Context, id int, sprintID *int, version int) (*model.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToSprint", ctx, id, sprintID, version)
+	ret0, _ := ret[0].(*model.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveToSprint indicates an expected call of MoveToSprint.
+func (mr *MockTodoRepositoryMockRecorder) MoveToSprint(ctx, id, sprintID, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToSprint", reflect.TypeOf((*MockTodoRepository)(nil).MoveToSprint), ctx, id, sprintID, version)
 }
 
 // Search mocks base method.
-func (m *MockTodoRepository) Search(req *model.TodoSearchRequest) ([]model.Todo, error) {
+func (m *MockTodoRepository) Search(ctx context.Context, req *model.TodoSearchRequest) ([]model.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Search", req)
+	ret := m.ctrl.Call(m, "Search", ctx, req)
 	ret0, _ := ret[0].([]model.Todo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Search indicates an expected call of Search.
-func (mr *MockTodoRepositoryMockRecorder) Search(req any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Search(ctx, req any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoRepository)(nil).Search), req)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoRepository)(nil).Search), ctx, req)
+}
+
+// SetCompleted mocks base method.
+func (m *MockTodoRepository) SetCompleted(ctx context.Context, id int, completed bool, version int) (*model.Todo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCompleted", ctx, id, completed, version)
+	ret0, _ := ret[0].(*model.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetCompleted indicates an expected call of SetCompleted.
+func (mr *MockTodoRepositoryMockRecorder) SetCompleted(ctx, id, completed, version any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCompleted", reflect.TypeOf((*MockTodoRepository)(nil).SetCompleted), ctx, id, completed, version)
 }
 
 // Update mocks base method.
-func (m *MockTodoRepository) Update(title string, completed bool, id int) (int, string, error) {
+func (m *MockTodoRepository) Update(ctx context.Context, title string, completed bool, id, version int) (*model.Todo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", title, completed, id)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(string)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret := m.ctrl.Call(m, "Update", ctx, title, completed, id, version)
+	ret0, _ := ret[0].(*model.Todo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTodoRepositoryMockRecorder) Update(title, completed, id any) *gomock.Call {
+func (mr *MockTodoRepositoryMockRecorder) Update(ctx, title, completed, id, version any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoRepository)(nil).Update), title, completed, id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoRepository)(nil).Update), ctx, title, completed, id, version)
 }
@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: workspace_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source=workspace_repository.go -destination=mock/mock_workspace_repository.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	model "backend/internal/model"
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockWorkspaceRepository is a mock of WorkspaceRepository interface.
+type MockWorkspaceRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockWorkspaceRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockWorkspaceRepositoryMockRecorder is the mock recorder for MockWorkspaceRepository.
+type MockWorkspaceRepositoryMockRecorder struct {
+	mock *MockWorkspaceRepository
+}
+
+// NewMockWorkspaceRepository creates a new mock instance.
+func NewMockWorkspaceRepository(ctrl *gomock.Controller) *MockWorkspaceRepository {
+	mock := &MockWorkspaceRepository{ctrl: ctrl}
+	mock.recorder = &MockWorkspaceRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWorkspaceRepository) EXPECT() *MockWorkspaceRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddMember mocks base method.
+func (m *MockWorkspaceRepository) AddMember(ctx context.Context, workspaceID, userID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMember", ctx, workspaceID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddMember indicates an expected call of AddMember.
+func (mr *MockWorkspaceRepositoryMockRecorder) AddMember(ctx, workspaceID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMember", reflect.TypeOf((*MockWorkspaceRepository)(nil).AddMember), ctx, workspaceID, userID, role)
+}
+
+// Create mocks base method.
+func (m *MockWorkspaceRepository) Create(ctx context.Context, name string, ownerID int) (*model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", ctx, name, ownerID)
+	ret0, _ := ret[0].(*model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockWorkspaceRepositoryMockRecorder) Create(ctx, name, ownerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockWorkspaceRepository)(nil).Create), ctx, name, ownerID)
+}
+
+// FindByUserID mocks base method.
+func (m *MockWorkspaceRepository) FindByUserID(ctx context.Context, userID int) ([]model.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByUserID", ctx, userID)
+	ret0, _ := ret[0].([]model.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByUserID indicates an expected call of FindByUserID.
+func (mr *MockWorkspaceRepositoryMockRecorder) FindByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserID", reflect.TypeOf((*MockWorkspaceRepository)(nil).FindByUserID), ctx, userID)
+}
+
+// GetMemberRole mocks base method.
+func (m *MockWorkspaceRepository) GetMemberRole(ctx context.Context, workspaceID, userID int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberRole", ctx, workspaceID, userID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberRole indicates an expected call of GetMemberRole.
+func (mr *MockWorkspaceRepositoryMockRecorder) GetMemberRole(ctx, workspaceID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberRole", reflect.TypeOf((*MockWorkspaceRepository)(nil).GetMemberRole), ctx, workspaceID, userID)
+}
+
+// IsMFARequiredForUser mocks base method.
+func (m *MockWorkspaceRepository) IsMFARequiredForUser(ctx context.Context, userID int) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsMFARequiredForUser", ctx, userID)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsMFARequiredForUser indicates an expected call of IsMFARequiredForUser.
+func (mr *MockWorkspaceRepositoryMockRecorder) IsMFARequiredForUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsMFARequiredForUser", reflect.TypeOf((*MockWorkspaceRepository)(nil).IsMFARequiredForUser), ctx, userID)
+}
+
+// SetMFARequired mocks base method.
+func (m *MockWorkspaceRepository) SetMFARequired(ctx context.Context, workspaceID int, required bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMFARequired", ctx, workspaceID, required)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMFARequired indicates an expected call of SetMFARequired.
+func (mr *MockWorkspaceRepositoryMockRecorder) SetMFARequired(ctx, workspaceID, required any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMFARequired", reflect.TypeOf((*MockWorkspaceRepository)(nil).SetMFARequired), ctx, workspaceID, required)
+}
+
+// UpdateMemberRole mocks base method.
+func (m *MockWorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMemberRole", ctx, workspaceID, userID, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMemberRole indicates an expected call of UpdateMemberRole.
+func (mr *MockWorkspaceRepositoryMockRecorder) UpdateMemberRole(ctx, workspaceID, userID, role any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMemberRole", reflect.TypeOf((*MockWorkspaceRepository)(nil).UpdateMemberRole), ctx, workspaceID, userID, role)
+}
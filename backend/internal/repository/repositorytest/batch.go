@@ -0,0 +1,73 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// BatchLoading は複数のスプリント・TODOをまとめて読み込むメソッドの契約テスト
+// newRepos はテストごとに呼ばれ、データを共有する空のリポジトリを返すこと
+func BatchLoading(t *testing.T, newRepos func(t *testing.T) *repository.Repositories) {
+	ctx := context.Background()
+
+	t.Run("SprintFindByIDs", func(t *testing.T) {
+		repos := newRepos(t)
+
+		first, err := repos.Sprints.Create(ctx, "Sprint 1", "bg-purple-500", false)
+		require.NoError(t, err)
+		second, err := repos.Sprints.Create(ctx, "Sprint 2", "bg-blue-500", true)
+		require.NoError(t, err)
+		deleted, err := repos.Sprints.Create(ctx, "Deleted", "bg-red-500", false)
+		require.NoError(t, err)
+		require.NoError(t, repos.Sprints.Delete(ctx, deleted.ID, repository.AnyVersion))
+
+		// 重複・存在しない・削除済みの ID を含んでも、該当するスプリントを ID 順に1件ずつ返す
+		sprints, err := repos.Sprints.FindByIDs(ctx, []int{second.ID, first.ID, second.ID, deleted.ID, 99999})
+		require.NoError(t, err)
+		require.Len(t, sprints, 2)
+		assert.Equal(t, first.ID, sprints[0].ID)
+		assert.Equal(t, second.ID, sprints[1].ID)
+		assert.True(t, sprints[1].IsFavorite)
+
+		sprints, err = repos.Sprints.FindByIDs(ctx, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, sprints)
+		assert.Empty(t, sprints)
+	})
+
+	t.Run("TodoFindBySprintIDs", func(t *testing.T) {
+		repos := newRepos(t)
+
+		first, err := repos.Sprints.Create(ctx, "Sprint 1", "bg-purple-500", false)
+		require.NoError(t, err)
+		second, err := repos.Sprints.Create(ctx, "Sprint 2", "bg-blue-500", false)
+		require.NoError(t, err)
+
+		a, err := repos.Todos.Create(ctx, "A", "", &second.ID)
+		require.NoError(t, err)
+		b, err := repos.Todos.Create(ctx, "B", "", &first.ID)
+		require.NoError(t, err)
+		_, err = repos.Todos.Create(ctx, "No sprint", "", nil)
+		require.NoError(t, err)
+		deleted, err := repos.Todos.Create(ctx, "Deleted", "", &first.ID)
+		require.NoError(t, err)
+		require.NoError(t, repos.Todos.Delete(ctx, deleted.ID, repository.AnyVersion))
+
+		todos, err := repos.Todos.FindBySprintIDs(ctx, []int{first.ID, second.ID, 99999})
+		require.NoError(t, err)
+		require.Len(t, todos, 2)
+		assert.Equal(t, a.ID, todos[0].ID)
+		assert.Equal(t, second.ID, *todos[0].SprintID)
+		assert.Equal(t, b.ID, todos[1].ID)
+
+		todos, err = repos.Todos.FindBySprintIDs(ctx, []int{})
+		require.NoError(t, err)
+		assert.NotNil(t, todos)
+		assert.Empty(t, todos)
+	})
+}
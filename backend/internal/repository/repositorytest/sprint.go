@@ -0,0 +1,224 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// SprintRepository は SprintRepository の契約テスト
+func SprintRepository(t *testing.T, newRepo func(t *testing.T) repository.SprintRepository) {
+	ctx := context.Background()
+
+	t.Run("Create", func(t *testing.T) {
+		repo := newRepo(t)
+
+		sprint, err := repo.Create(ctx, "Test Sprint", "bg-purple-500", false)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, sprint)
+		assert.Greater(t, sprint.ID, 0)
+		assert.Equal(t, "Test Sprint", sprint.Name)
+		assert.Equal(t, "bg-purple-500", sprint.Color)
+		assert.False(t, sprint.IsFavorite)
+		assert.False(t, time.Time(sprint.CreatedAt).IsZero())
+	})
+
+	t.Run("FindAll", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のスプリントを作成
+		_, err := repo.Create(ctx, "Sprint 1", "bg-purple-500", false)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "Sprint 2", "bg-blue-500", true)
+		require.NoError(t, err)
+
+		sprints, err := repo.FindAll(ctx)
+
+		assert.NoError(t, err)
+		require.Len(t, sprints, 2)
+		// お気に入りが先頭
+		assert.Equal(t, "Sprint 2", sprints[0].Name)
+	})
+
+	t.Run("FindAll_Empty", func(t *testing.T) {
+		sprints, err := newRepo(t).FindAll(ctx)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, sprints, "should be an empty slice, not nil (serialized as [])")
+		assert.Empty(t, sprints)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のスプリントを作成
+		sprint, err := repo.Create(ctx, "Original Sprint", "bg-purple-500", false)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, sprint.Version)
+
+		// 更新
+		updated, err := repo.Update(ctx, sprint.ID, "Updated Sprint", "bg-green-500", repository.AnyVersion)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Updated Sprint", updated.Name)
+		assert.Equal(t, 2, updated.Version)
+
+		sprints, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, sprints, 1)
+		assert.Equal(t, "Updated Sprint", sprints[0].Name)
+		assert.Equal(t, "bg-green-500", sprints[0].Color)
+	})
+
+	t.Run("Update_NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// 存在しないIDで更新
+		_, err := repo.Update(ctx, 99999, "Updated Sprint", "bg-blue-500", repository.AnyVersion)
+
+		assert.ErrorIs(t, err, repository.ErrSprintNotFound)
+		assert.ErrorIs(t, err, apperror.ErrNotFound)
+	})
+
+	t.Run("UpdateFavorite", func(t *testing.T) {
+		repo := newRepo(t)
+
+		sprint, err := repo.Create(ctx, "Sprint", "bg-purple-500", false)
+		require.NoError(t, err)
+
+		updated, err := repo.UpdateFavorite(ctx, sprint.ID, true, sprint.Version)
+		require.NoError(t, err)
+		assert.True(t, updated.IsFavorite)
+		assert.Equal(t, sprint.Version+1, updated.Version)
+
+		isFavorite := true
+		sprints, err := repo.Search(ctx, &model.SprintSearchRequest{IsFavorite: &isFavorite})
+		require.NoError(t, err)
+		require.Len(t, sprints, 1)
+		assert.Equal(t, sprint.ID, sprints[0].ID)
+
+		_, err = repo.UpdateFavorite(ctx, 99999, true, repository.AnyVersion)
+		assert.ErrorIs(t, err, repository.ErrSprintNotFound)
+
+		// 古いバージョンでは更新できない
+		_, err = repo.UpdateFavorite(ctx, sprint.ID, false, sprint.Version)
+		assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+		_, err = repo.Update(ctx, sprint.ID, "Updated Sprint", "bg-blue-500", sprint.Version)
+		assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+		assert.ErrorIs(t, repo.Delete(ctx, sprint.ID, sprint.Version), repository.ErrVersionMismatch)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のスプリントを作成
+		sprint, err := repo.Create(ctx, "To Be Deleted", "bg-red-500", false)
+		require.NoError(t, err)
+
+		// 削除
+		assert.NoError(t, repo.Delete(ctx, sprint.ID, repository.AnyVersion))
+
+		// 削除されたスプリントは一覧・検索・更新の対象外
+		sprints, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, sprints)
+
+		sprints, err = repo.Search(ctx, &model.SprintSearchRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, sprints)
+
+		_, err = repo.Update(ctx, sprint.ID, "Updated Sprint", "bg-blue-500", repository.AnyVersion)
+		assert.ErrorIs(t, err, repository.ErrSprintNotFound)
+		assert.ErrorIs(t, repo.Delete(ctx, sprint.ID, repository.AnyVersion), repository.ErrSprintNotFound)
+	})
+
+	t.Run("Exists", func(t *testing.T) {
+		repo := newRepo(t)
+
+		sprint, err := repo.Create(ctx, "Sprint", "bg-purple-500", false)
+		require.NoError(t, err)
+
+		exists, err := repo.Exists(ctx, sprint.ID)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+
+		exists, err = repo.Exists(ctx, sprint.ID+1)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+
+		// 削除済みのスプリントは存在しない扱い
+		require.NoError(t, repo.Delete(ctx, sprint.ID, repository.AnyVersion))
+		exists, err = repo.Exists(ctx, sprint.ID)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("Search_ByName", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のスプリントを作成
+		_, err := repo.Create(ctx, "Search Test Sprint", "bg-purple-500", false)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "Another Sprint", "bg-blue-500", false)
+		require.NoError(t, err)
+
+		// 名前で検索（大文字小文字は区別しない）
+		name := "SEARCH"
+		sprints, err := repo.Search(ctx, &model.SprintSearchRequest{Name: &name})
+
+		assert.NoError(t, err)
+		require.Len(t, sprints, 1)
+		assert.Equal(t, "Search Test Sprint", sprints[0].Name)
+	})
+
+	t.Run("Search_ByIsFavorite", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のスプリントを作成（お気に入り）
+		_, err := repo.Create(ctx, "Favorite Sprint", "bg-purple-500", true)
+		require.NoError(t, err)
+
+		// お気に入りではないスプリントも作成
+		_, err = repo.Create(ctx, "Non-Favorite Sprint", "bg-blue-500", false)
+		require.NoError(t, err)
+
+		// お気に入りで検索
+		isFavorite := true
+		sprints, err := repo.Search(ctx, &model.SprintSearchRequest{IsFavorite: &isFavorite})
+
+		assert.NoError(t, err)
+		require.Len(t, sprints, 1)
+		assert.True(t, sprints[0].IsFavorite)
+	})
+
+	t.Run("Search_MultipleConditions", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のスプリントを作成
+		_, err := repo.Create(ctx, "Multi Search Sprint", "bg-orange-500", true)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "Multi Search Sprint 2", "bg-orange-500", false)
+		require.NoError(t, err)
+
+		// 複数条件で検索
+		name := "Multi"
+		isFavorite := true
+		sprints, err := repo.Search(ctx, &model.SprintSearchRequest{
+			Name:       &name,
+			IsFavorite: &isFavorite,
+		})
+
+		assert.NoError(t, err)
+		require.Len(t, sprints, 1)
+		assert.Equal(t, "Multi Search Sprint", sprints[0].Name)
+	})
+}
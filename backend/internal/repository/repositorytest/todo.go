@@ -0,0 +1,296 @@
+// Package repositorytest はリポジトリ実装が満たすべき振る舞い（契約）のテストスイート
+//
+// 同じスイートを PostgreSQL・SQLite・インメモリの各実装で実行し、実装間の差異を検出する。
+// 各関数の newRepo はテストごとに呼ばれ、空のリポジトリを返すこと。
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TodoRepository は TodoRepository の契約テスト
+func TodoRepository(t *testing.T, newRepo func(t *testing.T) repository.TodoRepository) {
+	ctx := context.Background()
+
+	t.Run("Create", func(t *testing.T) {
+		repo := newRepo(t)
+
+		todo, err := repo.Create(ctx, "Test Todo", "Test Description", nil)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, todo)
+		assert.Greater(t, todo.ID, 0)
+		assert.Equal(t, "Test Todo", todo.Title)
+		assert.Equal(t, "Test Description", todo.Description)
+		assert.False(t, todo.Completed)
+		assert.Nil(t, todo.SprintID)
+		assert.False(t, time.Time(todo.CreatedAt).IsZero())
+		assert.False(t, time.Time(todo.UpdatedAt).IsZero())
+	})
+
+	t.Run("Create_UnknownSprint", func(t *testing.T) {
+		sprintID := 99999
+		_, err := newRepo(t).Create(ctx, "Test Todo", "Test Description", &sprintID)
+
+		assert.ErrorIs(t, err, repository.ErrUnknownSprint)
+		assert.ErrorIs(t, err, apperror.ErrValidation)
+	})
+
+	t.Run("Create_AssignsUniqueIDs", func(t *testing.T) {
+		repo := newRepo(t)
+
+		first, err := repo.Create(ctx, "Todo 1", "", nil)
+		require.NoError(t, err)
+		second, err := repo.Create(ctx, "Todo 2", "", nil)
+		require.NoError(t, err)
+
+		assert.NotEqual(t, first.ID, second.ID)
+	})
+
+	t.Run("FindAll", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のTODOを作成
+		_, err := repo.Create(ctx, "Todo 1", "Description 1", nil)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "Todo 2", "Description 2", nil)
+		require.NoError(t, err)
+
+		todos, err := repo.FindAll(ctx)
+
+		assert.NoError(t, err)
+		assert.Len(t, todos, 2)
+	})
+
+	t.Run("FindAll_Empty", func(t *testing.T) {
+		todos, err := newRepo(t).FindAll(ctx)
+
+		assert.NoError(t, err)
+		assert.NotNil(t, todos, "should be an empty slice, not nil (serialized as [])")
+		assert.Empty(t, todos)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のTODOを作成
+		todo, err := repo.Create(ctx, "Original Title", "Original Description", nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, 1, todo.Version)
+
+		// 更新
+		updated, err := repo.Update(ctx, "Updated Title", true, todo.ID, repository.AnyVersion)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Updated Title", updated.Title)
+		assert.Equal(t, 2, updated.Version)
+
+		todos, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.Equal(t, "Updated Title", todos[0].Title)
+		assert.Equal(t, "Original Description", todos[0].Description)
+		assert.True(t, todos[0].Completed)
+	})
+
+	t.Run("Update_NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// 存在しないIDで更新
+		_, err := repo.Update(ctx, "Updated Title", true, 99999, repository.AnyVersion)
+
+		assert.ErrorIs(t, err, repository.ErrTodoNotFound)
+		assert.ErrorIs(t, err, apperror.ErrNotFound)
+
+		// バージョンを指定しても、存在しなければ ErrTodoNotFound
+		_, err = repo.Update(ctx, "Updated Title", true, 99999, 1)
+		assert.ErrorIs(t, err, repository.ErrTodoNotFound)
+	})
+
+	t.Run("Update_Version", func(t *testing.T) {
+		repo := newRepo(t)
+
+		todo, err := repo.Create(ctx, "Original Title", "Description", nil)
+		require.NoError(t, err)
+
+		// 現在のバージョンを指定すれば更新できる
+		updated, err := repo.Update(ctx, "First", false, todo.ID, todo.Version)
+		require.NoError(t, err)
+
+		// 古いバージョンでの更新・削除は失敗し、内容は変わらない
+		_, err = repo.Update(ctx, "Second", false, todo.ID, todo.Version)
+		assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+		assert.ErrorIs(t, err, apperror.ErrPreconditionFailed)
+		assert.ErrorIs(t, repo.Delete(ctx, todo.ID, todo.Version), repository.ErrVersionMismatch)
+
+		todos, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.Equal(t, "First", todos[0].Title)
+		assert.Equal(t, updated.Version, todos[0].Version)
+
+		assert.NoError(t, repo.Delete(ctx, todo.ID, updated.Version))
+	})
+
+	t.Run("SetCompleted", func(t *testing.T) {
+		repo := newRepo(t)
+
+		todo, err := repo.Create(ctx, "Title", "Description", nil)
+		require.NoError(t, err)
+
+		// 完了状態だけが変わり、バージョンが上がる
+		updated, err := repo.SetCompleted(ctx, todo.ID, true, todo.Version)
+		require.NoError(t, err)
+		assert.True(t, updated.Completed)
+		assert.Equal(t, "Title", updated.Title)
+		assert.Equal(t, 2, updated.Version)
+
+		_, err = repo.SetCompleted(ctx, todo.ID, false, todo.Version)
+		assert.ErrorIs(t, err, repository.ErrVersionMismatch)
+
+		_, err = repo.SetCompleted(ctx, 99999, true, repository.AnyVersion)
+		assert.ErrorIs(t, err, repository.ErrTodoNotFound)
+	})
+
+	t.Run("MoveToSprint", func(t *testing.T) {
+		repo := newRepo(t)
+
+		todo, err := repo.Create(ctx, "Title", "Description", nil)
+		require.NoError(t, err)
+
+		// 存在しないスプリントには移動できず、内容は変わらない
+		sprintID := 99999
+		_, err = repo.MoveToSprint(ctx, todo.ID, &sprintID, repository.AnyVersion)
+		assert.ErrorIs(t, err, repository.ErrUnknownSprint)
+
+		todos, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.Equal(t, todo.Version, todos[0].Version)
+
+		// nil ならスプリントから外す
+		updated, err := repo.MoveToSprint(ctx, todo.ID, nil, todo.Version)
+		require.NoError(t, err)
+		assert.Nil(t, updated.SprintID)
+		assert.Equal(t, 2, updated.Version)
+
+		_, err = repo.MoveToSprint(ctx, 99999, nil, repository.AnyVersion)
+		assert.ErrorIs(t, err, repository.ErrTodoNotFound)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のTODOを作成
+		todo, err := repo.Create(ctx, "To Be Deleted", "Description", nil)
+		require.NoError(t, err)
+
+		// 削除
+		assert.NoError(t, repo.Delete(ctx, todo.ID, repository.AnyVersion))
+
+		// 削除されたTODOは一覧・検索・更新の対象外
+		todos, err := repo.FindAll(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, todos)
+
+		todos, err = repo.Search(ctx, &model.TodoSearchRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, todos)
+
+		_, err = repo.Update(ctx, "Updated Title", true, todo.ID, repository.AnyVersion)
+		assert.ErrorIs(t, err, repository.ErrTodoNotFound)
+
+		// 削除済みのTODOは再度削除できない
+		assert.ErrorIs(t, repo.Delete(ctx, todo.ID, repository.AnyVersion), repository.ErrTodoNotFound)
+	})
+
+	t.Run("Delete_NotFound", func(t *testing.T) {
+		assert.ErrorIs(t, newRepo(t).Delete(ctx, 99999, repository.AnyVersion), repository.ErrTodoNotFound)
+	})
+
+	t.Run("Search_ByTitle", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のTODOを作成
+		_, err := repo.Create(ctx, "Search Test Todo", "Description", nil)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "Another Todo", "Description", nil)
+		require.NoError(t, err)
+
+		// タイトルで検索（大文字小文字は区別しない）
+		title := "search"
+		todos, err := repo.Search(ctx, &model.TodoSearchRequest{Title: &title})
+
+		assert.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.Equal(t, "Search Test Todo", todos[0].Title)
+	})
+
+	t.Run("Search_ByCompleted", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のTODOを作成
+		todo, err := repo.Create(ctx, "Completed Todo", "Description", nil)
+		require.NoError(t, err)
+
+		// 完了状態に更新
+		_, err = repo.Update(ctx, "Completed Todo", true, todo.ID, repository.AnyVersion)
+		require.NoError(t, err)
+
+		// 未完了のTODOも作成
+		_, err = repo.Create(ctx, "Incomplete Todo", "Description", nil)
+		require.NoError(t, err)
+
+		// 完了状態で検索
+		completed := true
+		todos, err := repo.Search(ctx, &model.TodoSearchRequest{Completed: &completed})
+
+		assert.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.True(t, todos[0].Completed)
+	})
+
+	t.Run("Search_MultipleConditions", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// テスト用のTODOを作成
+		_, err := repo.Create(ctx, "Multi Search Todo", "Special Description", nil)
+		require.NoError(t, err)
+		_, err = repo.Create(ctx, "Multi Search Todo", "Plain Description", nil)
+		require.NoError(t, err)
+
+		// 複数条件で検索
+		title := "Multi"
+		description := "Special"
+		todos, err := repo.Search(ctx, &model.TodoSearchRequest{
+			Title:       &title,
+			Description: &description,
+		})
+
+		assert.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.Equal(t, "Special Description", todos[0].Description)
+	})
+
+	t.Run("Search_NoConditions", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, "Todo 1", "", nil)
+		require.NoError(t, err)
+
+		todos, err := repo.Search(ctx, &model.TodoSearchRequest{})
+
+		assert.NoError(t, err)
+		assert.Len(t, todos, 1)
+	})
+}
@@ -0,0 +1,64 @@
+package repositorytest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UnitOfWork は UnitOfWork の契約テスト
+// newUoW は空のデータに対する UnitOfWork と、トランザクション外から結果を確認するためのリポジトリを返す
+func UnitOfWork(t *testing.T, newUoW func(t *testing.T) (repository.UnitOfWork, *repository.Repositories)) {
+	ctx := context.Background()
+
+	t.Run("Commit", func(t *testing.T) {
+		uow, repos := newUoW(t)
+
+		err := uow.Do(ctx, func(tx *repository.Repositories) error {
+			sprint, err := tx.Sprints.Create(ctx, "Sprint", "bg-purple-500", false)
+			if err != nil {
+				return err
+			}
+			_, err = tx.Todos.Create(ctx, "Todo", "", &sprint.ID)
+			return err
+		})
+		require.NoError(t, err)
+
+		todos, err := repos.Todos.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.NotNil(t, todos[0].SprintID)
+	})
+
+	t.Run("Rollback", func(t *testing.T) {
+		uow, repos := newUoW(t)
+
+		todo, err := repos.Todos.Create(ctx, "Original", "", nil)
+		require.NoError(t, err)
+
+		// 途中でエラーになれば、それまでの変更は全て取り消される
+		errAbort := errors.New("abort")
+		err = uow.Do(ctx, func(tx *repository.Repositories) error {
+			if _, err := tx.Todos.Update(ctx, "Changed", true, todo.ID, repository.AnyVersion); err != nil {
+				return err
+			}
+			if _, err := tx.Todos.Create(ctx, "New", "", nil); err != nil {
+				return err
+			}
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		todos, err := repos.Todos.FindAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, todos, 1)
+		assert.Equal(t, "Original", todos[0].Title)
+		assert.False(t, todos[0].Completed)
+		assert.Equal(t, todo.Version, todos[0].Version)
+	})
+}
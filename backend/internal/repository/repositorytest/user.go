@@ -0,0 +1,206 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// UserRepository は UserRepository の契約テスト
+func UserRepository(t *testing.T, newRepo func(t *testing.T) repository.UserRepository) {
+	ctx := context.Background()
+
+	t.Run("Create", func(t *testing.T) {
+		repo := newRepo(t)
+
+		user, err := repo.Create(ctx, "alice", "alice@example.com", "hash")
+
+		require.NoError(t, err)
+		assert.Greater(t, user.ID, 0)
+		assert.Equal(t, "alice", user.Username)
+		assert.Equal(t, "alice@example.com", user.Email)
+		assert.Equal(t, "hash", user.PasswordHash)
+		assert.Nil(t, user.ExternalID)
+		assert.Equal(t, "local", user.Provider)
+		assert.Equal(t, model.RoleUser, user.Role)
+		assert.Equal(t, 0, user.TokenVersion)
+		assert.True(t, user.IsActive)
+		assert.False(t, time.Time(user.CreatedAt).IsZero())
+	})
+
+	t.Run("Create_Duplicate", func(t *testing.T) {
+		repo := newRepo(t)
+
+		_, err := repo.Create(ctx, "alice", "alice@example.com", "hash")
+		require.NoError(t, err)
+
+		_, err = repo.Create(ctx, "alice", "other@example.com", "hash")
+		assert.ErrorIs(t, err, repository.ErrUsernameTaken, "username must be unique")
+		assert.ErrorIs(t, err, apperror.ErrConflict)
+		_, err = repo.Create(ctx, "other", "alice@example.com", "hash")
+		assert.ErrorIs(t, err, repository.ErrEmailTaken, "email must be unique")
+	})
+
+	t.Run("Find", func(t *testing.T) {
+		repo := newRepo(t)
+
+		created, err := repo.Create(ctx, "alice", "alice@example.com", "hash")
+		require.NoError(t, err)
+
+		byUsername, err := repo.FindByUsername(ctx, "alice")
+		require.NoError(t, err)
+		require.NotNil(t, byUsername)
+		assert.Equal(t, created.ID, byUsername.ID)
+
+		byEmail, err := repo.FindByEmail(ctx, "alice@example.com")
+		require.NoError(t, err)
+		require.NotNil(t, byEmail)
+		assert.Equal(t, created.ID, byEmail.ID)
+
+		byID, err := repo.FindByID(ctx, created.ID)
+		require.NoError(t, err)
+		require.NotNil(t, byID)
+		assert.Equal(t, "alice", byID.Username)
+	})
+
+	t.Run("Find_NotFound", func(t *testing.T) {
+		repo := newRepo(t)
+
+		// 見つからない場合は nil, nil
+		user, err := repo.FindByUsername(ctx, "nobody")
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+
+		user, err = repo.FindByEmail(ctx, "nobody@example.com")
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+
+		user, err = repo.FindByID(ctx, 99999)
+		assert.NoError(t, err)
+		assert.Nil(t, user)
+	})
+
+	t.Run("SetActive", func(t *testing.T) {
+		repo := newRepo(t)
+
+		user, err := repo.Create(ctx, "alice", "alice@example.com", "hash")
+		require.NoError(t, err)
+
+		// 無効化するとログイン用の検索から除外され、発行済みトークンも失効する
+		rowsAffected, err := repo.SetActive(ctx, user.ID, false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rowsAffected)
+
+		found, err := repo.FindByUsername(ctx, "alice")
+		require.NoError(t, err)
+		assert.Nil(t, found)
+		found, err = repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		// 再有効化ではトークンバージョンは変わらない
+		rowsAffected, err = repo.SetActive(ctx, user.ID, true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rowsAffected)
+
+		found, err = repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.True(t, found.IsActive)
+		assert.Equal(t, 1, found.TokenVersion)
+
+		_, err = repo.SetActive(ctx, 99999, false)
+		assert.ErrorIs(t, err, repository.ErrUserNotFound)
+	})
+
+	t.Run("SetRole", func(t *testing.T) {
+		repo := newRepo(t)
+
+		user, err := repo.Create(ctx, "alice", "alice@example.com", "hash")
+		require.NoError(t, err)
+
+		rowsAffected, err := repo.SetRole(ctx, user.ID, model.RoleAdmin)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rowsAffected)
+
+		found, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.RoleAdmin, found.Role)
+
+		_, err = repo.SetRole(ctx, 99999, model.RoleAdmin)
+		assert.ErrorIs(t, err, repository.ErrUserNotFound)
+	})
+
+	t.Run("RevokeTokens", func(t *testing.T) {
+		repo := newRepo(t)
+
+		user, err := repo.Create(ctx, "alice", "alice@example.com", "hash")
+		require.NoError(t, err)
+
+		rowsAffected, err := repo.RevokeTokens(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, rowsAffected)
+
+		found, err := repo.FindByID(ctx, user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, user.TokenVersion+1, found.TokenVersion)
+
+		_, err = repo.RevokeTokens(ctx, 99999)
+		assert.ErrorIs(t, err, repository.ErrUserNotFound)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		repo := newRepo(t)
+
+		alice, err := repo.Create(ctx, "alice", "alice@example.com", "hash")
+		require.NoError(t, err)
+		bob, err := repo.Create(ctx, "bob", "bob@example.org", "hash")
+		require.NoError(t, err)
+		carol, err := repo.Create(ctx, "carol", "carol@example.com", "hash")
+		require.NoError(t, err)
+		_, err = repo.SetActive(ctx, bob.ID, false)
+		require.NoError(t, err)
+		_, err = repo.SetRole(ctx, carol.ID, model.RoleAdmin)
+		require.NoError(t, err)
+
+		ids := func(req model.UserSearchRequest) []int {
+			t.Helper()
+			if req.Limit == 0 {
+				req.Limit = 10
+			}
+			users, err := repo.Search(ctx, &req)
+			require.NoError(t, err)
+			result := []int{}
+			for _, u := range users {
+				result = append(result, u.ID)
+			}
+			return result
+		}
+
+		// 無効化されたユーザーも含め、ID順
+		assert.Equal(t, []int{alice.ID, bob.ID, carol.ID}, ids(model.UserSearchRequest{}))
+
+		// ユーザー名・メールアドレスの部分一致（大文字小文字は区別しない）
+		query := "EXAMPLE.COM"
+		assert.Equal(t, []int{alice.ID, carol.ID}, ids(model.UserSearchRequest{Query: &query}))
+		query = "bo"
+		assert.Equal(t, []int{bob.ID}, ids(model.UserSearchRequest{Query: &query}))
+
+		active := false
+		assert.Equal(t, []int{bob.ID}, ids(model.UserSearchRequest{IsActive: &active}))
+
+		role := model.RoleAdmin
+		assert.Equal(t, []int{carol.ID}, ids(model.UserSearchRequest{Role: &role}))
+
+		// ページング
+		assert.Equal(t, []int{bob.ID}, ids(model.UserSearchRequest{Limit: 1, Offset: 1}))
+		assert.Empty(t, ids(model.UserSearchRequest{Offset: 3}))
+	})
+}
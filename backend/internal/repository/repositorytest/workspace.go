@@ -0,0 +1,76 @@
+package repositorytest
+
+import (
+	"context"
+	"testing"
+
+	"backend/internal/model"
+	"backend/internal/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// WorkspaceRepository は WorkspaceRepository の契約テスト（メンバーの追加・ロールの変更）
+// newRepos はユーザーとワークスペースのデータを共有するリポジトリを返す
+func WorkspaceRepository(t *testing.T, newRepos func(t *testing.T) *repository.Repositories) {
+	ctx := context.Background()
+
+	// setup はオーナーの alice とメンバーでない bob、alice のワークスペースを作成する
+	setup := func(t *testing.T) (repository.WorkspaceRepository, *model.Workspace, *model.User, *model.User) {
+		repos := newRepos(t)
+		alice, err := repos.Users.Create(ctx, "alice", "alice@example.com", "hash")
+		require.NoError(t, err)
+		bob, err := repos.Users.Create(ctx, "bob", "bob@example.com", "hash")
+		require.NoError(t, err)
+		workspace, err := repos.Workspaces.Create(ctx, "Team", alice.ID)
+		require.NoError(t, err)
+		return repos.Workspaces, workspace, alice, bob
+	}
+
+	t.Run("AddMember", func(t *testing.T) {
+		repo, workspace, _, bob := setup(t)
+
+		require.NoError(t, repo.AddMember(ctx, workspace.ID, bob.ID, model.WorkspaceRoleMember))
+		role, err := repo.GetMemberRole(ctx, workspace.ID, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.WorkspaceRoleMember, role)
+	})
+
+	t.Run("AddMember_Existing", func(t *testing.T) {
+		repo, workspace, alice, bob := setup(t)
+		require.NoError(t, repo.AddMember(ctx, workspace.ID, bob.ID, model.WorkspaceRoleMember))
+
+		// 追加し直してもロールは変わらない
+		assert.ErrorIs(t, repo.AddMember(ctx, workspace.ID, bob.ID, model.WorkspaceRoleAdmin), repository.ErrMemberExists)
+		assert.ErrorIs(t, repo.AddMember(ctx, workspace.ID, alice.ID, model.WorkspaceRoleMember), repository.ErrMemberExists)
+
+		role, err := repo.GetMemberRole(ctx, workspace.ID, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.WorkspaceRoleMember, role)
+		role, err = repo.GetMemberRole(ctx, workspace.ID, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.WorkspaceRoleOwner, role)
+	})
+
+	t.Run("UpdateMemberRole", func(t *testing.T) {
+		repo, workspace, _, bob := setup(t)
+		require.NoError(t, repo.AddMember(ctx, workspace.ID, bob.ID, model.WorkspaceRoleMember))
+
+		require.NoError(t, repo.UpdateMemberRole(ctx, workspace.ID, bob.ID, model.WorkspaceRoleAdmin))
+		role, err := repo.GetMemberRole(ctx, workspace.ID, bob.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.WorkspaceRoleAdmin, role)
+	})
+
+	t.Run("UpdateMemberRole_OwnerAndNonMember", func(t *testing.T) {
+		repo, workspace, alice, bob := setup(t)
+
+		assert.ErrorIs(t, repo.UpdateMemberRole(ctx, workspace.ID, alice.ID, model.WorkspaceRoleMember), repository.ErrOwnerRoleImmutable)
+		assert.ErrorIs(t, repo.UpdateMemberRole(ctx, workspace.ID, bob.ID, model.WorkspaceRoleAdmin), repository.ErrMemberNotFound)
+
+		role, err := repo.GetMemberRole(ctx, workspace.ID, alice.ID)
+		require.NoError(t, err)
+		assert.Equal(t, model.WorkspaceRoleOwner, role)
+	})
+}
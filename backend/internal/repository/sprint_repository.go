@@ -4,31 +4,40 @@ package repository
 
 import (
 	"backend/internal/model"
+	"context"
 	"database/sql"
+	"errors"
 	"strconv"
 )
 
 type SprintRepository interface {
-	FindAll() ([]model.Sprint, error)
-	Search(req *model.SprintSearchRequest) ([]model.Sprint, error)
-	Create(name, color string, isFavorite bool) (*model.Sprint, error)
-	Update(id int, name, color string) (int, string, error)
-	UpdateFavorite(id int, isFavorite bool) error
-	Delete(id int) error
+	FindAll(ctx context.Context) ([]model.Sprint, error)
+	Search(ctx context.Context, req *model.SprintSearchRequest) ([]model.Sprint, error)
+	// FindByIDs は指定したスプリントをまとめて返す（GraphQL の DataLoader 用）
+	FindByIDs(ctx context.Context, ids []int) ([]model.Sprint, error)
+	Create(ctx context.Context, name, color string, isFavorite bool) (*model.Sprint, error)
+	// Update / UpdateFavorite / Delete は version が AnyVersion 以外なら、現在のバージョンと一致する場合のみ変更する
+	Update(ctx context.Context, id int, name, color string, version int) (*model.Sprint, error)
+	UpdateFavorite(ctx context.Context, id int, isFavorite bool, version int) (*model.Sprint, error)
+	Delete(ctx context.Context, id int, version int) error
+	// Exists は削除されていないスプリントが存在するかどうか
+	Exists(ctx context.Context, id int) (bool, error)
 }
 
+const sprintColumns = "id, name, color, is_favorite, version, created_at, updated_at"
+
 type sprintRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewSprintRepository(db *sql.DB) SprintRepository {
+func NewSprintRepository(db DBTX) SprintRepository {
 	return &sprintRepository{
 		db: db,
 	}
 }
 
-func (r *sprintRepository) FindAll() ([]model.Sprint, error) {
-	rows, err := r.db.Query("SELECT id, name, color, is_favorite, created_at, updated_at FROM sprints WHERE is_deleted = false ORDER BY is_favorite DESC, created_at DESC")
+func (r *sprintRepository) FindAll(ctx context.Context) ([]model.Sprint, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+sprintColumns+" FROM sprints WHERE is_deleted = false ORDER BY is_favorite DESC, created_at DESC")
 	if err != nil {
 		return nil, err
 	}
@@ -36,18 +45,18 @@ func (r *sprintRepository) FindAll() ([]model.Sprint, error) {
 
 	sprints := []model.Sprint{}
 	for rows.Next() {
-		var s model.Sprint
-		if err := rows.Scan(&s.ID, &s.Name, &s.Color, &s.IsFavorite, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		sp, err := scanSprint(rows)
+		if err != nil {
 			return nil, err
 		}
-		sprints = append(sprints, s)
+		sprints = append(sprints, *sp)
 	}
 
 	return sprints, nil
 }
 
-func (r *sprintRepository) Search(req *model.SprintSearchRequest) ([]model.Sprint, error) {
-	query := "SELECT id, name, color, is_favorite, created_at, updated_at FROM sprints WHERE is_deleted = false"
+func (r *sprintRepository) Search(ctx context.Context, req *model.SprintSearchRequest) ([]model.Sprint, error) {
+	query := "SELECT " + sprintColumns + " FROM sprints WHERE is_deleted = false"
 	args := []interface{}{}
 	paramCount := 1
 
@@ -67,7 +76,7 @@ func (r *sprintRepository) Search(req *model.SprintSearchRequest) ([]model.Sprin
 
 	query += " ORDER BY is_favorite DESC, created_at DESC"
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -75,27 +84,52 @@ func (r *sprintRepository) Search(req *model.SprintSearchRequest) ([]model.Sprin
 
 	sprints := []model.Sprint{}
 	for rows.Next() {
-		var s model.Sprint
-		if err := rows.Scan(&s.ID, &s.Name, &s.Color, &s.IsFavorite, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		sp, err := scanSprint(rows)
+		if err != nil {
 			return nil, err
 		}
-		sprints = append(sprints, s)
+		sprints = append(sprints, *sp)
 	}
 
 	return sprints, nil
 }
 
-func (r *sprintRepository) Create(name, color string, isFavorite bool) (*model.Sprint, error) {
+// FindByIDs は削除されていないスプリントを ID 順に返す。存在しない ID は無視する
+func (r *sprintRepository) FindByIDs(ctx context.Context, ids []int) ([]model.Sprint, error) {
+	sprints := []model.Sprint{}
+	if len(ids) == 0 {
+		return sprints, nil
+	}
+
+	placeholders, args := inPlaceholders(ids)
+	rows, err := r.db.QueryContext(ctx, "SELECT "+sprintColumns+" FROM sprints WHERE is_deleted = false AND id IN ("+placeholders+") ORDER BY id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sp, err := scanSprint(rows)
+		if err != nil {
+			return nil, err
+		}
+		sprints = append(sprints, *sp)
+	}
+
+	return sprints, rows.Err()
+}
+
+func (r *sprintRepository) Create(ctx context.Context, name, color string, isFavorite bool) (*model.Sprint, error) {
 	s := &model.Sprint{
 		Name:       name,
 		Color:      color,
 		IsFavorite: isFavorite,
 	}
 
-	err := r.db.QueryRow(
-		"INSERT INTO sprints (name, color, is_favorite) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at",
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO sprints (name, color, is_favorite) VALUES ($1, $2, $3) RETURNING id, version, created_at, updated_at",
 		s.Name, s.Color, s.IsFavorite,
-	).Scan(&s.ID, &s.CreatedAt, &s.UpdatedAt)
+	).Scan(&s.ID, &s.Version, &s.CreatedAt, &s.UpdatedAt)
 
 	if err != nil {
 		return nil, err
@@ -104,35 +138,71 @@ func (r *sprintRepository) Create(name, color string, isFavorite bool) (*model.S
 	return s, nil
 }
 
-func (r *sprintRepository) Update(id int, name, color string) (int, string, error) {
-	result, err := r.db.Exec(
-		"UPDATE sprints SET name = $1, color = $2, updated_at = NOW() WHERE id = $3 AND is_deleted = false",
-		name, color, id,
+// Update はスプリントを更新し、バージョンを上げた更新後のスプリントを返す
+// 存在しない・削除済みなら ErrSprintNotFound、バージョンが異なれば ErrVersionMismatch
+func (r *sprintRepository) Update(ctx context.Context, id int, name, color string, version int) (*model.Sprint, error) {
+	return r.updateOne(ctx, id, version,
+		"UPDATE sprints SET name = $1, color = $2, version = version + 1, updated_at = NOW() WHERE id = $3 AND is_deleted = false AND ($4 = 0 OR version = $4) RETURNING "+sprintColumns,
+		name, color, id, version,
+	)
+}
+
+func (r *sprintRepository) Exists(ctx context.Context, id int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRowContext(ctx,
+		"SELECT EXISTS (SELECT 1 FROM sprints WHERE id = $1 AND is_deleted = false)",
+		id,
+	).Scan(&exists)
+	return exists, err
+}
+
+// UpdateFavorite はお気に入り状態を更新する
+// 存在しない・削除済みなら ErrSprintNotFound、バージョンが異なれば ErrVersionMismatch
+func (r *sprintRepository) UpdateFavorite(ctx context.Context, id int, isFavorite bool, version int) (*model.Sprint, error) {
+	return r.updateOne(ctx, id, version,
+		"UPDATE sprints SET is_favorite = $1, version = version + 1, updated_at = NOW() WHERE id = $2 AND is_deleted = false AND ($3 = 0 OR version = $3) RETURNING "+sprintColumns,
+		isFavorite, id, version,
+	)
+}
+
+// Delete はスプリントを論理削除する
+// 存在しない・削除済みなら ErrSprintNotFound、バージョンが異なれば ErrVersionMismatch
+func (r *sprintRepository) Delete(ctx context.Context, id int, version int) error {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE sprints SET is_deleted = true, version = version + 1, updated_at = NOW() WHERE id = $1 AND is_deleted = false AND ($2 = 0 OR version = $2)",
+		id, version,
 	)
 	if err != nil {
-		return 0, "", err
+		return err
 	}
 
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return 0, "", err
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFoundOrMismatch(ctx, r.db, "sprints", id, version, ErrSprintNotFound)
 	}
 
-	return int(rowsAffected), "Sprint updated successfully", nil
+	return nil
 }
 
-func (r *sprintRepository) UpdateFavorite(id int, isFavorite bool) error {
-	_, err := r.db.Exec(
-		"UPDATE sprints SET is_favorite = $1, updated_at = NOW() WHERE id = $2 AND is_deleted = false",
-		isFavorite, id,
-	)
-	return err
+// updateOne は RETURNING 付きの更新を実行し、更新後のスプリントを返す
+func (r *sprintRepository) updateOne(ctx context.Context, id, version int, query string, args ...any) (*model.Sprint, error) {
+	sp, err := scanSprint(r.db.QueryRowContext(ctx, query, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFoundOrMismatch(ctx, r.db, "sprints", id, version, ErrSprintNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sp, nil
 }
 
-func (r *sprintRepository) Delete(id int) error {
-	_, err := r.db.Exec(
-		"UPDATE sprints SET is_deleted = true, updated_at = NOW() WHERE id = $1",
-		id,
-	)
-	return err
+func scanSprint(row rowScanner) (*model.Sprint, error) {
+	var s model.Sprint
+	if err := row.Scan(&s.ID, &s.Name, &s.Color, &s.IsFavorite, &s.Version, &s.CreatedAt, &s.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &s, nil
 }
@@ -0,0 +1,54 @@
+package repository
+
+//go:generate mockgen -source=stats_repository.go -destination=mock/mock_stats_repository.go -package=mock
+
+import (
+	"backend/internal/model"
+	"context"
+	"time"
+)
+
+type StatsRepository interface {
+	GetSystemStats(ctx context.Context) (*model.SystemStats, error)
+}
+
+type statsRepository struct {
+	db DBTX
+}
+
+func NewStatsRepository(db DBTX) StatsRepository {
+	return &statsRepository{db: db}
+}
+
+func (r *statsRepository) GetSystemStats(ctx context.Context) (*model.SystemStats, error) {
+	stats := &model.SystemStats{}
+	// 期間の起点はDBの方言に依存しないよう引数で渡す
+	since := time.Now().UTC().Add(-24 * time.Hour)
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			(SELECT COUNT(*) FROM users),
+			(SELECT COUNT(*) FROM users WHERE is_active = true),
+			(SELECT COUNT(*) FROM users WHERE role = 'admin'),
+			(SELECT COUNT(*) FROM user_mfa WHERE enabled = true),
+			(SELECT COUNT(*) FROM todos WHERE is_deleted = false),
+			(SELECT COUNT(*) FROM todos WHERE is_deleted = false AND completed = true),
+			(SELECT COUNT(*) FROM sprints WHERE is_deleted = false),
+			(SELECT COUNT(*) FROM workspaces),
+			(SELECT COUNT(*) FROM auth_audit_logs WHERE created_at > $1)
+	`, since).Scan(
+		&stats.Users,
+		&stats.ActiveUsers,
+		&stats.Admins,
+		&stats.MFAEnabled,
+		&stats.Todos,
+		&stats.CompletedTodos,
+		&stats.Sprints,
+		&stats.Workspaces,
+		&stats.FailedLogins24h,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
@@ -4,28 +4,38 @@ package repository
 
 import (
 	"backend/internal/model"
+	"context"
 	"database/sql"
+	"errors"
 	"strconv"
 )
 
 type TodoRepository interface {
-	FindAll() ([]model.Todo, error)
-	Search(req *model.TodoSearchRequest) ([]model.Todo, error)
-	Create(title string, description string, sprintID *int) (*model.Todo, error)
-	Update(title string, completed bool, id int) (int, string, error)
-	Delete(id int) error
+	FindAll(ctx context.Context) ([]model.Todo, error)
+	Search(ctx context.Context, req *model.TodoSearchRequest) ([]model.Todo, error)
+	// FindBySprintIDs は指定したスプリントのTODOをまとめて返す（GraphQL の DataLoader 用）
+	FindBySprintIDs(ctx context.Context, sprintIDs []int) ([]model.Todo, error)
+	Create(ctx context.Context, title string, description string, sprintID *int) (*model.Todo, error)
+	// Update / SetCompleted / MoveToSprint / Delete は version が AnyVersion 以外なら、現在のバージョンと一致する場合のみ変更する
+	Update(ctx context.Context, title string, completed bool, id int, version int) (*model.Todo, error)
+	SetCompleted(ctx context.Context, id int, completed bool, version int) (*model.Todo, error)
+	// MoveToSprint は sprintID が nil ならスプリントから外す
+	MoveToSprint(ctx context.Context, id int, sprintID *int, version int) (*model.Todo, error)
+	Delete(ctx context.Context, id int, version int) error
 }
 
+const todoColumns = "id, title, description, completed, sprint_id, version, created_at, updated_at"
+
 type todoRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewTodoRepository(db *sql.DB) TodoRepository {
+func NewTodoRepository(db DBTX) TodoRepository {
 	return &todoRepository{db: db}
 }
 
-func (r *todoRepository) FindAll() ([]model.Todo, error) {
-	rows, err := r.db.Query("SELECT id, title, description, completed, sprint_id, created_at, updated_at FROM todos WHERE is_deleted = false")
+func (r *todoRepository) FindAll(ctx context.Context) ([]model.Todo, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+todoColumns+" FROM todos WHERE is_deleted = false")
 	if err != nil {
 		return nil, err
 	}
@@ -33,17 +43,18 @@ func (r *todoRepository) FindAll() ([]model.Todo, error) {
 
 	todos := []model.Todo{}
 	for rows.Next() {
-		var t model.Todo
-		if err := rows.Scan(&t.ID, &t.Title, &t.Description, &t.Completed, &t.SprintID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		t, err := scanTodo(rows)
+		if err != nil {
 			return nil, err
 		}
-		todos = append(todos, t)
+		todos = append(todos, *t)
 	}
 
 	return todos, nil
 }
 
-func (r *todoRepository) Create(title string, description string, sprintID *int) (*model.Todo, error) {
+// Create はTODOを作成する。存在しないスプリントを指定すると ErrUnknownSprint
+func (r *todoRepository) Create(ctx context.Context, title string, description string, sprintID *int) (*model.Todo, error) {
 	t := &model.Todo{
 		Title:       title,
 		Description: description,
@@ -51,39 +62,76 @@ func (r *todoRepository) Create(title string, description string, sprintID *int)
 		SprintID:    sprintID,
 	}
 
-	err := r.db.QueryRow(
-		"INSERT INTO todos (title, description, sprint_id) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at",
+	err := r.db.QueryRowContext(ctx,
+		"INSERT INTO todos (title, description, sprint_id) VALUES ($1, $2, $3) RETURNING id, version, created_at, updated_at",
 		t.Title,
 		t.Description,
 		t.SprintID,
-	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+	).Scan(&t.ID, &t.Version, &t.CreatedAt, &t.UpdatedAt)
 
 	if err != nil {
+		if foreignKeyViolation(err) {
+			return nil, ErrUnknownSprint.WithCause(err)
+		}
 		return nil, err
 	}
 
 	return t, nil
 }
 
-func (r *todoRepository) Update(title string, completed bool, id int) (int, string, error) {
-	result, err := r.db.Exec(
-		"UPDATE todos SET title = $1, completed = $2, updated_at = NOW() WHERE id = $3 AND is_deleted = false",
-		title, completed, id,
-	)
+// Update はTODOを更新し、バージョンを上げた更新後のTODOを返す
+// 存在しない・削除済みなら ErrTodoNotFound、バージョンが異なれば ErrVersionMismatch
+func (r *todoRepository) Update(ctx context.Context, title string, completed bool, id int, version int) (*model.Todo, error) {
+	t, err := scanTodo(r.db.QueryRowContext(ctx,
+		"UPDATE todos SET title = $1, completed = $2, version = version + 1, updated_at = NOW() WHERE id = $3 AND is_deleted = false AND ($4 = 0 OR version = $4) RETURNING "+todoColumns,
+		title, completed, id, version,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFoundOrMismatch(ctx, r.db, "todos", id, version, ErrTodoNotFound)
+	}
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
+	return t, nil
+}
 
-	rowsAffected, err := result.RowsAffected()
+// SetCompleted はTODOの完了状態だけを変更する
+// 存在しない・削除済みなら ErrTodoNotFound、バージョンが異なれば ErrVersionMismatch
+func (r *todoRepository) SetCompleted(ctx context.Context, id int, completed bool, version int) (*model.Todo, error) {
+	t, err := scanTodo(r.db.QueryRowContext(ctx,
+		"UPDATE todos SET completed = $1, version = version + 1, updated_at = NOW() WHERE id = $2 AND is_deleted = false AND ($3 = 0 OR version = $3) RETURNING "+todoColumns,
+		completed, id, version,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFoundOrMismatch(ctx, r.db, "todos", id, version, ErrTodoNotFound)
+	}
 	if err != nil {
-		return 0, "", err
+		return nil, err
 	}
+	return t, nil
+}
 
-	return int(rowsAffected), "Todo updated successfully", nil
+// MoveToSprint はTODOのスプリントを変更する。存在しないスプリントを指定すると ErrUnknownSprint
+// 存在しない・削除済みなら ErrTodoNotFound、バージョンが異なれば ErrVersionMismatch
+func (r *todoRepository) MoveToSprint(ctx context.Context, id int, sprintID *int, version int) (*model.Todo, error) {
+	t, err := scanTodo(r.db.QueryRowContext(ctx,
+		"UPDATE todos SET sprint_id = $1, version = version + 1, updated_at = NOW() WHERE id = $2 AND is_deleted = false AND ($3 = 0 OR version = $3) RETURNING "+todoColumns,
+		sprintID, id, version,
+	))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, notFoundOrMismatch(ctx, r.db, "todos", id, version, ErrTodoNotFound)
+	}
+	if err != nil {
+		if foreignKeyViolation(err) {
+			return nil, ErrUnknownSprint.WithCause(err)
+		}
+		return nil, err
+	}
+	return t, nil
 }
 
-func (r *todoRepository) Search(req *model.TodoSearchRequest) ([]model.Todo, error) {
-	query := "SELECT id, title, description, completed, sprint_id, created_at, updated_at FROM todos WHERE is_deleted = false"
+func (r *todoRepository) Search(ctx context.Context, req *model.TodoSearchRequest) ([]model.Todo, error) {
+	query := "SELECT " + todoColumns + " FROM todos WHERE is_deleted = false"
 	args := []interface{}{}
 	paramCount := 1
 
@@ -115,7 +163,7 @@ func (r *todoRepository) Search(req *model.TodoSearchRequest) ([]model.Todo, err
 		paramCount++
 	}
 
-	rows, err := r.db.Query(query, args...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -123,18 +171,67 @@ func (r *todoRepository) Search(req *model.TodoSearchRequest) ([]model.Todo, err
 
 	todos := []model.Todo{}
 	for rows.Next() {
-		var t model.Todo
-		if err := rows.Scan(&t.ID, &t.Title, &t.Description, &t.Completed, &t.SprintID, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		t, err := scanTodo(rows)
+		if err != nil {
 			return nil, err
 		}
-		todos = append(todos, t)
+		todos = append(todos, *t)
 	}
 
 	return todos, nil
 }
 
-func (r *todoRepository) Delete(id int) error {
-	query := `UPDATE todos SET is_deleted = true WHERE id = $1`
-	_, err := r.db.Exec(query, id)
-	return err
+// FindBySprintIDs は削除されていないTODOを ID 順に返す。sprintIDs が空なら空のスライス
+func (r *todoRepository) FindBySprintIDs(ctx context.Context, sprintIDs []int) ([]model.Todo, error) {
+	todos := []model.Todo{}
+	if len(sprintIDs) == 0 {
+		return todos, nil
+	}
+
+	placeholders, args := inPlaceholders(sprintIDs)
+	rows, err := r.db.QueryContext(ctx, "SELECT "+todoColumns+" FROM todos WHERE is_deleted = false AND sprint_id IN ("+placeholders+") ORDER BY id", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		t, err := scanTodo(rows)
+		if err != nil {
+			return nil, err
+		}
+		todos = append(todos, *t)
+	}
+
+	return todos, rows.Err()
+}
+
+// Delete はTODOを論理削除する
+// 存在しない・削除済みなら ErrTodoNotFound、バージョンが異なれば ErrVersionMismatch
+func (r *todoRepository) Delete(ctx context.Context, id int, version int) error {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE todos SET is_deleted = true, version = version + 1, updated_at = NOW() WHERE id = $1 AND is_deleted = false AND ($2 = 0 OR version = $2)",
+		id, version,
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return notFoundOrMismatch(ctx, r.db, "todos", id, version, ErrTodoNotFound)
+	}
+
+	return nil
+}
+
+func scanTodo(row rowScanner) (*model.Todo, error) {
+	var t model.Todo
+	if err := row.Scan(&t.ID, &t.Title, &t.Description, &t.Completed, &t.SprintID, &t.Version, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
 }
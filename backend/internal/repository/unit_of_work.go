@@ -0,0 +1,134 @@
+package repository
+
+//go:generate mockgen -source=unit_of_work.go -destination=mock/mock_unit_of_work.go -package=mock
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Repositories は同じ接続（またはトランザクション）を共有するリポジトリの組
+type Repositories struct {
+	Todos      TodoRepository
+	Sprints    SprintRepository
+	Users      UserRepository
+	AuthAudit  AuthAuditRepository
+	MFA        MFARepository
+	Workspaces WorkspaceRepository
+	Stats      StatsRepository
+	// ChangeEvents は変更イベントのアウトボックス（changefeed のデコレーターが変更と同じトランザクションで記録する）
+	ChangeEvents ChangeEventRepository
+}
+
+// NewRepositories は db（*sql.DB または *sql.Tx）を使うリポジトリを作成する
+func NewRepositories(db DBTX) *Repositories {
+	return &Repositories{
+		Todos:      NewTodoRepository(db),
+		Sprints:    NewSprintRepository(db),
+		Users:      NewUserRepository(db),
+		AuthAudit:  NewAuthAuditRepository(db),
+		MFA:        NewMFARepository(db),
+		Workspaces: NewWorkspaceRepository(db),
+		Stats:      NewStatsRepository(db),
+
+		ChangeEvents: NewChangeEventRepository(db),
+	}
+}
+
+// UnitOfWork は複数のリポジトリにまたがる処理を1つのトランザクションで実行する
+type UnitOfWork interface {
+	// Do は fn をトランザクション内で実行する
+	// fn がエラーを返すかパニックした場合はロールバックし、シリアライズ失敗・デッドロック・
+	// SQLite のロック競合時は fn ごと再実行する（fn はDB以外の副作用を持たないこと）
+	Do(ctx context.Context, fn func(repos *Repositories) error) error
+}
+
+// UnitOfWorkConfig はトランザクションの設定
+type UnitOfWorkConfig struct {
+	Isolation    sql.IsolationLevel
+	MaxRetries   int
+	RetryBackoff time.Duration
+}
+
+// DefaultUnitOfWorkConfig はデフォルト設定（READ COMMITTED、最大3回再試行）
+func DefaultUnitOfWorkConfig() UnitOfWorkConfig {
+	return UnitOfWorkConfig{
+		Isolation:    sql.LevelDefault,
+		MaxRetries:   3,
+		RetryBackoff: 20 * time.Millisecond,
+	}
+}
+
+type unitOfWork struct {
+	db  *sql.DB
+	cfg UnitOfWorkConfig
+}
+
+func NewUnitOfWork(db *sql.DB, cfg UnitOfWorkConfig) UnitOfWork {
+	return &unitOfWork{db: db, cfg: cfg}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(repos *Repositories) error) error {
+	for attempt := 0; ; attempt++ {
+		err := u.run(ctx, fn)
+		if err == nil || !isRetryableTxError(err) || attempt >= u.cfg.MaxRetries {
+			return err
+		}
+
+		backoff := u.cfg.RetryBackoff * time.Duration(attempt+1)
+		log.Printf("[DB] Retrying transaction in %s (attempt %d): %v", backoff, attempt+1, err)
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+func (u *unitOfWork) run(ctx context.Context, fn func(repos *Repositories) error) error {
+	tx, err := u.db.BeginTx(ctx, &sql.TxOptions{Isolation: u.cfg.Isolation})
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(NewRepositories(tx)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			log.Printf("[DB] Failed to roll back transaction: %v", rbErr)
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+// isRetryableTxError は再実行で成功し得るエラー（シリアライズ失敗・デッドロック・ロック競合）かどうか
+func isRetryableTxError(err error) bool {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "40001", // serialization_failure
+			"40P01": // deadlock_detected
+			return true
+		}
+		return false
+	}
+	// SQLite は書き込みロックを busy_timeout まで待っても取れないと SQLITE_BUSY、
+	// 同じ接続内のテーブルロックの競合では SQLITE_LOCKED を返す（メッセージで判定する。uniqueViolation を参照）
+	msg := err.Error()
+	return strings.Contains(msg, "database is locked") || strings.Contains(msg, "database table is locked")
+}
@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"backend/internal/storage/storagetest"
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestUnitOfWork(t *testing.T) (UnitOfWork, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	cfg := DefaultUnitOfWorkConfig()
+	cfg.RetryBackoff = time.Millisecond
+	return NewUnitOfWork(db, cfg), mock
+}
+
+func TestUnitOfWork_CommitsOnSuccess(t *testing.T) {
+	uow, mock := newTestUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("UPDATE todos SET title").WillReturnRows(
+		sqlmock.NewRows([]string{"id", "title", "description", "completed", "sprint_id", "version", "created_at", "updated_at"}).
+			AddRow(1, "title", "", true, nil, 2, time.Now(), time.Now()),
+	)
+	mock.ExpectCommit()
+
+	err := uow.Do(context.Background(), func(repos *Repositories) error {
+		_, err := repos.Todos.Update(context.Background(), "title", true, 1, AnyVersion)
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RollsBackOnError(t *testing.T) {
+	uow, mock := newTestUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	errAbort := errors.New("abort")
+	err := uow.Do(context.Background(), func(repos *Repositories) error {
+		return errAbort
+	})
+
+	assert.ErrorIs(t, err, errAbort)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RollsBackOnPanic(t *testing.T) {
+	uow, mock := newTestUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	assert.PanicsWithValue(t, "boom", func() {
+		_ = uow.Do(context.Background(), func(repos *Repositories) error {
+			panic("boom")
+		})
+	})
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_RetriesSerializationFailure(t *testing.T) {
+	uow, mock := newTestUnitOfWork(t)
+
+	serializationFailure := &pq.Error{Code: "40001"}
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM user_recovery_codes").WillReturnError(serializationFailure)
+	mock.ExpectRollback()
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM user_recovery_codes").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM user_mfa").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	attempts := 0
+	err := uow.Do(context.Background(), func(repos *Repositories) error {
+		attempts++
+		// トランザクション内では MFARepository.Delete は新しいトランザクションを開始しない
+		return repos.MFA.Delete(context.Background(), 1)
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_GivesUpAfterMaxRetries(t *testing.T) {
+	uow, mock := newTestUnitOfWork(t)
+
+	deadlock := &pq.Error{Code: "40P01"}
+	for i := 0; i <= DefaultUnitOfWorkConfig().MaxRetries; i++ {
+		mock.ExpectBegin()
+		mock.ExpectRollback()
+	}
+
+	attempts := 0
+	err := uow.Do(context.Background(), func(repos *Repositories) error {
+		attempts++
+		return deadlock
+	})
+
+	assert.ErrorIs(t, err, deadlock)
+	assert.Equal(t, DefaultUnitOfWorkConfig().MaxRetries+1, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWork_DoesNotRetryOtherErrors(t *testing.T) {
+	uow, mock := newTestUnitOfWork(t)
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	attempts := 0
+	err := uow.Do(context.Background(), func(repos *Repositories) error {
+		attempts++
+		return &pq.Error{Code: "23505"} // unique_violation
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// logWriter は log の出力ごとに fn を呼ぶ
+type logWriter func(p []byte)
+
+func (w logWriter) Write(p []byte) (int, error) {
+	w(p)
+	return len(p), nil
+}
+
+// 別の接続が書き込みロックを持っている間は SQLITE_BUSY で失敗し、ロックが解放された後の再実行で成功する
+func TestUnitOfWork_RetriesSQLiteBusy(t *testing.T) {
+	ctx := context.Background()
+	db := storagetest.NewSQLite(t)
+	// 接続を2つ（ロックを持つ接続とトランザクションを実行する接続）に限る
+	db.SetMaxIdleConns(0)
+	db.SetMaxIdleConns(2)
+	db.SetMaxOpenConns(2)
+
+	// 1つ目の接続で書き込みロックを取る（BEGIN IMMEDIATE）
+	holder, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer holder.Close()
+	lock, err := holder.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	defer lock.Rollback()
+
+	// トランザクションを実行する2つ目の接続は、ロックを待たずに失敗させる
+	conn, err := db.Conn(ctx)
+	require.NoError(t, err)
+	_, err = conn.ExecContext(ctx, "PRAGMA busy_timeout = 0")
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	// 1回目が失敗して再試行のログを出したらロックを解放する
+	var retries int
+	log.SetOutput(logWriter(func(p []byte) {
+		if strings.Contains(string(p), "Retrying transaction") {
+			if retries++; retries == 1 {
+				assert.Contains(t, string(p), "database is locked")
+				assert.NoError(t, lock.Rollback())
+			}
+		}
+	}))
+	defer log.SetOutput(os.Stderr)
+
+	cfg := DefaultUnitOfWorkConfig()
+	cfg.RetryBackoff = time.Millisecond
+	attempts := 0
+	err = NewUnitOfWork(db, cfg).Do(ctx, func(repos *Repositories) error {
+		attempts++
+		_, err := repos.Todos.Create(ctx, "Todo", "", nil)
+		return err
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, retries)
+	// 1回目は BEGIN で失敗するため fn は1回だけ実行される
+	assert.Equal(t, 1, attempts)
+
+	todos, err := NewTodoRepository(db).FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, todos, 1)
+}
@@ -4,41 +4,65 @@ package repository
 
 import (
 	"backend/internal/model"
+	"context"
 	"database/sql"
+	"strconv"
+	"strings"
 )
 
 type UserRepository interface {
-	FindByUsername(username string) (*model.User, error)
-	FindByEmail(email string) (*model.User, error)
-	FindByID(id int) (*model.User, error)
-	Create(username, email, passwordHash string) (*model.User, error)
+	FindByUsername(ctx context.Context, username string) (*model.User, error)
+	FindByEmail(ctx context.Context, email string) (*model.User, error)
+	FindByID(ctx context.Context, id int) (*model.User, error)
+	Create(ctx context.Context, username, email, passwordHash string) (*model.User, error)
+	Search(ctx context.Context, req *model.UserSearchRequest) ([]model.User, error)
+	SetActive(ctx context.Context, id int, active bool) (int, error)
+	SetRole(ctx context.Context, id int, role string) (int, error)
+	RevokeTokens(ctx context.Context, id int) (int, error)
 }
 
 type userRepository struct {
-	db *sql.DB
+	db DBTX
 }
 
-func NewUserRepository(db *sql.DB) UserRepository {
+func NewUserRepository(db DBTX) UserRepository {
 	return &userRepository{db: db}
 }
 
-func (r *userRepository) FindByUsername(username string) (*model.User, error) {
+const userColumns = "id, username, email, password_hash, external_id, provider, role, token_version, is_active, created_at, updated_at"
+
+// rowScanner は *sql.Row と *sql.Rows の共通インターフェース
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanUser(row rowScanner) (*model.User, error) {
 	user := &model.User{}
-	err := r.db.QueryRow(`
-		SELECT id, username, email, password_hash, external_id, provider, is_active, created_at, updated_at
-		FROM users
-		WHERE username = $1 AND is_active = true
-	`, username).Scan(
+	err := row.Scan(
 		&user.ID,
 		&user.Username,
 		&user.Email,
 		&user.PasswordHash,
 		&user.ExternalID,
 		&user.Provider,
+		&user.Role,
+		&user.TokenVersion,
 		&user.IsActive,
 		&user.CreatedAt,
 		&user.UpdatedAt,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *userRepository) FindByUsername(ctx context.Context, username string) (*model.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE username = $1 AND is_active = true
+	`, username))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -50,23 +74,12 @@ func (r *userRepository) FindByUsername(username string) (*model.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) FindByEmail(email string) (*model.User, error) {
-	user := &model.User{}
-	err := r.db.QueryRow(`
-		SELECT id, username, email, password_hash, external_id, provider, is_active, created_at, updated_at
+func (r *userRepository) FindByEmail(ctx context.Context, email string) (*model.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
 		FROM users
 		WHERE email = $1 AND is_active = true
-	`, email).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.ExternalID,
-		&user.Provider,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	`, email))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -78,23 +91,12 @@ func (r *userRepository) FindByEmail(email string) (*model.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) FindByID(id int) (*model.User, error) {
-	user := &model.User{}
-	err := r.db.QueryRow(`
-		SELECT id, username, email, password_hash, external_id, provider, is_active, created_at, updated_at
+func (r *userRepository) FindByID(ctx context.Context, id int) (*model.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, `
+		SELECT `+userColumns+`
 		FROM users
 		WHERE id = $1 AND is_active = true
-	`, id).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.ExternalID,
-		&user.Provider,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+	`, id))
 
 	if err == sql.ErrNoRows {
 		return nil, nil
@@ -106,27 +108,119 @@ func (r *userRepository) FindByID(id int) (*model.User, error) {
 	return user, nil
 }
 
-func (r *userRepository) Create(username, email, passwordHash string) (*model.User, error) {
-	user := &model.User{}
-	err := r.db.QueryRow(`
+// Create はユーザーを作成する。ユーザー名・メールアドレスが重複していれば ErrUsernameTaken / ErrEmailTaken
+func (r *userRepository) Create(ctx context.Context, username, email, passwordHash string) (*model.User, error) {
+	user, err := scanUser(r.db.QueryRowContext(ctx, `
 		INSERT INTO users (username, email, password_hash)
 		VALUES ($1, $2, $3)
-		RETURNING id, username, email, password_hash, external_id, provider, is_active, created_at, updated_at
-	`, username, email, passwordHash).Scan(
-		&user.ID,
-		&user.Username,
-		&user.Email,
-		&user.PasswordHash,
-		&user.ExternalID,
-		&user.Provider,
-		&user.IsActive,
-		&user.CreatedAt,
-		&user.UpdatedAt,
-	)
+		RETURNING `+userColumns+`
+	`, username, email, passwordHash))
+	if err != nil {
+		if constraint, ok := uniqueViolation(err); ok {
+			if strings.Contains(constraint, "email") {
+				return nil, ErrEmailTaken.WithCause(err)
+			}
+			return nil, ErrUsernameTaken.WithCause(err)
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// Search は管理者向けのユーザー検索（無効化されたユーザーも含む）
+func (r *userRepository) Search(ctx context.Context, req *model.UserSearchRequest) ([]model.User, error) {
+	query := "SELECT " + userColumns + " FROM users WHERE 1 = 1"
+	args := []interface{}{}
+	paramCount := 1
+
+	// ユーザー名・メールアドレスで部分一致検索
+	if req.Query != nil {
+		query += " AND (username ILIKE $" + strconv.Itoa(paramCount) + " OR email ILIKE $" + strconv.Itoa(paramCount) + ")"
+		args = append(args, "%"+*req.Query+"%")
+		paramCount++
+	}
+
+	// 有効・無効でフィルタ
+	if req.IsActive != nil {
+		query += " AND is_active = $" + strconv.Itoa(paramCount)
+		args = append(args, *req.IsActive)
+		paramCount++
+	}
 
+	// ロールでフィルタ
+	if req.Role != nil {
+		query += " AND role = $" + strconv.Itoa(paramCount)
+		args = append(args, *req.Role)
+		paramCount++
+	}
+
+	query += " ORDER BY id LIMIT $" + strconv.Itoa(paramCount) + " OFFSET $" + strconv.Itoa(paramCount+1)
+	args = append(args, req.Limit, req.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
+
+	users := []model.User{}
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
 
-	return user, nil
+	return users, nil
+}
+
+// userRowsAffected は更新件数を返す。対象のユーザーがいなければ ErrUserNotFound
+func userRowsAffected(result sql.Result) (int, error) {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	if rowsAffected == 0 {
+		return 0, ErrUserNotFound
+	}
+	return int(rowsAffected), nil
+}
+
+// SetActive はユーザーを有効化・無効化する。無効化時は発行済みトークンも失効させる
+func (r *userRepository) SetActive(ctx context.Context, id int, active bool) (int, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE users
+		SET is_active = $1,
+			token_version = CASE WHEN $1 THEN token_version ELSE token_version + 1 END,
+			updated_at = NOW()
+		WHERE id = $2
+	`, active, id)
+	if err != nil {
+		return 0, err
+	}
+	return userRowsAffected(result)
+}
+
+func (r *userRepository) SetRole(ctx context.Context, id int, role string) (int, error) {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE users SET role = $1, updated_at = NOW() WHERE id = $2",
+		role, id,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return userRowsAffected(result)
+}
+
+// RevokeTokens はトークンバージョンを上げて、発行済みのトークンをすべて失効させる
+func (r *userRepository) RevokeTokens(ctx context.Context, id int) (int, error) {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE users SET token_version = token_version + 1, updated_at = NOW() WHERE id = $1",
+		id,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return userRowsAffected(result)
 }
@@ -0,0 +1,164 @@
+package repository
+
+//go:generate mockgen -source=workspace_repository.go -destination=mock/mock_workspace_repository.go -package=mock
+
+import (
+	"backend/internal/model"
+	"context"
+	"database/sql"
+)
+
+type WorkspaceRepository interface {
+	FindByUserID(ctx context.Context, userID int) ([]model.Workspace, error)
+	Create(ctx context.Context, name string, ownerID int) (*model.Workspace, error)
+	GetMemberRole(ctx context.Context, workspaceID, userID int) (string, error)
+	// AddMember はメンバーを追加する。既にメンバーなら ErrMemberExists（ロールは変更しない）
+	AddMember(ctx context.Context, workspaceID, userID int, role string) error
+	// UpdateMemberRole はメンバーのロールを変更する。メンバーでなければ ErrMemberNotFound、オーナーなら ErrOwnerRoleImmutable
+	UpdateMemberRole(ctx context.Context, workspaceID, userID int, role string) error
+	SetMFARequired(ctx context.Context, workspaceID int, required bool) error
+	IsMFARequiredForUser(ctx context.Context, userID int) (bool, error)
+}
+
+type workspaceRepository struct {
+	db DBTX
+}
+
+func NewWorkspaceRepository(db DBTX) WorkspaceRepository {
+	return &workspaceRepository{db: db}
+}
+
+func (r *workspaceRepository) FindByUserID(ctx context.Context, userID int) ([]model.Workspace, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT w.id, w.name, w.mfa_required, m.role, w.created_at, w.updated_at
+		FROM workspaces w
+		JOIN workspace_members m ON m.workspace_id = w.id
+		WHERE m.user_id = $1
+		ORDER BY w.created_at
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	workspaces := []model.Workspace{}
+	for rows.Next() {
+		var w model.Workspace
+		if err := rows.Scan(&w.ID, &w.Name, &w.MFARequired, &w.Role, &w.CreatedAt, &w.UpdatedAt); err != nil {
+			return nil, err
+		}
+		workspaces = append(workspaces, w)
+	}
+
+	return workspaces, nil
+}
+
+// Create はワークスペースを作成し、作成者をオーナーとして登録する
+func (r *workspaceRepository) Create(ctx context.Context, name string, ownerID int) (*model.Workspace, error) {
+	w := &model.Workspace{Name: name, Role: model.WorkspaceRoleOwner}
+	err := withTx(ctx, r.db, func(tx DBTX) error {
+		err := tx.QueryRowContext(ctx,
+			"INSERT INTO workspaces (name) VALUES ($1) RETURNING id, mfa_required, created_at, updated_at",
+			name,
+		).Scan(&w.ID, &w.MFARequired, &w.CreatedAt, &w.UpdatedAt)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO workspace_members (workspace_id, user_id, role) VALUES ($1, $2, $3)",
+			w.ID, ownerID, model.WorkspaceRoleOwner,
+		)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return w, nil
+}
+
+// GetMemberRole はユーザーのロールを返す。メンバーでなければ空文字
+func (r *workspaceRepository) GetMemberRole(ctx context.Context, workspaceID, userID int) (string, error) {
+	var role string
+	err := r.db.QueryRowContext(ctx,
+		"SELECT role FROM workspace_members WHERE workspace_id = $1 AND user_id = $2",
+		workspaceID, userID,
+	).Scan(&role)
+
+	if err == sql.ErrNoRows {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (r *workspaceRepository) AddMember(ctx context.Context, workspaceID, userID int, role string) error {
+	res, err := r.db.ExecContext(ctx, `
+		INSERT INTO workspace_members (workspace_id, user_id, role)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (workspace_id, user_id) DO NOTHING
+	`, workspaceID, userID, role)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrMemberExists
+	}
+	return nil
+}
+
+// UpdateMemberRole はオーナー以外のメンバーのロールを変更する（オーナーの行は変更しない）
+func (r *workspaceRepository) UpdateMemberRole(ctx context.Context, workspaceID, userID int, role string) error {
+	res, err := r.db.ExecContext(ctx, `
+		UPDATE workspace_members SET role = $3
+		WHERE workspace_id = $1 AND user_id = $2 AND role <> $4
+	`, workspaceID, userID, role, model.WorkspaceRoleOwner)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	current, err := r.GetMemberRole(ctx, workspaceID, userID)
+	if err != nil {
+		return err
+	}
+	if current == model.WorkspaceRoleOwner {
+		return ErrOwnerRoleImmutable
+	}
+	return ErrMemberNotFound
+}
+
+func (r *workspaceRepository) SetMFARequired(ctx context.Context, workspaceID int, required bool) error {
+	_, err := r.db.ExecContext(ctx,
+		"UPDATE workspaces SET mfa_required = $1, updated_at = NOW() WHERE id = $2",
+		required, workspaceID,
+	)
+	return err
+}
+
+// IsMFARequiredForUser はユーザーがMFA必須のワークスペースに所属しているかを返す
+func (r *workspaceRepository) IsMFARequiredForUser(ctx context.Context, userID int) (bool, error) {
+	var required bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM workspaces w
+			JOIN workspace_members m ON m.workspace_id = w.id
+			WHERE m.user_id = $1 AND w.mfa_required = true
+		)
+	`, userID).Scan(&required)
+	return required, err
+}
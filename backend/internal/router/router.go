@@ -0,0 +1,170 @@
+// Package router はAPIのルーティングを組み立てる
+//
+// 現行のAPIは /api/v1 以下に登録する。移行期間中はパスに /api/v1 を付けない旧ルートも
+// 同じハンドラーで提供し、廃止予定であることを Deprecation / Sunset ヘッダーで通知する。
+package router
+
+import (
+	"backend/internal/auth"
+	"backend/internal/handler"
+	appmw "backend/internal/middleware"
+	"backend/internal/ratelimit"
+	"backend/internal/repository"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	echoSwagger "github.com/swaggo/echo-swagger"
+)
+
+// APIPrefix は現行バージョンのAPIのパス
+const APIPrefix = "/api/v1"
+
+// LegacyDeprecation は旧ルートの廃止予定
+var LegacyDeprecation = appmw.DeprecationConfig{
+	Since:           time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+	Sunset:          time.Date(2027, 4, 1, 0, 0, 0, 0, time.UTC),
+	SuccessorPrefix: APIPrefix,
+}
+
+// Handlers はルートに登録するハンドラー
+type Handlers struct {
+	Todo      handler.TodoHandlerInterface
+	TodoBulk  handler.TodoBulkHandlerInterface
+	Sprint    handler.SprintHandlerInterface
+	Auth      handler.AuthHandlerInterface
+	MFA       handler.MFAHandlerInterface
+	Workspace handler.WorkspaceHandlerInterface
+	JWKS      handler.JWKSHandlerInterface
+	Admin     handler.AdminHandlerInterface
+	GraphQL   handler.GraphQLHandlerInterface
+	Events    handler.EventsHandlerInterface
+}
+
+// Config はルーターの組み立てに必要な依存関係と設定
+type Config struct {
+	Handlers Handlers
+	// Tokens / Users は認証ミドルウェアがトークンと利用者の状態を確認するために使う
+	Tokens *auth.TokenService
+	Users  repository.UserRepository
+	// Limiter はログイン・登録のレート制限
+	Limiter *ratelimit.Limiter
+	// Idempotency は作成系のエンドポイントに適用する Idempotency-Key のミドルウェア
+	Idempotency echo.MiddlewareFunc
+	Validator   echo.Validator
+	// RequestTimeout は1リクエストあたりのDB処理のタイムアウト
+	RequestTimeout time.Duration
+	// LegacyRoutes が true なら、パスに APIPrefix を付けない旧ルートも登録する
+	LegacyRoutes bool
+	// TrustedProxies は X-Forwarded-For を信頼するリバースプロキシ（空なら接続元のIPを使う）
+	TrustedProxies []*net.IPNet
+}
+
+// New はミドルウェアとルートを登録した echo を返す
+func New(cfg Config) *echo.Echo {
+	e := echo.New()
+	// エラーはすべて application/problem+json で返す（詳細はリクエストIDとともにログに出力）
+	e.HTTPErrorHandler = appmw.ErrorHandler
+	e.Validator = cfg.Validator
+	// レート制限・ログインのバックオフ・監査ログはクライアントのIPで記録する
+	e.IPExtractor = appmw.IPExtractor(cfg.TrustedProxies)
+
+	e.Use(middleware.RequestID())
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+	// ブラウザから ETag（If-Match / If-None-Match で送り返す）と廃止予定のヘッダーを読めるようにする
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		ExposeHeaders: []string{"ETag", appmw.HeaderDeprecation, appmw.HeaderSunset, appmw.HeaderLink},
+	}))
+	// DB処理のタイムアウト（リポジトリはリクエストのコンテキストでクエリを実行する）
+	// 変更イベントの購読は接続し続けるため対象外
+	e.Use(appmw.DBTimeoutWithSkipper(cfg.RequestTimeout, isEventStream))
+
+	// バージョンに依存しないエンドポイント
+	e.GET("/.well-known/jwks.json", cfg.Handlers.JWKS.GetJWKS)
+	e.GET("/swagger/*", echoSwagger.WrapHandler)
+
+	v1 := e.Group(APIPrefix)
+	// 公開鍵はルートの /.well-known が正式な場所で、/api/v1 からも取得できる
+	v1.GET("/.well-known/jwks.json", cfg.Handlers.JWKS.GetJWKS)
+	registerAPI(v1, cfg)
+	if cfg.LegacyRoutes {
+		registerAPI(e.Group("", appmw.Deprecated(LegacyDeprecation)), cfg)
+	}
+
+	return e
+}
+
+// registerAPI は g に全てのAPIルートを登録する
+func registerAPI(g *echo.Group, cfg Config) {
+	h := cfg.Handlers
+
+	// 認証不要エンドポイント
+	g.POST("/login", h.Auth.Login, appmw.RateLimit(cfg.Limiter))
+	g.POST("/register", h.Auth.Register, appmw.RateLimit(cfg.Limiter))
+	g.POST("/login/mfa", h.MFA.VerifyLogin, appmw.RateLimit(cfg.Limiter))
+
+	// MFA登録（MFA必須ワークスペースの未登録ユーザーは登録用トークンで呼び出す）
+	mfaEnrollment := g.Group("/mfa/totp")
+	mfaEnrollment.Use(appmw.MFAEnrollmentMiddleware(cfg.Tokens, cfg.Users))
+	mfaEnrollment.POST("/enroll", h.MFA.EnrollTOTP)
+	mfaEnrollment.POST("/confirm", h.MFA.ConfirmTOTP)
+
+	// 認証必要エンドポイント
+	protected := g.Group("")
+	protected.Use(appmw.AuthMiddleware(cfg.Tokens, cfg.Users))
+
+	// todos
+	protected.GET("/todos", h.Todo.GetTodos)
+	protected.POST("/todos", h.Todo.CreateTodo, cfg.Idempotency)
+	protected.POST("/todos/search", h.Todo.SearchTodos)
+	protected.POST("/todos/bulk", h.TodoBulk.BulkTodos, cfg.Idempotency)
+	protected.PUT("/todos/:id", h.Todo.UpdateTodo)
+	protected.DELETE("/todos/:id", h.Todo.DeleteTodo)
+
+	// sprints
+	protected.GET("/sprints", h.Sprint.GetSprints)
+	protected.POST("/sprints", h.Sprint.CreateSprint, cfg.Idempotency)
+	protected.POST("/sprints/search", h.Sprint.SearchSprints)
+	protected.PUT("/sprints/:id", h.Sprint.UpdateSprint)
+	protected.PUT("/sprints/:id/favorite", h.Sprint.UpdateFavorite)
+	protected.DELETE("/sprints/:id", h.Sprint.DeleteSprint)
+
+	// graphql
+	protected.POST("/graphql", h.GraphQL.Query)
+	protected.GET("/graphql/schema", h.GraphQL.GetSchema)
+
+	// events（購読はクエリの ticket でも認証する）
+	protected.POST("/events/tickets", h.Events.CreateTicket)
+	events := g.Group("/events", appmw.EventStreamAuthMiddleware(cfg.Tokens, cfg.Users))
+	events.GET("", h.Events.StreamEvents)
+	events.GET("/ws", h.Events.StreamEventsWebSocket)
+
+	// mfa
+	protected.DELETE("/mfa/totp", h.MFA.DisableTOTP)
+	protected.POST("/mfa/recovery-codes", h.MFA.RegenerateRecoveryCodes)
+
+	// workspaces
+	protected.GET("/workspaces", h.Workspace.GetWorkspaces)
+	protected.POST("/workspaces", h.Workspace.CreateWorkspace)
+	protected.POST("/workspaces/:id/members", h.Workspace.AddMember)
+	protected.PUT("/workspaces/:id/members/:username/role", h.Workspace.UpdateMemberRole)
+	protected.PUT("/workspaces/:id/mfa", h.Workspace.UpdateMFARequirement)
+
+	// admin（サーバー管理者のみ）
+	admin := protected.Group("/admin")
+	admin.POST("/users/search", h.Admin.SearchUsers, appmw.RequirePermissions(auth.PermissionUsersRead))
+	admin.PUT("/users/:id/deactivate", h.Admin.DeactivateUser, appmw.RequirePermissions(auth.PermissionUsersWrite))
+	admin.PUT("/users/:id/reactivate", h.Admin.ReactivateUser, appmw.RequirePermissions(auth.PermissionUsersWrite))
+	admin.PUT("/users/:id/role", h.Admin.UpdateUserRole, appmw.RequirePermissions(auth.PermissionUsersWrite))
+	admin.POST("/users/:id/logout", h.Admin.ForceLogout, appmw.RequirePermissions(auth.PermissionUsersWrite))
+	admin.DELETE("/users/:id/mfa", h.Admin.ResetMFA, appmw.RequirePermissions(auth.PermissionUsersWrite))
+	admin.GET("/stats", h.Admin.GetStats, appmw.RequirePermissions(auth.PermissionStatsRead))
+}
+
+// isEventStream は変更イベントの購読（SSE / WebSocket）のルートかどうか
+func isEventStream(c echo.Context) bool {
+	return strings.HasSuffix(c.Path(), "/events") || strings.HasSuffix(c.Path(), "/events/ws")
+}
@@ -0,0 +1,248 @@
+package router
+
+import (
+	"backend/internal/auth"
+	"backend/internal/changefeed"
+	"backend/internal/graphqlapi"
+	"backend/internal/handler"
+	"backend/internal/idempotency"
+	appmw "backend/internal/middleware"
+	"backend/internal/ratelimit"
+	"backend/internal/repository/memory"
+	"backend/internal/validation"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRouter は main と同じ構成のルーターを、インメモリのリポジトリで組み立てる
+// configure で Config を変更できる
+func newTestRouter(t *testing.T, legacyRoutes bool, configure ...func(*Config)) (*echo.Echo, string) {
+	repos := memory.NewRepositories()
+	keys, err := auth.NewKeyManager(auth.Config{DevMode: true})
+	require.NoError(t, err)
+	tokens := auth.NewTokenService(keys)
+	limiter := ratelimit.NewLimiter(ratelimit.NewMemoryStore(), ratelimit.DefaultConfig())
+
+	validator := validation.NewValidator(validation.DefaultPasswordPolicy())
+	validator.RegisterExists("sprint", repos.Sprints.Exists)
+	graphqlAPI := graphqlapi.New(repos.Todos, repos.Sprints, repos.Users, validator)
+
+	cfg := Config{
+		Handlers: Handlers{
+			Todo:      handler.NewTodoHandler(repos.Todos),
+			TodoBulk:  handler.NewTodoBulkHandler(memory.NewUnitOfWork(repos)),
+			Sprint:    handler.NewSprintHandler(repos.Sprints),
+			Auth:      handler.NewAuthHandler(repos.Users, repos.MFA, repos.Workspaces, repos.AuthAudit, limiter, tokens),
+			MFA:       handler.NewMFAHandler(repos.Users, repos.MFA, repos.Workspaces, repos.AuthAudit, limiter, tokens),
+			Workspace: handler.NewWorkspaceHandler(repos.Workspaces, repos.Users, repos.MFA),
+			JWKS:      handler.NewJWKSHandler(keys),
+			Admin:     handler.NewAdminHandler(repos.Users, repos.Stats, memory.NewUnitOfWork(repos)),
+			GraphQL:   handler.NewGraphQLHandler(graphqlAPI, graphqlapi.Limits{MaxDepth: 10, MaxComplexity: 1000}),
+			Events:    handler.NewEventsHandler(changefeed.NewHub(0, 0), repos.Users, tokens),
+		},
+		Tokens:         tokens,
+		Users:          repos.Users,
+		Limiter:        limiter,
+		Idempotency:    appmw.Idempotency(idempotency.NewMemoryStore(), time.Hour, APIPrefix),
+		Validator:      validator,
+		RequestTimeout: time.Second,
+		LegacyRoutes:   legacyRoutes,
+	}
+	for _, f := range configure {
+		f(&cfg)
+	}
+	e := New(cfg)
+
+	user, err := repos.Users.Create(context.Background(), "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+	token, err := tokens.GenerateJWT(user)
+	require.NoError(t, err)
+	return e, token
+}
+
+func do(e *echo.Echo, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRouter_V1(t *testing.T) {
+	e, token := newTestRouter(t, true)
+
+	rec := do(e, http.MethodPost, "/api/v1/todos", token, `{"title":"Versioned"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Empty(t, rec.Header().Get(appmw.HeaderDeprecation))
+
+	rec = do(e, http.MethodGet, "/api/v1/todos", token, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Versioned")
+
+	assert.Equal(t, http.StatusUnauthorized, do(e, http.MethodGet, "/api/v1/todos", "", "").Code)
+	assert.Equal(t, http.StatusUnprocessableEntity, do(e, http.MethodPost, "/api/v1/register", "", `{}`).Code)
+
+	// 公開鍵はルートと /api/v1 の両方から取得でき、廃止予定ではない
+	for _, path := range []string{"/.well-known/jwks.json", "/api/v1/.well-known/jwks.json"} {
+		rec = do(e, http.MethodGet, path, "", "")
+		assert.Equal(t, http.StatusOK, rec.Code, path)
+		assert.Empty(t, rec.Header().Get(appmw.HeaderDeprecation), path)
+	}
+}
+
+// doFrom は X-Forwarded-For を付けて remoteAddr から呼び出す
+func doFrom(e *echo.Echo, remoteAddr, forwardedFor, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+	req.RemoteAddr = remoteAddr
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+// クライアントが送った X-Forwarded-For を変えても、IPごとのリクエスト数の上限はリセットされない
+func TestRouter_RateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	e, _ := newTestRouter(t, false)
+	limit := ratelimit.DefaultConfig().RequestLimit
+
+	for i := 0; i < limit; i++ {
+		rec := doFrom(e, "203.0.113.7:1234", fmt.Sprintf("198.51.100.%d", i), "/api/v1/register", `{}`)
+		require.NotEqual(t, http.StatusTooManyRequests, rec.Code, i)
+	}
+	rec := doFrom(e, "203.0.113.7:1234", "198.51.100.250", "/api/v1/register", `{}`)
+	assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+
+	// 他の接続元は制限されない
+	rec = doFrom(e, "203.0.113.8:1234", "198.51.100.0", "/api/v1/register", `{}`)
+	assert.NotEqual(t, http.StatusTooManyRequests, rec.Code)
+}
+
+// 信頼するプロキシから届いた場合は X-Forwarded-For のクライアントごとに数える
+func TestRouter_RateLimitTrustedProxy(t *testing.T) {
+	_, proxy, err := net.ParseCIDR("10.0.0.0/24")
+	require.NoError(t, err)
+	e, _ := newTestRouter(t, false, func(cfg *Config) { cfg.TrustedProxies = []*net.IPNet{proxy} })
+	limit := ratelimit.DefaultConfig().RequestLimit
+
+	for i := 0; i < limit; i++ {
+		doFrom(e, "10.0.0.2:1234", "198.51.100.1", "/api/v1/register", `{}`)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, doFrom(e, "10.0.0.2:1234", "198.51.100.1", "/api/v1/register", `{}`).Code)
+	assert.NotEqual(t, http.StatusTooManyRequests, doFrom(e, "10.0.0.2:1234", "198.51.100.2", "/api/v1/register", `{}`).Code)
+}
+
+func TestRouter_GraphQL(t *testing.T) {
+	e, token := newTestRouter(t, false)
+
+	rec := do(e, http.MethodPost, "/api/v1/graphql", token, `{"query":"mutation { createTodo(title: \"GraphQL\") { id } }"}`)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"data":{"createTodo":{"id":1}}}`, rec.Body.String())
+
+	rec = do(e, http.MethodGet, "/api/v1/todos", token, "")
+	assert.Contains(t, rec.Body.String(), "GraphQL")
+
+	assert.Equal(t, http.StatusUnauthorized, do(e, http.MethodPost, "/api/v1/graphql", "", `{"query":"{ me { id } }"}`).Code)
+	assert.Equal(t, http.StatusOK, do(e, http.MethodGet, "/api/v1/graphql/schema", token, "").Code)
+}
+
+func TestRouter_Events(t *testing.T) {
+	e, token := newTestRouter(t, false)
+
+	rec := do(e, http.MethodPost, "/api/v1/events/tickets", token, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var res struct {
+		Ticket string `json:"ticket"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+
+	// クエリではチケットだけを受け付ける（アクセストークンは URL に含めない）
+	assert.Equal(t, http.StatusUnauthorized, do(e, http.MethodGet, "/api/v1/events?ticket="+token, "", "").Code)
+	// チケットは購読以外には使えない
+	assert.Equal(t, http.StatusUnauthorized, do(e, http.MethodGet, "/api/v1/todos", res.Ticket, "").Code)
+
+	// 購読にはDB処理のタイムアウトを適用しない
+	ctx, cancel := context.WithCancel(context.Background())
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events?ticket="+res.Ticket, nil).WithContext(ctx)
+	rec = httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		e.ServeHTTP(rec, req)
+	}()
+	select {
+	case <-done:
+		t.Fatal("stream ended before the client disconnected")
+	case <-time.After(1500 * time.Millisecond):
+	}
+	cancel()
+	<-done
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, ": connected\n\n", rec.Body.String())
+}
+
+func TestRouter_LegacyRoutes(t *testing.T) {
+	e, token := newTestRouter(t, true)
+
+	// 旧ルートは同じハンドラーで動作し、廃止予定のヘッダーを返す
+	rec := do(e, http.MethodPost, "/todos", token, `{"title":"Legacy"}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+
+	rec = do(e, http.MethodGet, "/todos", token, "")
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Legacy")
+	assert.Equal(t, "@1792368000", rec.Header().Get(appmw.HeaderDeprecation))
+	assert.Equal(t, "Thu, 01 Apr 2027 00:00:00 GMT", rec.Header().Get(appmw.HeaderSunset))
+	assert.Equal(t, `</api/v1/todos>; rel="successor-version"`, rec.Header().Get(appmw.HeaderLink))
+
+	// エラーのレスポンスにも付ける
+	rec = do(e, http.MethodGet, "/todos", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.NotEmpty(t, rec.Header().Get(appmw.HeaderDeprecation))
+}
+
+func TestRouter_LegacyRoutesDisabled(t *testing.T) {
+	e, token := newTestRouter(t, false)
+
+	assert.Equal(t, http.StatusNotFound, do(e, http.MethodGet, "/todos", token, "").Code)
+	assert.Equal(t, http.StatusOK, do(e, http.MethodGet, "/api/v1/todos", token, "").Code)
+}
+
+// 旧ルートと /api/v1 には同じルートが登録されている
+func TestRouter_LegacyRoutesMatchV1(t *testing.T) {
+	e, _ := newTestRouter(t, true)
+
+	routes := map[string]bool{}
+	for _, r := range e.Routes() {
+		if r.Method == echo.RouteNotFound {
+			continue
+		}
+		routes[r.Method+" "+r.Path] = true
+	}
+
+	var v1 int
+	for route := range routes {
+		method, path, _ := strings.Cut(route, " ")
+		if !strings.HasPrefix(path, APIPrefix+"/") || path == APIPrefix+"/.well-known/jwks.json" {
+			continue
+		}
+		v1++
+		legacy := method + " " + strings.TrimPrefix(path, APIPrefix)
+		assert.True(t, routes[legacy], "missing legacy route %s", legacy)
+	}
+	assert.Greater(t, v1, 20)
+}
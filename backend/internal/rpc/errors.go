@@ -0,0 +1,135 @@
+package rpc
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/middleware"
+	"backend/internal/model"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+)
+
+// errorDomain は ErrorInfo の domain
+const errorDomain = "retrotodo"
+
+// errorWriter はハンドラーの外（認証・ルーティング）のエラーを、リクエストのプロトコル（Connect / gRPC / gRPC-Web）で書き込む
+var errorWriter = connect.NewErrorWriter()
+
+// ErrorHandler は echo のミドルウェアとルーティングのエラーを Connect のエラーとして返す
+// サービスが返したエラーは errorInterceptor が同じ変換をする
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+	if wErr := errorWriter.Write(c.Response(), c.Request(), toError(c, err)); wErr != nil {
+		c.Logger().Error(wErr)
+	}
+}
+
+// errorInterceptor はサービスが返したエラーを Connect のエラーにする
+type errorInterceptor struct{}
+
+func (errorInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		res, err := next(ctx, req)
+		if err != nil {
+			return nil, toError(echoContextFrom(ctx), err)
+		}
+		return res, nil
+	}
+}
+
+func (errorInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+func (errorInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return func(ctx context.Context, conn connect.StreamingHandlerConn) error {
+		if err := next(ctx, conn); err != nil {
+			return toError(echoContextFrom(ctx), err)
+		}
+		return nil
+	}
+}
+
+// toError はエラーを Connect のエラーにする
+// apperror などは REST と同じ変換（middleware.ProblemFor）を使い、REST のエラーコードを ErrorInfo の reason で返す
+func toError(c echo.Context, err error) *connect.Error {
+	var e *connect.Error
+	if errors.As(err, &e) {
+		return e
+	}
+	if c == nil {
+		log.Printf("[RPC] unexpected error outside of a request: %v", err)
+		return connect.NewError(connect.CodeInternal, errors.New("internal server error"))
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) && (httpErr.Code == http.StatusNotFound || httpErr.Code == http.StatusMethodNotAllowed) {
+		return connect.NewError(connect.CodeUnimplemented, errors.New("unknown procedure "+c.Request().URL.Path))
+	}
+
+	problem := middleware.ProblemFor(c, err)
+	e = connect.NewError(codeFor(problem), errors.New(problem.Detail))
+	addDetail(e, &errdetails.ErrorInfo{Reason: problem.Code, Domain: errorDomain})
+	if len(problem.Errors) > 0 {
+		addDetail(e, badRequest(problem.Errors))
+	}
+	return e
+}
+
+// codeFor は REST のエラーレスポンスに対応する Connect のエラーコード
+func codeFor(problem *model.Problem) connect.Code {
+	switch {
+	case problem.Code == apperror.CodeRequestCanceled:
+		return connect.CodeCanceled
+	case problem.Status == http.StatusBadRequest, problem.Status == http.StatusUnprocessableEntity:
+		return connect.CodeInvalidArgument
+	case problem.Status == http.StatusUnauthorized:
+		return connect.CodeUnauthenticated
+	case problem.Status == http.StatusForbidden:
+		return connect.CodePermissionDenied
+	case problem.Status == http.StatusNotFound:
+		return connect.CodeNotFound
+	case problem.Status == http.StatusConflict:
+		return connect.CodeAlreadyExists
+	case problem.Status == http.StatusPreconditionFailed:
+		// バージョンの不一致（test-and-set の失敗）は読み直して再試行する
+		return connect.CodeAborted
+	case problem.Status == http.StatusRequestEntityTooLarge, problem.Status == http.StatusTooManyRequests:
+		return connect.CodeResourceExhausted
+	case problem.Status == http.StatusGatewayTimeout:
+		return connect.CodeDeadlineExceeded
+	case problem.Status == http.StatusServiceUnavailable:
+		return connect.CodeUnavailable
+	default:
+		return connect.CodeInternal
+	}
+}
+
+// badRequest は入力検証に失敗したフィールド（reason は REST のフィールドのエラーコード）
+func badRequest(fields []model.FieldError) *errdetails.BadRequest {
+	br := &errdetails.BadRequest{}
+	for _, f := range fields {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       f.Field,
+			Description: f.Message,
+			Reason:      f.Code,
+		})
+	}
+	return br
+}
+
+func addDetail(e *connect.Error, msg proto.Message) {
+	detail, err := connect.NewErrorDetail(msg)
+	if err != nil {
+		log.Printf("[RPC] failed to encode error detail: %v", err)
+		return
+	}
+	e.AddDetail(detail)
+}
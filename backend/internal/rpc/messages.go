@@ -0,0 +1,97 @@
+package rpc
+
+import (
+	"backend/internal/changefeed"
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	"backend/internal/model"
+	"backend/internal/types"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// model から proto/retrotodo/v1 のメッセージへの変換
+
+func todoMessage(t *model.Todo) *retrotodov1.Todo {
+	return &retrotodov1.Todo{
+		Id:          int32(t.ID),
+		Title:       t.Title,
+		Description: t.Description,
+		Completed:   t.Completed,
+		SprintId:    int32Ptr(t.SprintID),
+		Version:     int32(t.Version),
+		CreatedAt:   timestamp(t.CreatedAt),
+		UpdatedAt:   timestamp(t.UpdatedAt),
+	}
+}
+
+func todoMessages(todos []model.Todo) []*retrotodov1.Todo {
+	messages := make([]*retrotodov1.Todo, 0, len(todos))
+	for i := range todos {
+		messages = append(messages, todoMessage(&todos[i]))
+	}
+	return messages
+}
+
+func sprintMessage(s *model.Sprint) *retrotodov1.Sprint {
+	return &retrotodov1.Sprint{
+		Id:         int32(s.ID),
+		Name:       s.Name,
+		Color:      s.Color,
+		IsFavorite: s.IsFavorite,
+		Version:    int32(s.Version),
+		CreatedAt:  timestamp(s.CreatedAt),
+		UpdatedAt:  timestamp(s.UpdatedAt),
+	}
+}
+
+func sprintMessages(sprints []model.Sprint) []*retrotodov1.Sprint {
+	messages := make([]*retrotodov1.Sprint, 0, len(sprints))
+	for i := range sprints {
+		messages = append(messages, sprintMessage(&sprints[i]))
+	}
+	return messages
+}
+
+func userMessage(u *model.User) *retrotodov1.User {
+	return &retrotodov1.User{
+		Id:        int32(u.ID),
+		Username:  u.Username,
+		Email:     u.Email,
+		Role:      u.Role,
+		IsActive:  u.IsActive,
+		CreatedAt: timestamp(u.CreatedAt),
+	}
+}
+
+func timestamp(t types.CustomTime) *timestamppb.Timestamp {
+	return timestamppb.New(time.Time(t))
+}
+
+// changeType は変更イベントの種類を ChangeType にする
+func changeType(action string) retrotodov1.ChangeType {
+	switch action {
+	case changefeed.ActionCreated:
+		return retrotodov1.ChangeType_CHANGE_TYPE_CREATED
+	case changefeed.ActionDeleted:
+		return retrotodov1.ChangeType_CHANGE_TYPE_DELETED
+	default:
+		return retrotodov1.ChangeType_CHANGE_TYPE_UPDATED
+	}
+}
+
+func int32Ptr(v *int) *int32 {
+	if v == nil {
+		return nil
+	}
+	i := int32(*v)
+	return &i
+}
+
+func intPtr(v *int32) *int {
+	if v == nil {
+		return nil
+	}
+	i := int(*v)
+	return &i
+}
@@ -0,0 +1,276 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../gen/retrotodo/v1/retrotodov1connect/sprint.connect.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../gen/retrotodo/v1/retrotodov1connect/sprint.connect.go -destination=mock/mock_sprint_service.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	context "context"
+	reflect "reflect"
+
+	connect "connectrpc.com/connect"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSprintServiceClient is a mock of SprintServiceClient interface.
+type MockSprintServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockSprintServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockSprintServiceClientMockRecorder is the mock recorder for MockSprintServiceClient.
+type MockSprintServiceClientMockRecorder struct {
+	mock *MockSprintServiceClient
+}
+
+// NewMockSprintServiceClient creates a new mock instance.
+func NewMockSprintServiceClient(ctrl *gomock.Controller) *MockSprintServiceClient {
+	mock := &MockSprintServiceClient{ctrl: ctrl}
+	mock.recorder = &MockSprintServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSprintServiceClient) EXPECT() *MockSprintServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateSprint mocks base method.
+func (m *MockSprintServiceClient) CreateSprint(arg0 context.Context, arg1 *connect.Request[retrotodov1.CreateSprintRequest]) (*connect.Response[retrotodov1.CreateSprintResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSprint", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.CreateSprintResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSprint indicates an expected call of CreateSprint.
+func (mr *MockSprintServiceClientMockRecorder) CreateSprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSprint", reflect.TypeOf((*MockSprintServiceClient)(nil).CreateSprint), arg0, arg1)
+}
+
+// DeleteSprint mocks base method.
+func (m *MockSprintServiceClient) DeleteSprint(arg0 context.Context, arg1 *connect.Request[retrotodov1.DeleteSprintRequest]) (*connect.Response[retrotodov1.DeleteSprintResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSprint", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.DeleteSprintResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSprint indicates an expected call of DeleteSprint.
+func (mr *MockSprintServiceClientMockRecorder) DeleteSprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSprint", reflect.TypeOf((*MockSprintServiceClient)(nil).DeleteSprint), arg0, arg1)
+}
+
+// ListSprints mocks base method.
+func (m *MockSprintServiceClient) ListSprints(arg0 context.Context, arg1 *connect.Request[retrotodov1.ListSprintsRequest]) (*connect.Response[retrotodov1.ListSprintsResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSprints", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.ListSprintsResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSprints indicates an expected call of ListSprints.
+func (mr *MockSprintServiceClientMockRecorder) ListSprints(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSprints", reflect.TypeOf((*MockSprintServiceClient)(nil).ListSprints), arg0, arg1)
+}
+
+// SearchSprints mocks base method.
+func (m *MockSprintServiceClient) SearchSprints(arg0 context.Context, arg1 *connect.Request[retrotodov1.SearchSprintsRequest]) (*connect.Response[retrotodov1.SearchSprintsResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSprints", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.SearchSprintsResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSprints indicates an expected call of SearchSprints.
+func (mr *MockSprintServiceClientMockRecorder) SearchSprints(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSprints", reflect.TypeOf((*MockSprintServiceClient)(nil).SearchSprints), arg0, arg1)
+}
+
+// UpdateFavorite mocks base method.
+func (m *MockSprintServiceClient) UpdateFavorite(arg0 context.Context, arg1 *connect.Request[retrotodov1.UpdateFavoriteRequest]) (*connect.Response[retrotodov1.UpdateFavoriteResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFavorite", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.UpdateFavoriteResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFavorite indicates an expected call of UpdateFavorite.
+func (mr *MockSprintServiceClientMockRecorder) UpdateFavorite(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorite", reflect.TypeOf((*MockSprintServiceClient)(nil).UpdateFavorite), arg0, arg1)
+}
+
+// UpdateSprint mocks base method.
+func (m *MockSprintServiceClient) UpdateSprint(arg0 context.Context, arg1 *connect.Request[retrotodov1.UpdateSprintRequest]) (*connect.Response[retrotodov1.UpdateSprintResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSprint", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.UpdateSprintResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSprint indicates an expected call of UpdateSprint.
+func (mr *MockSprintServiceClientMockRecorder) UpdateSprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSprint", reflect.TypeOf((*MockSprintServiceClient)(nil).UpdateSprint), arg0, arg1)
+}
+
+// WatchSprints mocks base method.
+func (m *MockSprintServiceClient) WatchSprints(arg0 context.Context, arg1 *connect.Request[retrotodov1.WatchSprintsRequest]) (*connect.ServerStreamForClient[retrotodov1.WatchSprintsResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchSprints", arg0, arg1)
+	ret0, _ := ret[0].(*connect.ServerStreamForClient[retrotodov1.WatchSprintsResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchSprints indicates an expected call of WatchSprints.
+func (mr *MockSprintServiceClientMockRecorder) WatchSprints(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchSprints", reflect.TypeOf((*MockSprintServiceClient)(nil).WatchSprints), arg0, arg1)
+}
+
+// MockSprintServiceHandler is a mock of SprintServiceHandler interface.
+type MockSprintServiceHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockSprintServiceHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockSprintServiceHandlerMockRecorder is the mock recorder for MockSprintServiceHandler.
+type MockSprintServiceHandlerMockRecorder struct {
+	mock *MockSprintServiceHandler
+}
+
+// NewMockSprintServiceHandler creates a new mock instance.
+func NewMockSprintServiceHandler(ctrl *gomock.Controller) *MockSprintServiceHandler {
+	mock := &MockSprintServiceHandler{ctrl: ctrl}
+	mock.recorder = &MockSprintServiceHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSprintServiceHandler) EXPECT() *MockSprintServiceHandlerMockRecorder {
+	return m.recorder
+}
+
+// CreateSprint mocks base method.
+func (m *MockSprintServiceHandler) CreateSprint(arg0 context.Context, arg1 *connect.Request[retrotodov1.CreateSprintRequest]) (*connect.Response[retrotodov1.CreateSprintResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSprint", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.CreateSprintResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSprint indicates an expected call of CreateSprint.
+func (mr *MockSprintServiceHandlerMockRecorder) CreateSprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSprint", reflect.TypeOf((*MockSprintServiceHandler)(nil).CreateSprint), arg0, arg1)
+}
+
+// DeleteSprint mocks base method.
+func (m *MockSprintServiceHandler) DeleteSprint(arg0 context.Context, arg1 *connect.Request[retrotodov1.DeleteSprintRequest]) (*connect.Response[retrotodov1.DeleteSprintResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSprint", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.DeleteSprintResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteSprint indicates an expected call of DeleteSprint.
+func (mr *MockSprintServiceHandlerMockRecorder) DeleteSprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSprint", reflect.TypeOf((*MockSprintServiceHandler)(nil).DeleteSprint), arg0, arg1)
+}
+
+// ListSprints mocks base method.
+func (m *MockSprintServiceHandler) ListSprints(arg0 context.Context, arg1 *connect.Request[retrotodov1.ListSprintsRequest]) (*connect.Response[retrotodov1.ListSprintsResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSprints", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.ListSprintsResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSprints indicates an expected call of ListSprints.
+func (mr *MockSprintServiceHandlerMockRecorder) ListSprints(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSprints", reflect.TypeOf((*MockSprintServiceHandler)(nil).ListSprints), arg0, arg1)
+}
+
+// SearchSprints mocks base method.
+func (m *MockSprintServiceHandler) SearchSprints(arg0 context.Context, arg1 *connect.Request[retrotodov1.SearchSprintsRequest]) (*connect.Response[retrotodov1.SearchSprintsResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSprints", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.SearchSprintsResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSprints indicates an expected call of SearchSprints.
+func (mr *MockSprintServiceHandlerMockRecorder) SearchSprints(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSprints", reflect.TypeOf((*MockSprintServiceHandler)(nil).SearchSprints), arg0, arg1)
+}
+
+// UpdateFavorite mocks base method.
+func (m *MockSprintServiceHandler) UpdateFavorite(arg0 context.Context, arg1 *connect.Request[retrotodov1.UpdateFavoriteRequest]) (*connect.Response[retrotodov1.UpdateFavoriteResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateFavorite", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.UpdateFavoriteResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateFavorite indicates an expected call of UpdateFavorite.
+func (mr *MockSprintServiceHandlerMockRecorder) UpdateFavorite(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFavorite", reflect.TypeOf((*MockSprintServiceHandler)(nil).UpdateFavorite), arg0, arg1)
+}
+
+// UpdateSprint mocks base method.
+func (m *MockSprintServiceHandler) UpdateSprint(arg0 context.Context, arg1 *connect.Request[retrotodov1.UpdateSprintRequest]) (*connect.Response[retrotodov1.UpdateSprintResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSprint", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.UpdateSprintResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSprint indicates an expected call of UpdateSprint.
+func (mr *MockSprintServiceHandlerMockRecorder) UpdateSprint(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSprint", reflect.TypeOf((*MockSprintServiceHandler)(nil).UpdateSprint), arg0, arg1)
+}
+
+// WatchSprints mocks base method.
+func (m *MockSprintServiceHandler) WatchSprints(arg0 context.Context, arg1 *connect.Request[retrotodov1.WatchSprintsRequest], arg2 *connect.ServerStream[retrotodov1.WatchSprintsResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchSprints", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchSprints indicates an expected call of WatchSprints.
+func (mr *MockSprintServiceHandlerMockRecorder) WatchSprints(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchSprints", reflect.TypeOf((*MockSprintServiceHandler)(nil).WatchSprints), arg0, arg1, arg2)
+}
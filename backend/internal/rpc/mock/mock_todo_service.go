@@ -0,0 +1,246 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../gen/retrotodo/v1/retrotodov1connect/todo.connect.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../gen/retrotodo/v1/retrotodov1connect/todo.connect.go -destination=mock/mock_todo_service.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	context "context"
+	reflect "reflect"
+
+	connect "connectrpc.com/connect"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTodoServiceClient is a mock of TodoServiceClient interface.
+type MockTodoServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockTodoServiceClientMockRecorder is the mock recorder for MockTodoServiceClient.
+type MockTodoServiceClientMockRecorder struct {
+	mock *MockTodoServiceClient
+}
+
+// NewMockTodoServiceClient creates a new mock instance.
+func NewMockTodoServiceClient(ctrl *gomock.Controller) *MockTodoServiceClient {
+	mock := &MockTodoServiceClient{ctrl: ctrl}
+	mock.recorder = &MockTodoServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoServiceClient) EXPECT() *MockTodoServiceClientMockRecorder {
+	return m.recorder
+}
+
+// CreateTodo mocks base method.
+func (m *MockTodoServiceClient) CreateTodo(arg0 context.Context, arg1 *connect.Request[retrotodov1.CreateTodoRequest]) (*connect.Response[retrotodov1.CreateTodoResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTodo", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.CreateTodoResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTodo indicates an expected call of CreateTodo.
+func (mr *MockTodoServiceClientMockRecorder) CreateTodo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTodo", reflect.TypeOf((*MockTodoServiceClient)(nil).CreateTodo), arg0, arg1)
+}
+
+// DeleteTodo mocks base method.
+func (m *MockTodoServiceClient) DeleteTodo(arg0 context.Context, arg1 *connect.Request[retrotodov1.DeleteTodoRequest]) (*connect.Response[retrotodov1.DeleteTodoResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTodo", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.DeleteTodoResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTodo indicates an expected call of DeleteTodo.
+func (mr *MockTodoServiceClientMockRecorder) DeleteTodo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockTodoServiceClient)(nil).DeleteTodo), arg0, arg1)
+}
+
+// ListTodos mocks base method.
+func (m *MockTodoServiceClient) ListTodos(arg0 context.Context, arg1 *connect.Request[retrotodov1.ListTodosRequest]) (*connect.Response[retrotodov1.ListTodosResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTodos", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.ListTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTodos indicates an expected call of ListTodos.
+func (mr *MockTodoServiceClientMockRecorder) ListTodos(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTodos", reflect.TypeOf((*MockTodoServiceClient)(nil).ListTodos), arg0, arg1)
+}
+
+// SearchTodos mocks base method.
+func (m *MockTodoServiceClient) SearchTodos(arg0 context.Context, arg1 *connect.Request[retrotodov1.SearchTodosRequest]) (*connect.Response[retrotodov1.SearchTodosResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTodos", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.SearchTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTodos indicates an expected call of SearchTodos.
+func (mr *MockTodoServiceClientMockRecorder) SearchTodos(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTodos", reflect.TypeOf((*MockTodoServiceClient)(nil).SearchTodos), arg0, arg1)
+}
+
+// UpdateTodo mocks base method.
+func (m *MockTodoServiceClient) UpdateTodo(arg0 context.Context, arg1 *connect.Request[retrotodov1.UpdateTodoRequest]) (*connect.Response[retrotodov1.UpdateTodoResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTodo", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.UpdateTodoResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTodo indicates an expected call of UpdateTodo.
+func (mr *MockTodoServiceClientMockRecorder) UpdateTodo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTodo", reflect.TypeOf((*MockTodoServiceClient)(nil).UpdateTodo), arg0, arg1)
+}
+
+// WatchTodos mocks base method.
+func (m *MockTodoServiceClient) WatchTodos(arg0 context.Context, arg1 *connect.Request[retrotodov1.WatchTodosRequest]) (*connect.ServerStreamForClient[retrotodov1.WatchTodosResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchTodos", arg0, arg1)
+	ret0, _ := ret[0].(*connect.ServerStreamForClient[retrotodov1.WatchTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchTodos indicates an expected call of WatchTodos.
+func (mr *MockTodoServiceClientMockRecorder) WatchTodos(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTodos", reflect.TypeOf((*MockTodoServiceClient)(nil).WatchTodos), arg0, arg1)
+}
+
+// MockTodoServiceHandler is a mock of TodoServiceHandler interface.
+type MockTodoServiceHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoServiceHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockTodoServiceHandlerMockRecorder is the mock recorder for MockTodoServiceHandler.
+type MockTodoServiceHandlerMockRecorder struct {
+	mock *MockTodoServiceHandler
+}
+
+// NewMockTodoServiceHandler creates a new mock instance.
+func NewMockTodoServiceHandler(ctrl *gomock.Controller) *MockTodoServiceHandler {
+	mock := &MockTodoServiceHandler{ctrl: ctrl}
+	mock.recorder = &MockTodoServiceHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoServiceHandler) EXPECT() *MockTodoServiceHandlerMockRecorder {
+	return m.recorder
+}
+
+// CreateTodo mocks base method.
+func (m *MockTodoServiceHandler) CreateTodo(arg0 context.Context, arg1 *connect.Request[retrotodov1.CreateTodoRequest]) (*connect.Response[retrotodov1.CreateTodoResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTodo", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.CreateTodoResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTodo indicates an expected call of CreateTodo.
+func (mr *MockTodoServiceHandlerMockRecorder) CreateTodo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTodo", reflect.TypeOf((*MockTodoServiceHandler)(nil).CreateTodo), arg0, arg1)
+}
+
+// DeleteTodo mocks base method.
+func (m *MockTodoServiceHandler) DeleteTodo(arg0 context.Context, arg1 *connect.Request[retrotodov1.DeleteTodoRequest]) (*connect.Response[retrotodov1.DeleteTodoResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTodo", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.DeleteTodoResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTodo indicates an expected call of DeleteTodo.
+func (mr *MockTodoServiceHandlerMockRecorder) DeleteTodo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTodo", reflect.TypeOf((*MockTodoServiceHandler)(nil).DeleteTodo), arg0, arg1)
+}
+
+// ListTodos mocks base method.
+func (m *MockTodoServiceHandler) ListTodos(arg0 context.Context, arg1 *connect.Request[retrotodov1.ListTodosRequest]) (*connect.Response[retrotodov1.ListTodosResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTodos", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.ListTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTodos indicates an expected call of ListTodos.
+func (mr *MockTodoServiceHandlerMockRecorder) ListTodos(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTodos", reflect.TypeOf((*MockTodoServiceHandler)(nil).ListTodos), arg0, arg1)
+}
+
+// SearchTodos mocks base method.
+func (m *MockTodoServiceHandler) SearchTodos(arg0 context.Context, arg1 *connect.Request[retrotodov1.SearchTodosRequest]) (*connect.Response[retrotodov1.SearchTodosResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchTodos", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.SearchTodosResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchTodos indicates an expected call of SearchTodos.
+func (mr *MockTodoServiceHandlerMockRecorder) SearchTodos(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchTodos", reflect.TypeOf((*MockTodoServiceHandler)(nil).SearchTodos), arg0, arg1)
+}
+
+// UpdateTodo mocks base method.
+func (m *MockTodoServiceHandler) UpdateTodo(arg0 context.Context, arg1 *connect.Request[retrotodov1.UpdateTodoRequest]) (*connect.Response[retrotodov1.UpdateTodoResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTodo", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.UpdateTodoResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTodo indicates an expected call of UpdateTodo.
+func (mr *MockTodoServiceHandlerMockRecorder) UpdateTodo(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTodo", reflect.TypeOf((*MockTodoServiceHandler)(nil).UpdateTodo), arg0, arg1)
+}
+
+// WatchTodos mocks base method.
+func (m *MockTodoServiceHandler) WatchTodos(arg0 context.Context, arg1 *connect.Request[retrotodov1.WatchTodosRequest], arg2 *connect.ServerStream[retrotodov1.WatchTodosResponse]) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchTodos", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchTodos indicates an expected call of WatchTodos.
+func (mr *MockTodoServiceHandlerMockRecorder) WatchTodos(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTodos", reflect.TypeOf((*MockTodoServiceHandler)(nil).WatchTodos), arg0, arg1, arg2)
+}
@@ -0,0 +1,97 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../gen/retrotodo/v1/retrotodov1connect/user.connect.go
+//
+// Generated by this command:
+//
+//	mockgen -source=../gen/retrotodo/v1/retrotodov1connect/user.connect.go -destination=mock/mock_user_service.go -package=mock
+//
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	context "context"
+	reflect "reflect"
+
+	connect "connectrpc.com/connect"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockUserServiceClient is a mock of UserServiceClient interface.
+type MockUserServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceClientMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceClientMockRecorder is the mock recorder for MockUserServiceClient.
+type MockUserServiceClientMockRecorder struct {
+	mock *MockUserServiceClient
+}
+
+// NewMockUserServiceClient creates a new mock instance.
+func NewMockUserServiceClient(ctrl *gomock.Controller) *MockUserServiceClient {
+	mock := &MockUserServiceClient{ctrl: ctrl}
+	mock.recorder = &MockUserServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServiceClient) EXPECT() *MockUserServiceClientMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockUserServiceClient) GetCurrentUser(arg0 context.Context, arg1 *connect.Request[retrotodov1.GetCurrentUserRequest]) (*connect.Response[retrotodov1.GetCurrentUserResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.GetCurrentUserResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockUserServiceClientMockRecorder) GetCurrentUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockUserServiceClient)(nil).GetCurrentUser), arg0, arg1)
+}
+
+// MockUserServiceHandler is a mock of UserServiceHandler interface.
+type MockUserServiceHandler struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserServiceHandlerMockRecorder
+	isgomock struct{}
+}
+
+// MockUserServiceHandlerMockRecorder is the mock recorder for MockUserServiceHandler.
+type MockUserServiceHandlerMockRecorder struct {
+	mock *MockUserServiceHandler
+}
+
+// NewMockUserServiceHandler creates a new mock instance.
+func NewMockUserServiceHandler(ctrl *gomock.Controller) *MockUserServiceHandler {
+	mock := &MockUserServiceHandler{ctrl: ctrl}
+	mock.recorder = &MockUserServiceHandlerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserServiceHandler) EXPECT() *MockUserServiceHandlerMockRecorder {
+	return m.recorder
+}
+
+// GetCurrentUser mocks base method.
+func (m *MockUserServiceHandler) GetCurrentUser(arg0 context.Context, arg1 *connect.Request[retrotodov1.GetCurrentUserRequest]) (*connect.Response[retrotodov1.GetCurrentUserResponse], error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCurrentUser", arg0, arg1)
+	ret0, _ := ret[0].(*connect.Response[retrotodov1.GetCurrentUserResponse])
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCurrentUser indicates an expected call of GetCurrentUser.
+func (mr *MockUserServiceHandlerMockRecorder) GetCurrentUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCurrentUser", reflect.TypeOf((*MockUserServiceHandler)(nil).GetCurrentUser), arg0, arg1)
+}
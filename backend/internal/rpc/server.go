@@ -0,0 +1,234 @@
+// Package rpc は proto/retrotodo/v1 のサービスを Connect / gRPC / gRPC-Web で提供する
+//
+// REST とは別のポートで、同じリポジトリ・入力検証・JWT 認証（Authorization: Bearer）を使う。
+// メッセージとハンドラーは buf generate で internal/gen に生成する（buf.gen.yaml）。
+package rpc
+
+import (
+	"backend/internal/auth"
+	"backend/internal/changefeed"
+	"backend/internal/gen/retrotodo/v1/retrotodov1connect"
+	appmw "backend/internal/middleware"
+	"backend/internal/repository"
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Validator は入力を model の validate タグで検証する（validation.Validator）
+type Validator interface {
+	ValidateContext(ctx context.Context, i interface{}) error
+}
+
+// Config はRPCサーバーの組み立てに必要な依存関係と設定
+type Config struct {
+	Todos   repository.TodoRepository
+	Sprints repository.SprintRepository
+	// Tokens / Users は REST と同じく、トークンと利用者の状態の確認に使う
+	Tokens *auth.TokenService
+	Users  repository.UserRepository
+	// Hub は Watch* が購読する変更イベント
+	Hub       *changefeed.Hub
+	Validator Validator
+	// RequestTimeout は unary の1リクエストあたりのDB処理のタイムアウト（Watch* には適用しない）
+	RequestTimeout time.Duration
+	// TrustedProxies は X-Forwarded-For を信頼するリバースプロキシ（空なら接続元のIPを使う）
+	TrustedProxies []*net.IPNet
+}
+
+// maxMessageSize はリクエストのメッセージの上限
+const maxMessageSize = 4 << 20
+
+// NewServer は全てのサービスを登録した echo を返す（REST とは別のポートで起動する）
+// ハンドラーは buf generate で生成した retrotodov1connect のもので、Connect / gRPC / gRPC-Web と
+// Protobuf のバイナリ・JSON の両方のコーデックを受け付ける。gRPC のため TLS なしの HTTP/2（h2c）も有効にする
+func NewServer(cfg Config) *echo.Echo {
+	e := echo.New()
+	e.HideBanner = true
+	e.HTTPErrorHandler = ErrorHandler
+	e.IPExtractor = appmw.IPExtractor(cfg.TrustedProxies)
+	e.Server.Protocols = new(http.Protocols)
+	e.Server.Protocols.SetHTTP1(true)
+	e.Server.Protocols.SetUnencryptedHTTP2(true)
+
+	e.Use(middleware.RequestID())
+	e.Use(middleware.Logger())
+	e.Use(middleware.Recover())
+
+	g := e.Group("", appmw.AuthMiddleware(cfg.Tokens, cfg.Users), withContext)
+	timeout := appmw.DBTimeout(cfg.RequestTimeout)
+	opts := []connect.HandlerOption{
+		connect.WithInterceptors(errorInterceptor{}),
+		connect.WithReadMaxBytes(maxMessageSize),
+	}
+	// unary は DB のタイムアウトを適用し、Watch* は切断されるまで続ける
+	mount := func(h http.Handler, unary []string, streams ...string) {
+		for _, procedure := range unary {
+			g.POST(procedure, connectHandler(h), timeout)
+		}
+		for _, procedure := range streams {
+			g.POST(procedure, connectHandler(h))
+		}
+	}
+
+	_, todos := retrotodov1connect.NewTodoServiceHandler(NewTodoService(cfg.Todos, cfg.Validator, cfg.Hub, cfg.Users), opts...)
+	mount(todos, []string{
+		retrotodov1connect.TodoServiceListTodosProcedure,
+		retrotodov1connect.TodoServiceSearchTodosProcedure,
+		retrotodov1connect.TodoServiceCreateTodoProcedure,
+		retrotodov1connect.TodoServiceUpdateTodoProcedure,
+		retrotodov1connect.TodoServiceDeleteTodoProcedure,
+	}, retrotodov1connect.TodoServiceWatchTodosProcedure)
+
+	_, sprints := retrotodov1connect.NewSprintServiceHandler(NewSprintService(cfg.Sprints, cfg.Validator, cfg.Hub, cfg.Users), opts...)
+	mount(sprints, []string{
+		retrotodov1connect.SprintServiceListSprintsProcedure,
+		retrotodov1connect.SprintServiceSearchSprintsProcedure,
+		retrotodov1connect.SprintServiceCreateSprintProcedure,
+		retrotodov1connect.SprintServiceUpdateSprintProcedure,
+		retrotodov1connect.SprintServiceUpdateFavoriteProcedure,
+		retrotodov1connect.SprintServiceDeleteSprintProcedure,
+	}, retrotodov1connect.SprintServiceWatchSprintsProcedure)
+
+	_, users := retrotodov1connect.NewUserServiceHandler(NewUserService(cfg.Users), opts...)
+	mount(users, []string{retrotodov1connect.UserServiceGetCurrentUserProcedure})
+
+	return e
+}
+
+// connectHandler は生成したハンドラーを echo のハンドラーにする
+// echo の Response.Flush はヘッダーを送ったことを記録しないため、ストリーミングで最初に Flush したときに記録する
+func connectHandler(h http.Handler) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		h.ServeHTTP(flushWriter{c.Response()}, c.Request())
+		return nil
+	}
+}
+
+type flushWriter struct {
+	*echo.Response
+}
+
+func (w flushWriter) Flush() {
+	if !w.Committed {
+		w.WriteHeader(http.StatusOK)
+	}
+	w.Response.Flush()
+}
+
+type userIDKey struct{}
+
+type tokenVersionKey struct{}
+
+type echoContextKey struct{}
+
+// withContext は AuthMiddleware が確認したユーザーID・トークンバージョンと echo のコンテキスト（エラーの変換に使う）を
+// リクエストのコンテキストに保存する
+func withContext(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		id, _ := c.Get("user_id").(int)
+		tokenVersion, _ := c.Get("token_version").(int)
+		ctx := context.WithValue(c.Request().Context(), userIDKey{}, id)
+		ctx = context.WithValue(ctx, tokenVersionKey{}, tokenVersion)
+		ctx = context.WithValue(ctx, echoContextKey{}, c)
+		c.SetRequest(c.Request().WithContext(ctx))
+		return next(c)
+	}
+}
+
+// UserIDFrom はリクエストのユーザーIDを返す
+func UserIDFrom(ctx context.Context) int {
+	id, _ := ctx.Value(userIDKey{}).(int)
+	return id
+}
+
+func tokenVersionFrom(ctx context.Context) int {
+	v, _ := ctx.Value(tokenVersionKey{}).(int)
+	return v
+}
+
+func echoContextFrom(ctx context.Context) echo.Context {
+	c, _ := ctx.Value(echoContextKey{}).(echo.Context)
+	return c
+}
+
+// authCheckInterval は購読中のユーザーが無効化・強制ログアウトされていないかを確認する間隔（GET /events と同じ）
+// テストでは短くする
+var authCheckInterval = time.Minute
+
+// authCheckTimeout は購読中に行うユーザーの確認のタイムアウト（DBTimeout は Watch* に適用しないため）
+const authCheckTimeout = 5 * time.Second
+
+// watcher は Watch* の購読に使う変更イベントとユーザーの確認
+type watcher struct {
+	hub   *changefeed.Hub
+	users repository.UserRepository
+}
+
+// watch は filter に一致する変更イベントを、ctx が終わるまで handle に渡す
+// 購読を開始したら opened でレスポンスのヘッダーを送る（クライアントは最初の変更を待たずに購読の開始を知れる）
+// 購読が打ち切られた（受け取りが遅い）場合は resource_exhausted、
+// 購読中にユーザーが無効化・強制ログアウトされた場合は unauthenticated で終了する
+func (w *watcher) watch(ctx context.Context, filter changefeed.Filter, opened func() error, handle func(changefeed.Event) error) error {
+	sub := w.hub.Subscribe()
+	defer sub.Close()
+	if err := opened(); err != nil {
+		return err
+	}
+
+	authCheck := time.NewTicker(authCheckInterval)
+	defer authCheck.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			// クライアントの切断・Connect-Timeout-Ms は Connect のエラーのまま返す（REST のエラーとしてログに出さない）
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return connect.NewError(connect.CodeDeadlineExceeded, ctx.Err())
+			}
+			return connect.NewError(connect.CodeCanceled, ctx.Err())
+		case ev, ok := <-sub.Events():
+			if !ok {
+				return connect.NewError(connect.CodeResourceExhausted, errors.New("subscription dropped because the client is too slow; resubscribe and reload"))
+			}
+			if !filter.Match(ev) {
+				continue
+			}
+			if err := handle(ev); err != nil {
+				return err
+			}
+		case <-authCheck.C:
+			if !w.stillAuthorized(ctx) {
+				return connect.NewError(connect.CodeUnauthenticated, errors.New("token has been revoked"))
+			}
+		}
+	}
+}
+
+// stillAuthorized は購読を始めたユーザーが無効化・強制ログアウトされていないかを確認する
+// DBのエラーでは購読を切らない
+func (w *watcher) stillAuthorized(ctx context.Context) bool {
+	userID := UserIDFrom(ctx)
+	ctx, cancel := context.WithTimeout(ctx, authCheckTimeout)
+	defer cancel()
+	user, err := w.users.FindByID(ctx, userID)
+	if err != nil {
+		log.Printf("[RPC] Failed to check user %d: %v", userID, err)
+		return true
+	}
+	return user != nil && user.TokenVersion == tokenVersionFrom(ctx)
+}
+
+// sendHeader は最初の変更を待たずにレスポンスのヘッダーを送る
+// connect-go の ServerStream はヘッダーを最初の Send で送り、メッセージなし（nil）の Send がヘッダーだけの送信になる
+func sendHeader[Res any](stream *connect.ServerStream[Res]) func() error {
+	return func() error {
+		return stream.Send(nil)
+	}
+}
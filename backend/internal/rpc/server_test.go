@@ -0,0 +1,355 @@
+package rpc
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/auth"
+	"backend/internal/changefeed"
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	"backend/internal/gen/retrotodo/v1/retrotodov1connect"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"backend/internal/repository/memory"
+	"backend/internal/validation"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/proto"
+)
+
+type testServer struct {
+	url   string
+	token string
+	hub   *changefeed.Hub
+	// userRepo / userID はトークンの持ち主（alice）
+	userRepo repository.UserRepository
+	userID   int
+	// h2c はTLSなしの HTTP/2 のクライアント（gRPC 用）
+	h2c *http.Client
+}
+
+// newTestServer は main と同じ構成のRPCサーバーを、インメモリのリポジトリで起動する
+func newTestServer(t *testing.T) *testServer {
+	repos := memory.NewRepositories()
+	hub := changefeed.NewHub(0, 0)
+	repos = changefeed.NewRepositories(repos, memory.NewUnitOfWork(repos), hub)
+
+	keys, err := auth.NewKeyManager(auth.Config{DevMode: true})
+	require.NoError(t, err)
+	tokens := auth.NewTokenService(keys)
+	validator := validation.NewValidator(validation.DefaultPasswordPolicy())
+	validator.RegisterExists("sprint", repos.Sprints.Exists)
+
+	e := NewServer(Config{
+		Todos:          repos.Todos,
+		Sprints:        repos.Sprints,
+		Tokens:         tokens,
+		Users:          repos.Users,
+		Hub:            hub,
+		Validator:      validator,
+		RequestTimeout: time.Second,
+	})
+	srv := httptest.NewUnstartedServer(e)
+	srv.Config.Protocols = e.Server.Protocols
+	srv.Start()
+	t.Cleanup(srv.Close)
+
+	user, err := repos.Users.Create(context.Background(), "alice", "alice@example.com", "hash")
+	require.NoError(t, err)
+	token, err := tokens.GenerateJWT(user)
+	require.NoError(t, err)
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	h2c := &http.Client{Transport: &http.Transport{Protocols: protocols}}
+	return &testServer{url: srv.URL, token: token, hub: hub, userRepo: repos.Users, userID: user.ID, h2c: h2c}
+}
+
+// clientProtocol はクライアントのプロトコルとコーデックの組み合わせ（生成したクライアントの既定は Connect + バイナリ）
+type clientProtocol struct {
+	opts []connect.ClientOption
+	// gRPC は HTTP/2 が必要
+	http2 bool
+}
+
+var clientProtocols = map[string]clientProtocol{
+	"connect+proto": {},
+	"connect+json":  {opts: []connect.ClientOption{connect.WithProtoJSON()}},
+	"grpc":          {opts: []connect.ClientOption{connect.WithGRPC()}, http2: true},
+	"grpc-web":      {opts: []connect.ClientOption{connect.WithGRPCWeb()}},
+}
+
+func (s *testServer) clientOptions(p clientProtocol) (*http.Client, []connect.ClientOption) {
+	opts := append([]connect.ClientOption{connect.WithInterceptors(s.auth())}, p.opts...)
+	if p.http2 {
+		return s.h2c, opts
+	}
+	return http.DefaultClient, opts
+}
+
+func (s *testServer) todos(p clientProtocol) retrotodov1connect.TodoServiceClient {
+	client, opts := s.clientOptions(p)
+	return retrotodov1connect.NewTodoServiceClient(client, s.url, opts...)
+}
+
+func (s *testServer) sprints(p clientProtocol) retrotodov1connect.SprintServiceClient {
+	client, opts := s.clientOptions(p)
+	return retrotodov1connect.NewSprintServiceClient(client, s.url, opts...)
+}
+
+func (s *testServer) users(p clientProtocol) retrotodov1connect.UserServiceClient {
+	client, opts := s.clientOptions(p)
+	return retrotodov1connect.NewUserServiceClient(client, s.url, opts...)
+}
+
+// auth は Authorization ヘッダーを付ける（s.token を呼び出し時に読む）
+func (s *testServer) auth() connect.Interceptor {
+	return authInterceptor{token: func() string { return s.token }}
+}
+
+type authInterceptor struct {
+	token func() string
+}
+
+func (a authInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		req.Header().Set("Authorization", "Bearer "+a.token())
+		return next(ctx, req)
+	}
+}
+
+func (a authInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return func(ctx context.Context, spec connect.Spec) connect.StreamingClientConn {
+		conn := next(ctx, spec)
+		conn.RequestHeader().Set("Authorization", "Bearer "+a.token())
+		return conn
+	}
+}
+
+func (a authInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+func TestServer_Unary(t *testing.T) {
+	for name, p := range clientProtocols {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t)
+			ctx := context.Background()
+			todos := s.todos(p)
+
+			sprint, err := s.sprints(p).CreateSprint(ctx, connect.NewRequest(&retrotodov1.CreateSprintRequest{Name: "Sprint 1"}))
+			require.NoError(t, err)
+			assert.Equal(t, "bg-purple-500", sprint.Msg.Sprint.Color)
+
+			created, err := todos.CreateTodo(ctx, connect.NewRequest(&retrotodov1.CreateTodoRequest{Title: "Write RPC", SprintId: proto.Int32(1)}))
+			require.NoError(t, err)
+			assert.Equal(t, "Write RPC", created.Msg.Todo.Title)
+			assert.Equal(t, int32(1), created.Msg.Todo.GetSprintId())
+			assert.Equal(t, int32(1), created.Msg.Todo.Version)
+			assert.False(t, created.Msg.Todo.CreatedAt.AsTime().IsZero())
+
+			updated, err := todos.UpdateTodo(ctx, connect.NewRequest(&retrotodov1.UpdateTodoRequest{Id: 1, Title: "Ship RPC", Completed: true, Version: 1}))
+			require.NoError(t, err)
+			assert.True(t, updated.Msg.Todo.Completed)
+
+			found, err := todos.SearchTodos(ctx, connect.NewRequest(&retrotodov1.SearchTodosRequest{Completed: proto.Bool(true)}))
+			require.NoError(t, err)
+			require.Len(t, found.Msg.Todos, 1)
+			assert.Equal(t, "Ship RPC", found.Msg.Todos[0].Title)
+
+			user, err := s.users(p).GetCurrentUser(ctx, connect.NewRequest(&retrotodov1.GetCurrentUserRequest{}))
+			require.NoError(t, err)
+			assert.Equal(t, "alice", user.Msg.User.Username)
+
+			_, err = todos.DeleteTodo(ctx, connect.NewRequest(&retrotodov1.DeleteTodoRequest{Id: 1, Version: 2}))
+			require.NoError(t, err)
+
+			list, err := todos.ListTodos(ctx, connect.NewRequest(&retrotodov1.ListTodosRequest{}))
+			require.NoError(t, err)
+			assert.Empty(t, list.Msg.Todos)
+		})
+	}
+}
+
+func TestServer_Errors(t *testing.T) {
+	for name, p := range clientProtocols {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t)
+			ctx := context.Background()
+			todos := s.todos(p)
+			_, err := todos.CreateTodo(ctx, connect.NewRequest(&retrotodov1.CreateTodoRequest{Title: "Todo"}))
+			require.NoError(t, err)
+
+			t.Run("validation", func(t *testing.T) {
+				_, err := todos.CreateTodo(ctx, connect.NewRequest(&retrotodov1.CreateTodoRequest{SprintId: proto.Int32(99)}))
+				assertError(t, err, connect.CodeInvalidArgument, "validation_failed")
+
+				var violations []*errdetails.BadRequest_FieldViolation
+				for _, d := range connectError(t, err).Details() {
+					if v, err := d.Value(); err == nil {
+						if br, ok := v.(*errdetails.BadRequest); ok {
+							violations = br.FieldViolations
+						}
+					}
+				}
+				require.NotEmpty(t, violations)
+				assert.NotEmpty(t, violations[0].Field)
+				assert.NotEmpty(t, violations[0].Reason)
+			})
+
+			t.Run("not found", func(t *testing.T) {
+				_, err := todos.UpdateTodo(ctx, connect.NewRequest(&retrotodov1.UpdateTodoRequest{Id: 99, Title: "x", Version: 1}))
+				assertError(t, err, connect.CodeNotFound, "todo_not_found")
+			})
+
+			t.Run("version mismatch", func(t *testing.T) {
+				_, err := todos.DeleteTodo(ctx, connect.NewRequest(&retrotodov1.DeleteTodoRequest{Id: 1, Version: 5}))
+				assertError(t, err, connect.CodeAborted, "version_mismatch")
+			})
+
+			t.Run("unauthenticated", func(t *testing.T) {
+				token := s.token
+				s.token = "invalid"
+				defer func() { s.token = token }()
+				_, err := s.users(p).GetCurrentUser(ctx, connect.NewRequest(&retrotodov1.GetCurrentUserRequest{}))
+				assertError(t, err, connect.CodeUnauthenticated, apperror.CodeInvalidToken)
+			})
+		})
+	}
+
+	t.Run("unknown procedure", func(t *testing.T) {
+		s := newTestServer(t)
+		req, err := http.NewRequest(http.MethodPost, s.url+"/retrotodo.v1.TodoService/Nope", strings.NewReader(`{}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer "+s.token)
+		res, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusNotImplemented, res.StatusCode)
+		assert.Contains(t, string(body), `"code":"unimplemented"`)
+	})
+}
+
+func connectError(t *testing.T, err error) *connect.Error {
+	t.Helper()
+	var ce *connect.Error
+	require.True(t, errors.As(err, &ce), "not a connect error: %v", err)
+	return ce
+}
+
+// assertError はエラーコードと ErrorInfo の reason（REST のエラーコード）を確認する
+func assertError(t *testing.T, err error, code connect.Code, reason string) {
+	t.Helper()
+	require.Error(t, err)
+	ce := connectError(t, err)
+	assert.Equal(t, code, ce.Code(), ce.Error())
+	for _, d := range ce.Details() {
+		v, err := d.Value()
+		require.NoError(t, err)
+		if info, ok := v.(*errdetails.ErrorInfo); ok {
+			assert.Equal(t, reason, info.Reason)
+			assert.Equal(t, errorDomain, info.Domain)
+			return
+		}
+	}
+	t.Errorf("no ErrorInfo in %v", ce)
+}
+
+func TestServer_WatchTodos(t *testing.T) {
+	for name, p := range clientProtocols {
+		t.Run(name, func(t *testing.T) {
+			s := newTestServer(t)
+			todos := s.todos(p)
+			_, err := s.sprints(p).CreateSprint(context.Background(), connect.NewRequest(&retrotodov1.CreateSprintRequest{Name: "Sprint 1"}))
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			stream, err := todos.WatchTodos(ctx, connect.NewRequest(&retrotodov1.WatchTodosRequest{SprintId: proto.Int32(1)}))
+			require.NoError(t, err)
+			defer stream.Close()
+			require.Eventually(t, func() bool { return s.hub.Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+
+			// スプリントに所属しないTODOは送られない
+			_, err = todos.CreateTodo(context.Background(), connect.NewRequest(&retrotodov1.CreateTodoRequest{Title: "Other"}))
+			require.NoError(t, err)
+			_, err = todos.CreateTodo(context.Background(), connect.NewRequest(&retrotodov1.CreateTodoRequest{Title: "Mine", SprintId: proto.Int32(1)}))
+			require.NoError(t, err)
+			_, err = todos.DeleteTodo(context.Background(), connect.NewRequest(&retrotodov1.DeleteTodoRequest{Id: 2, Version: 1}))
+			require.NoError(t, err)
+
+			var events []*retrotodov1.WatchTodosResponse
+			for len(events) < 2 && stream.Receive() {
+				events = append(events, stream.Msg())
+			}
+			require.NoError(t, stream.Err())
+			require.Len(t, events, 2)
+			assert.Equal(t, retrotodov1.ChangeType_CHANGE_TYPE_CREATED, events[0].Type)
+			assert.Equal(t, int32(2), events[0].TodoId)
+			require.NotNil(t, events[0].Todo)
+			assert.Equal(t, "Mine", events[0].Todo.Title)
+			assert.Equal(t, retrotodov1.ChangeType_CHANGE_TYPE_DELETED, events[1].Type)
+			assert.Equal(t, int32(2), events[1].TodoId)
+			assert.Nil(t, events[1].Todo)
+
+			// 切断すると購読が外れる
+			cancel()
+			require.Eventually(t, func() bool { return s.hub.Subscribers() == 0 }, time.Second, 5*time.Millisecond)
+		})
+	}
+}
+
+// 購読中に強制ログアウトされたら unauthenticated で終了する
+func TestServer_WatchRevoked(t *testing.T) {
+	interval := authCheckInterval
+	authCheckInterval = 10 * time.Millisecond
+	t.Cleanup(func() { authCheckInterval = interval })
+
+	s := newTestServer(t)
+	stream, err := s.sprints(clientProtocols["connect+proto"]).WatchSprints(context.Background(), connect.NewRequest(&retrotodov1.WatchSprintsRequest{}))
+	require.NoError(t, err)
+	defer stream.Close()
+	require.Eventually(t, func() bool { return s.hub.Subscribers() == 1 }, time.Second, 5*time.Millisecond)
+
+	_, err = s.userRepo.RevokeTokens(context.Background(), s.userID)
+	require.NoError(t, err)
+
+	assert.False(t, stream.Receive())
+	assert.Equal(t, connect.CodeUnauthenticated, connect.CodeOf(stream.Err()))
+	require.Eventually(t, func() bool { return s.hub.Subscribers() == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestCodeFor(t *testing.T) {
+	tests := []struct {
+		problem model.Problem
+		want    connect.Code
+	}{
+		{model.Problem{Status: 499, Code: apperror.CodeRequestCanceled}, connect.CodeCanceled},
+		{model.Problem{Status: http.StatusBadRequest}, connect.CodeInvalidArgument},
+		{model.Problem{Status: http.StatusUnprocessableEntity}, connect.CodeInvalidArgument},
+		{model.Problem{Status: http.StatusUnauthorized}, connect.CodeUnauthenticated},
+		{model.Problem{Status: http.StatusForbidden}, connect.CodePermissionDenied},
+		{model.Problem{Status: http.StatusNotFound}, connect.CodeNotFound},
+		{model.Problem{Status: http.StatusConflict}, connect.CodeAlreadyExists},
+		{model.Problem{Status: http.StatusPreconditionFailed}, connect.CodeAborted},
+		{model.Problem{Status: http.StatusTooManyRequests}, connect.CodeResourceExhausted},
+		{model.Problem{Status: http.StatusGatewayTimeout}, connect.CodeDeadlineExceeded},
+		{model.Problem{Status: http.StatusServiceUnavailable}, connect.CodeUnavailable},
+		{model.Problem{Status: http.StatusInternalServerError}, connect.CodeInternal},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, codeFor(&tt.problem), "status %d", tt.problem.Status)
+	}
+}
@@ -0,0 +1,98 @@
+package rpc
+
+//go:generate mockgen -source=../gen/retrotodo/v1/retrotodov1connect/sprint.connect.go -destination=mock/mock_sprint_service.go -package=mock
+
+import (
+	"backend/internal/changefeed"
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	"backend/internal/gen/retrotodo/v1/retrotodov1connect"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+type SprintService struct {
+	repo      repository.SprintRepository
+	validator Validator
+	watcher   watcher
+}
+
+func NewSprintService(repo repository.SprintRepository, validator Validator, hub *changefeed.Hub, users repository.UserRepository) retrotodov1connect.SprintServiceHandler {
+	return &SprintService{repo: repo, validator: validator, watcher: watcher{hub: hub, users: users}}
+}
+
+func (s *SprintService) ListSprints(ctx context.Context, req *connect.Request[retrotodov1.ListSprintsRequest]) (*connect.Response[retrotodov1.ListSprintsResponse], error) {
+	sprints, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.ListSprintsResponse{Sprints: sprintMessages(sprints)}), nil
+}
+
+func (s *SprintService) SearchSprints(ctx context.Context, req *connect.Request[retrotodov1.SearchSprintsRequest]) (*connect.Response[retrotodov1.SearchSprintsResponse], error) {
+	sprints, err := s.repo.Search(ctx, &model.SprintSearchRequest{Name: req.Msg.Name, IsFavorite: req.Msg.IsFavorite})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.SearchSprintsResponse{Sprints: sprintMessages(sprints)}), nil
+}
+
+func (s *SprintService) CreateSprint(ctx context.Context, req *connect.Request[retrotodov1.CreateSprintRequest]) (*connect.Response[retrotodov1.CreateSprintResponse], error) {
+	sp := &model.Sprint{Name: req.Msg.Name, Color: req.Msg.Color, IsFavorite: req.Msg.IsFavorite}
+	if err := s.validator.ValidateContext(ctx, sp); err != nil {
+		return nil, err
+	}
+
+	// デフォルト値を設定
+	if sp.Color == "" {
+		sp.Color = "bg-purple-500"
+	}
+
+	created, err := s.repo.Create(ctx, sp.Name, sp.Color, sp.IsFavorite)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.CreateSprintResponse{Sprint: sprintMessage(created)}), nil
+}
+
+func (s *SprintService) UpdateSprint(ctx context.Context, req *connect.Request[retrotodov1.UpdateSprintRequest]) (*connect.Response[retrotodov1.UpdateSprintResponse], error) {
+	sp := &model.Sprint{Name: req.Msg.Name, Color: req.Msg.Color}
+	if err := s.validator.ValidateContext(ctx, sp); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, int(req.Msg.Id), sp.Name, sp.Color, int(req.Msg.Version))
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.UpdateSprintResponse{Sprint: sprintMessage(updated)}), nil
+}
+
+func (s *SprintService) UpdateFavorite(ctx context.Context, req *connect.Request[retrotodov1.UpdateFavoriteRequest]) (*connect.Response[retrotodov1.UpdateFavoriteResponse], error) {
+	updated, err := s.repo.UpdateFavorite(ctx, int(req.Msg.Id), req.Msg.IsFavorite, int(req.Msg.Version))
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.UpdateFavoriteResponse{Sprint: sprintMessage(updated)}), nil
+}
+
+func (s *SprintService) DeleteSprint(ctx context.Context, req *connect.Request[retrotodov1.DeleteSprintRequest]) (*connect.Response[retrotodov1.DeleteSprintResponse], error) {
+	if err := s.repo.Delete(ctx, int(req.Msg.Id), int(req.Msg.Version)); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.DeleteSprintResponse{}), nil
+}
+
+// WatchSprints は購読を開始した後のスプリントの変更を、クライアントが切断するまで送る
+func (s *SprintService) WatchSprints(ctx context.Context, req *connect.Request[retrotodov1.WatchSprintsRequest], stream *connect.ServerStream[retrotodov1.WatchSprintsResponse]) error {
+	filter := changefeed.Filter{Kinds: []string{changefeed.KindSprint}}
+	return s.watcher.watch(ctx, filter, sendHeader(stream), func(ev changefeed.Event) error {
+		res := &retrotodov1.WatchSprintsResponse{Type: changeType(ev.Action), SprintId: int32(ev.ID)}
+		if ev.Sprint != nil {
+			res.Sprint = sprintMessage(ev.Sprint)
+		}
+		return stream.Send(res)
+	})
+}
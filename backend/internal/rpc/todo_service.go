@@ -0,0 +1,94 @@
+package rpc
+
+//go:generate mockgen -source=../gen/retrotodo/v1/retrotodov1connect/todo.connect.go -destination=mock/mock_todo_service.go -package=mock
+
+import (
+	"backend/internal/changefeed"
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	"backend/internal/gen/retrotodo/v1/retrotodov1connect"
+	"backend/internal/model"
+	"backend/internal/repository"
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+type TodoService struct {
+	repo      repository.TodoRepository
+	validator Validator
+	watcher   watcher
+}
+
+func NewTodoService(repo repository.TodoRepository, validator Validator, hub *changefeed.Hub, users repository.UserRepository) retrotodov1connect.TodoServiceHandler {
+	return &TodoService{repo: repo, validator: validator, watcher: watcher{hub: hub, users: users}}
+}
+
+func (s *TodoService) ListTodos(ctx context.Context, req *connect.Request[retrotodov1.ListTodosRequest]) (*connect.Response[retrotodov1.ListTodosResponse], error) {
+	todos, err := s.repo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.ListTodosResponse{Todos: todoMessages(todos)}), nil
+}
+
+func (s *TodoService) SearchTodos(ctx context.Context, req *connect.Request[retrotodov1.SearchTodosRequest]) (*connect.Response[retrotodov1.SearchTodosResponse], error) {
+	todos, err := s.repo.Search(ctx, &model.TodoSearchRequest{
+		Title:       req.Msg.Title,
+		Description: req.Msg.Description,
+		Completed:   req.Msg.Completed,
+		SprintID:    intPtr(req.Msg.SprintId),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.SearchTodosResponse{Todos: todoMessages(todos)}), nil
+}
+
+func (s *TodoService) CreateTodo(ctx context.Context, req *connect.Request[retrotodov1.CreateTodoRequest]) (*connect.Response[retrotodov1.CreateTodoResponse], error) {
+	t := &model.Todo{Title: req.Msg.Title, Description: req.Msg.Description, SprintID: intPtr(req.Msg.SprintId)}
+	if err := s.validator.ValidateContext(ctx, t); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, t.Title, t.Description, t.SprintID)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.CreateTodoResponse{Todo: todoMessage(created)}), nil
+}
+
+func (s *TodoService) UpdateTodo(ctx context.Context, req *connect.Request[retrotodov1.UpdateTodoRequest]) (*connect.Response[retrotodov1.UpdateTodoResponse], error) {
+	t := &model.Todo{Title: req.Msg.Title, Completed: req.Msg.Completed}
+	if err := s.validator.ValidateContext(ctx, t); err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, t.Title, t.Completed, int(req.Msg.Id), int(req.Msg.Version))
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.UpdateTodoResponse{Todo: todoMessage(updated)}), nil
+}
+
+func (s *TodoService) DeleteTodo(ctx context.Context, req *connect.Request[retrotodov1.DeleteTodoRequest]) (*connect.Response[retrotodov1.DeleteTodoResponse], error) {
+	if err := s.repo.Delete(ctx, int(req.Msg.Id), int(req.Msg.Version)); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&retrotodov1.DeleteTodoResponse{}), nil
+}
+
+// WatchTodos は購読を開始した後のTODOの変更を、クライアントが切断するまで送る
+// sprintId を指定した場合、作成・更新は変更後にそのスプリントに所属するTODOだけを送る（削除は全て送る）
+func (s *TodoService) WatchTodos(ctx context.Context, req *connect.Request[retrotodov1.WatchTodosRequest], stream *connect.ServerStream[retrotodov1.WatchTodosResponse]) error {
+	filter := changefeed.Filter{Kinds: []string{changefeed.KindTodo}}
+	if req.Msg.SprintId != nil {
+		filter.SprintIDs = []int{int(*req.Msg.SprintId)}
+	}
+	return s.watcher.watch(ctx, filter, sendHeader(stream), func(ev changefeed.Event) error {
+		res := &retrotodov1.WatchTodosResponse{Type: changeType(ev.Action), TodoId: int32(ev.ID)}
+		if ev.Todo != nil {
+			res.Todo = todoMessage(ev.Todo)
+		}
+		return stream.Send(res)
+	})
+}
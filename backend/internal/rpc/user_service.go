@@ -0,0 +1,31 @@
+package rpc
+
+//go:generate mockgen -source=../gen/retrotodo/v1/retrotodov1connect/user.connect.go -destination=mock/mock_user_service.go -package=mock
+
+import (
+	retrotodov1 "backend/internal/gen/retrotodo/v1"
+	"backend/internal/gen/retrotodo/v1/retrotodov1connect"
+	"backend/internal/repository"
+	"context"
+
+	"connectrpc.com/connect"
+)
+
+type UserService struct {
+	users repository.UserRepository
+}
+
+func NewUserService(users repository.UserRepository) retrotodov1connect.UserServiceHandler {
+	return &UserService{users: users}
+}
+
+func (s *UserService) GetCurrentUser(ctx context.Context, req *connect.Request[retrotodov1.GetCurrentUserRequest]) (*connect.Response[retrotodov1.GetCurrentUserResponse], error) {
+	user, err := s.users.FindByID(ctx, UserIDFrom(ctx))
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, repository.ErrUserNotFound
+	}
+	return connect.NewResponse(&retrotodov1.GetCurrentUserResponse{User: userMessage(user)}), nil
+}
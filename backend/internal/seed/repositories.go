@@ -0,0 +1,35 @@
+package seed
+
+import (
+	"context"
+	"log"
+
+	"backend/internal/repository"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Repositories は dev セットと同じデータをリポジトリ経由で投入する
+// DBを使わないデモモード（インメモリのリポジトリ）用で、空のリポジトリに対して1回だけ呼ぶ
+func Repositories(ctx context.Context, repos *repository.Repositories) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(devUser.password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	user, err := repos.Users.Create(ctx, devUser.username, devUser.email, string(hash))
+	if err != nil {
+		return err
+	}
+	if _, err := repos.Users.SetRole(ctx, user.ID, devUser.role); err != nil {
+		return err
+	}
+
+	for _, s := range devSprints {
+		if _, err := repos.Sprints.Create(ctx, s.name, s.color, s.isFavorite); err != nil {
+			return err
+		}
+	}
+
+	log.Printf("[SEED] ✓ Seeded %q", "dev")
+	return nil
+}
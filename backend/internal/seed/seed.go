@@ -0,0 +1,146 @@
+// Package seed はマイグレーションとは別に投入するサンプルデータを管理する
+//
+// 各シードセットは何度実行しても同じ状態になる（冪等）ように作られている。
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sort"
+
+	"backend/internal/storage"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Options はシード投入時のパラメータ
+type Options struct {
+	// Sprints は demo セットで生成するスプリント数
+	Sprints int
+	// TodosPerSprint は demo セットで各スプリントに生成するTODO数
+	TodosPerSprint int
+}
+
+// DefaultOptions はデフォルトのパラメータ
+func DefaultOptions() Options {
+	return Options{
+		Sprints:        50,
+		TodosPerSprint: 200,
+	}
+}
+
+// Set は名前付きのシードセット
+type Set struct {
+	Name        string
+	Description string
+	// PostgresOnly は PostgreSQL 固有の関数で生成するセット（SQLite では使えない）
+	PostgresOnly bool
+	run          func(ctx context.Context, tx *sql.Tx, opts Options) error
+}
+
+var sets = map[string]Set{
+	"dev": {
+		Name:        "dev",
+		Description: "ローカル開発用のテストユーザーとサンプルスプリント",
+		run:         seedDev,
+	},
+	"demo": {
+		Name:         "demo",
+		Description:  "負荷試験・デモ用の大量のスプリントとTODO",
+		PostgresOnly: true,
+		run:          seedDemo,
+	},
+	"e2e": {
+		Name:        "e2e",
+		Description: "E2Eテスト用の固定ユーザー・スプリント・TODO",
+		run:         seedE2E,
+	},
+}
+
+// Sets は利用可能なシードセットを名前順に返す
+func Sets() []Set {
+	result := make([]Set, 0, len(sets))
+	for _, s := range sets {
+		result = append(result, s)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result
+}
+
+// Run は指定したシードセットを1つのトランザクションで投入する
+func Run(ctx context.Context, db *sql.DB, name string, opts Options) error {
+	set, ok := sets[name]
+	if !ok {
+		return fmt.Errorf("unknown seed set %q", name)
+	}
+	if opts.Sprints < 0 || opts.TodosPerSprint < 0 {
+		return fmt.Errorf("seed options must not be negative")
+	}
+	if set.PostgresOnly && storage.IsSQLite(db) {
+		return fmt.Errorf("seed set %q requires PostgreSQL", name)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := set.run(ctx, tx, opts); err != nil {
+		return fmt.Errorf("seed %s: %w", name, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("[SEED] ✓ Seeded %q", name)
+	return nil
+}
+
+// seedUser はユーザーが存在しない場合のみ作成する
+func seedUser(ctx context.Context, tx *sql.Tx, username, email, password, role string) error {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO users (username, email, password_hash, role) VALUES ($1, $2, $3, $4)
+		ON CONFLICT (username) DO NOTHING
+	`, username, email, string(hash), role)
+	return err
+}
+
+// seedSprint は同名のスプリントが存在しない場合のみ作成し、IDを返す
+func seedSprint(ctx context.Context, tx *sql.Tx, name, color string, isFavorite bool) (int, error) {
+	var id int
+	err := tx.QueryRowContext(ctx,
+		"SELECT id FROM sprints WHERE name = $1 AND is_deleted = false ORDER BY id LIMIT 1",
+		name,
+	).Scan(&id)
+	if err == nil {
+		return id, nil
+	}
+	if err != sql.ErrNoRows {
+		return 0, err
+	}
+
+	err = tx.QueryRowContext(ctx,
+		"INSERT INTO sprints (name, color, is_favorite) VALUES ($1, $2, $3) RETURNING id",
+		name, color, isFavorite,
+	).Scan(&id)
+	return id, err
+}
+
+// seedTodo は同じスプリントに同じタイトルのTODOが存在しない場合のみ作成する
+func seedTodo(ctx context.Context, tx *sql.Tx, title, description string, completed bool, sprintID int) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO todos (title, description, completed, sprint_id)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (
+			SELECT 1 FROM todos WHERE title = $1 AND sprint_id = $4 AND is_deleted = false
+		)
+	`, title, description, completed, sprintID)
+	return err
+}
@@ -0,0 +1,110 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"testing"
+
+	"backend/internal/repository/memory"
+	"backend/internal/storage/storagetest"
+
+	_ "github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func setupTestDB(t *testing.T) *sql.DB {
+	testDBConn := os.Getenv("TEST_DB_CONN")
+	if testDBConn == "" {
+		t.Skip("TEST_DB_CONN not set, skipping integration tests")
+	}
+
+	db, err := sql.Open("postgres", testDBConn)
+	require.NoError(t, err)
+
+	// テーブルをクリーンアップ
+	_, err = db.Exec("DELETE FROM todos")
+	require.NoError(t, err)
+	_, err = db.Exec("DELETE FROM sprints")
+	require.NoError(t, err)
+
+	return db
+}
+
+func count(t *testing.T, db *sql.DB, query string) int {
+	var n int
+	require.NoError(t, db.QueryRow(query).Scan(&n))
+	return n
+}
+
+func TestRun_Idempotent(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	opts := Options{Sprints: 3, TodosPerSprint: 10}
+	for _, set := range Sets() {
+		// 2回実行しても件数が変わらないこと
+		require.NoError(t, Run(context.Background(), db, set.Name, opts))
+		sprints := count(t, db, "SELECT COUNT(*) FROM sprints")
+		todos := count(t, db, "SELECT COUNT(*) FROM todos")
+
+		require.NoError(t, Run(context.Background(), db, set.Name, opts))
+		assert.Equal(t, sprints, count(t, db, "SELECT COUNT(*) FROM sprints"), set.Name)
+		assert.Equal(t, todos, count(t, db, "SELECT COUNT(*) FROM todos"), set.Name)
+	}
+
+	assert.Equal(t, 3, count(t, db, "SELECT COUNT(*) FROM sprints WHERE name LIKE 'Demo Sprint %'"))
+	assert.Equal(t, 30, count(t, db, "SELECT COUNT(*) FROM todos t JOIN sprints s ON s.id = t.sprint_id WHERE s.name LIKE 'Demo Sprint %'"))
+}
+
+func TestRun_DemoGrows(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	require.NoError(t, Run(context.Background(), db, "demo", Options{Sprints: 2, TodosPerSprint: 5}))
+	require.NoError(t, Run(context.Background(), db, "demo", Options{Sprints: 4, TodosPerSprint: 5}))
+
+	assert.Equal(t, 4, count(t, db, "SELECT COUNT(*) FROM sprints"))
+	assert.Equal(t, 20, count(t, db, "SELECT COUNT(*) FROM todos"))
+}
+
+func TestRun_SQLite(t *testing.T) {
+	db := storagetest.NewSQLite(t)
+
+	for _, set := range Sets() {
+		if set.PostgresOnly {
+			assert.Error(t, Run(context.Background(), db, set.Name, DefaultOptions()), set.Name)
+			continue
+		}
+		require.NoError(t, Run(context.Background(), db, set.Name, DefaultOptions()))
+		users := count(t, db, "SELECT COUNT(*) FROM users")
+		sprints := count(t, db, "SELECT COUNT(*) FROM sprints")
+
+		require.NoError(t, Run(context.Background(), db, set.Name, DefaultOptions()))
+		assert.Equal(t, users, count(t, db, "SELECT COUNT(*) FROM users"), set.Name)
+		assert.Equal(t, sprints, count(t, db, "SELECT COUNT(*) FROM sprints"), set.Name)
+	}
+}
+
+func TestRun_UnknownSet(t *testing.T) {
+	err := Run(context.Background(), nil, "prod", DefaultOptions())
+	assert.Error(t, err)
+}
+
+func TestRepositories(t *testing.T) {
+	ctx := context.Background()
+	repos := memory.NewRepositories()
+
+	require.NoError(t, Repositories(ctx, repos))
+
+	user, err := repos.Users.FindByUsername(ctx, "testuser")
+	require.NoError(t, err)
+	require.NotNil(t, user)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("password123")))
+
+	sprints, err := repos.Sprints.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, sprints, 3)
+}
@@ -0,0 +1,123 @@
+package seed
+
+import (
+	"context"
+	"database/sql"
+	"log"
+
+	"backend/internal/model"
+)
+
+// devUser は dev セットのテストユーザー
+var devUser = struct {
+	username, email, password, role string
+}{"testuser", "test@example.com", "password123", model.RoleUser}
+
+// devSprints は dev セットのサンプルスプリント
+var devSprints = []struct {
+	name       string
+	color      string
+	isFavorite bool
+}{
+	{"バックログ", "bg-blue-500", false},
+	{"2510-4", "bg-purple-500", false},
+	{"Personal Sprint", "bg-purple-500", true},
+}
+
+// seedDev はローカル開発用のデータ（以前はマイグレーションに含まれていたもの）
+func seedDev(ctx context.Context, tx *sql.Tx, _ Options) error {
+	if err := seedUser(ctx, tx, devUser.username, devUser.email, devUser.password, devUser.role); err != nil {
+		return err
+	}
+
+	for _, s := range devSprints {
+		if _, err := seedSprint(ctx, tx, s.name, s.color, s.isFavorite); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedE2E はE2Eテストが前提とする固定データ
+func seedE2E(ctx context.Context, tx *sql.Tx, _ Options) error {
+	if err := seedUser(ctx, tx, "e2e_user", "e2e_user@example.com", "e2e-password-123", model.RoleUser); err != nil {
+		return err
+	}
+	if err := seedUser(ctx, tx, "e2e_admin", "e2e_admin@example.com", "e2e-password-123", model.RoleAdmin); err != nil {
+		return err
+	}
+
+	sprintID, err := seedSprint(ctx, tx, "E2E Sprint", "bg-green-500", false)
+	if err != nil {
+		return err
+	}
+	if _, err := seedSprint(ctx, tx, "E2E Favorite Sprint", "bg-pink-500", true); err != nil {
+		return err
+	}
+
+	todos := []struct {
+		title     string
+		completed bool
+	}{
+		{"E2E open todo", false},
+		{"E2E completed todo", true},
+	}
+	for _, t := range todos {
+		if err := seedTodo(ctx, tx, t.title, "", t.completed, sprintID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedDemo は負荷試験用に大量のスプリントとTODOを生成する
+// 名前に連番を含めることで、再実行しても重複せずに不足分だけが追加される
+func seedDemo(ctx context.Context, tx *sql.Tx, opts Options) error {
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO sprints (name, color, is_favorite, created_at, updated_at)
+		SELECT
+			format('Demo Sprint %s', g),
+			(ARRAY['bg-purple-500', 'bg-blue-500', 'bg-green-500', 'bg-pink-500', 'bg-yellow-500'])[g % 5 + 1],
+			g % 10 = 0,
+			NOW() - make_interval(days => ($1 - g) * 14),
+			NOW() - make_interval(days => ($1 - g) * 14)
+		FROM generate_series(1, $1) AS g
+		WHERE NOT EXISTS (
+			SELECT 1 FROM sprints s WHERE s.name = format('Demo Sprint %s', g)
+		)
+	`, opts.Sprints)
+	if err != nil {
+		return err
+	}
+	sprints, _ := res.RowsAffected()
+
+	// タイトル・説明・完了状態は連番から決定的に生成する（約6割が完了済み）
+	res, err = tx.ExecContext(ctx, `
+		INSERT INTO todos (title, description, completed, sprint_id, created_at, updated_at)
+		SELECT
+			format('%s %s #%s',
+				(ARRAY['Implement', 'Fix', 'Review', 'Refactor', 'Document', 'Test', 'Deploy', 'Investigate'])[t % 8 + 1],
+				(ARRAY['login flow', 'sprint board', 'todo search', 'API pagination', 'dark mode', 'CSV export', 'notifications'])[(t / 8) % 7 + 1],
+				t),
+			CASE WHEN t % 3 = 0 THEN NULL ELSE format('Generated for %s', s.name) END,
+			t % 5 < 3,
+			s.id,
+			s.created_at + make_interval(mins => t * 13),
+			s.created_at + make_interval(mins => t * 17)
+		FROM sprints s
+		CROSS JOIN generate_series(1, $1) AS t
+		WHERE s.name LIKE 'Demo Sprint %'
+			AND s.is_deleted = false
+			AND NOT EXISTS (
+				SELECT 1 FROM todos x
+				WHERE x.sprint_id = s.id AND x.title LIKE format('%% #%s', t)
+			)
+	`, opts.TodosPerSprint)
+	if err != nil {
+		return err
+	}
+	todos, _ := res.RowsAffected()
+
+	log.Printf("[SEED] demo: inserted %d sprints and %d todos", sprints, todos)
+	return nil
+}
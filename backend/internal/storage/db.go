@@ -1,46 +1,111 @@
 package storage
 
 import (
+	"backend/internal/config"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"time"
 
-	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 )
 
-var DB *sql.DB
+// pingの再試行間隔（DBの起動待ち）
+const (
+	initialPingBackoff = 500 * time.Millisecond
+	maxPingBackoff     = 5 * time.Second
+)
 
-func Init() {
-	log.Println("[DB] Loading .env file...")
-	err := godotenv.Load()
-	if err != nil {
-		log.Fatal("Error loading .env file")
+// Store はデータベース接続（コネクションプール）を保持する
+type Store struct {
+	DB *sql.DB
+	// Driver は config.DriverPostgres または config.DriverSQLite
+	Driver string
+}
+
+// New はDBに接続し、プールを設定して疎通を確認する
+// DBが起動するまで cfg.ConnectMaxWait の間、バックオフしながら ping を再試行する
+func New(ctx context.Context, cfg config.DatabaseConfig) (*Store, error) {
+	if cfg.Driver == config.DriverMemory {
+		return nil, errors.New("database driver memory has no database to connect to")
+	}
+	log.Printf("[DB] Connecting to database: %s", cfg)
+
+	driverName, dsn := "postgres", cfg.DSN()
+	if cfg.Driver == config.DriverSQLite {
+		driverName, dsn = sqliteDriverName, sqliteDSN(cfg.Path)
 	}
 
-	dbHost := os.Getenv("DB_HOST")
-	dbPort := os.Getenv("DB_PORT")
-	dbUser := os.Getenv("DB_USER")
-	dbName := os.Getenv("DB_NAME")
-
-	log.Printf("[DB] Connecting to database: host=%s port=%s user=%s dbname=%s", dbHost, dbPort, dbUser, dbName)
-
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable",
-		dbHost,
-		dbPort,
-		dbUser,
-		os.Getenv("DB_PASSWORD"),
-		dbName,
-	)
-	DB, err = sql.Open("postgres", dsn)
+	db, err := sql.Open(driverName, dsn)
 	if err != nil {
-		log.Fatalf("[DB] Failed to open database connection: %v", err)
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
 	}
 
-	log.Println("[DB] Pinging database...")
-	if err = DB.Ping(); err != nil {
-		log.Fatalf("[DB] Failed to ping database: %v", err)
+	s := &Store{DB: db, Driver: cfg.Driver}
+	s.ConfigurePool(cfg)
+
+	if cfg.ConnectMaxWait > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.ConnectMaxWait)
+		defer cancel()
+	}
+	if err := pingWithRetry(ctx, db.PingContext, initialPingBackoff, maxPingBackoff); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
+
 	log.Println("[DB] Successfully connected to database!")
+	return s, nil
+}
+
+// ConfigurePool はコネクションプールの設定を反映する
+func (s *Store) ConfigurePool(cfg config.DatabaseConfig) {
+	s.DB.SetMaxOpenConns(cfg.MaxOpenConns)
+	s.DB.SetMaxIdleConns(cfg.MaxIdleConns)
+	s.DB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	s.DB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+}
+
+// Ping はDBの疎通を確認する
+func (s *Store) Ping(ctx context.Context) error {
+	return s.DB.PingContext(ctx)
+}
+
+// Stats はコネクションプールの統計を返す
+func (s *Store) Stats() sql.DBStats {
+	return s.DB.Stats()
+}
+
+// Close は全ての接続を閉じる
+func (s *Store) Close() error {
+	return s.DB.Close()
+}
+
+// pingWithRetry は成功するか ctx が終了するまで ping を再試行する
+func pingWithRetry(ctx context.Context, ping func(context.Context) error, backoff, maxBackoff time.Duration) error {
+	for attempt := 1; ; attempt++ {
+		err := ping(ctx)
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return fmt.Errorf("gave up after %d attempt(s): %w", attempt, err)
+		}
+
+		log.Printf("[DB] Database not ready (attempt %d), retrying in %s: %v", attempt, backoff, err)
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("gave up after %d attempt(s): %w", attempt, err)
+		case <-timer.C:
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
 }
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPingWithRetry_SucceedsAfterRetries(t *testing.T) {
+	attempts := 0
+	ping := func(context.Context) error {
+		attempts++
+		if attempts < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	err := pingWithRetry(context.Background(), ping, time.Millisecond, 2*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestPingWithRetry_StopsWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	attempts := 0
+	ping := func(context.Context) error {
+		attempts++
+		return errors.New("connection refused")
+	}
+
+	start := time.Now()
+	err := pingWithRetry(ctx, ping, 5*time.Millisecond, 5*time.Millisecond)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Greater(t, attempts, 1)
+	assert.Less(t, time.Since(start), time.Second)
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"net/url"
+	"strings"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteDriverName は PostgreSQL 方言の SQL を SQLite 向けに書き換えるドライバの登録名
+const sqliteDriverName = "sqlite3_pgdialect"
+
+func init() {
+	sql.Register(sqliteDriverName, &dialectDriver{base: &sqlite3.SQLiteDriver{}})
+}
+
+// sqliteDSN は SQLite の接続文字列を返す
+// 外部キー制約を有効にし、書き込みの競合はロック待ちで解決する（トランザクションは開始時に書き込みロックを取る）
+func sqliteDSN(path string) string {
+	params := url.Values{}
+	params.Set("_foreign_keys", "on")
+	params.Set("_busy_timeout", "5000")
+	params.Set("_journal_mode", "WAL")
+	params.Set("_txlock", "immediate")
+	return "file:" + path + "?" + params.Encode()
+}
+
+// IsSQLite は db が SQLite ドライバで開かれているかどうか
+func IsSQLite(db *sql.DB) bool {
+	_, ok := db.Driver().(*dialectDriver)
+	return ok
+}
+
+// rewriteSQL は PostgreSQL 方言のクエリを SQLite で実行できる形に書き換える
+//   - $N    → ?N（同じ番号を複数回使っても同じ引数を参照する）
+//   - ILIKE → LIKE（SQLite の LIKE は ASCII の大文字小文字を区別しない）
+//   - NOW() → CURRENT_TIMESTAMP
+//
+// 文字列リテラル・引用符付き識別子・コメントの中は書き換えない
+// RETURNING と ON CONFLICT は SQLite でもそのまま使える
+func rewriteSQL(query string) string {
+	var b strings.Builder
+	b.Grow(len(query))
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '\'' || c == '"':
+			end := skipQuoted(query, i, c)
+			b.WriteString(query[i:end])
+			i = end
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				end = len(query) - i
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				end = len(query) - i
+			} else {
+				end += 4
+			}
+			b.WriteString(query[i : i+end])
+			i += end
+		case c == '$' && i+1 < len(query) && isDigit(query[i+1]):
+			b.WriteByte('?')
+			i++
+		case isIdentStart(c) && (i == 0 || !isIdentPart(query[i-1])):
+			end := i
+			for end < len(query) && isIdentPart(query[end]) {
+				end++
+			}
+			word := query[i:end]
+			switch {
+			case strings.EqualFold(word, "ILIKE"):
+				b.WriteString("LIKE")
+			case strings.EqualFold(word, "NOW") && strings.HasPrefix(query[end:], "()"):
+				b.WriteString("CURRENT_TIMESTAMP")
+				end += 2
+			default:
+				b.WriteString(word)
+			}
+			i = end
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// skipQuoted は start の引用符で始まるリテラルの終端（閉じ引用符の次）を返す
+// 引用符の2連続はエスケープとして扱う
+func skipQuoted(s string, start int, quote byte) int {
+	for i := start + 1; i < len(s); i++ {
+		if s[i] != quote {
+			continue
+		}
+		if i+1 < len(s) && s[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1
+	}
+	return len(s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || c == '$'
+}
+
+// dialectDriver は SQLite ドライバをラップし、実行前にクエリを書き換える
+type dialectDriver struct {
+	base driver.Driver
+}
+
+func (d *dialectDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.base.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &dialectConn{Conn: conn}, nil
+}
+
+// dialectConn は書き換えたクエリを元のコネクションに渡す
+// 元のコネクションが対応していないインターフェースは driver.ErrSkip で database/sql に任せる
+type dialectConn struct {
+	driver.Conn
+}
+
+func (c *dialectConn) Prepare(query string) (driver.Stmt, error) {
+	return c.Conn.Prepare(rewriteSQL(query))
+}
+
+func (c *dialectConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, rewriteSQL(query))
+	}
+	return c.Prepare(query)
+}
+
+func (c *dialectConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if e, ok := c.Conn.(driver.ExecerContext); ok {
+		return e.ExecContext(ctx, rewriteSQL(query), args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *dialectConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if q, ok := c.Conn.(driver.QueryerContext); ok {
+		return q.QueryContext(ctx, rewriteSQL(query), args)
+	}
+	return nil, driver.ErrSkip
+}
+
+func (c *dialectConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	//nolint:staticcheck // ConnBeginTx 非対応のドライバ向けのフォールバック
+	return c.Conn.Begin()
+}
+
+func (c *dialectConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteSQL(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			"placeholders",
+			"UPDATE todos SET title = $1, completed = $2 WHERE id = $10",
+			"UPDATE todos SET title = ?1, completed = ?2 WHERE id = ?10",
+		},
+		{
+			"reused placeholder",
+			"ON CONFLICT (user_id) DO UPDATE SET secret = $2 WHERE x = $2",
+			"ON CONFLICT (user_id) DO UPDATE SET secret = ?2 WHERE x = ?2",
+		},
+		{
+			"ilike",
+			"SELECT id FROM users WHERE username ILIKE $1 OR email ilike $1",
+			"SELECT id FROM users WHERE username LIKE ?1 OR email LIKE ?1",
+		},
+		{
+			"now",
+			"UPDATE sprints SET updated_at = NOW() WHERE id = $1",
+			"UPDATE sprints SET updated_at = CURRENT_TIMESTAMP WHERE id = ?1",
+		},
+		{
+			"returning is unchanged",
+			"INSERT INTO sprints (name) VALUES ($1) RETURNING id, created_at",
+			"INSERT INTO sprints (name) VALUES (?1) RETURNING id, created_at",
+		},
+		{
+			"literals and comments are unchanged",
+			"SELECT 'costs $1 ILIKE NOW()', \"$2\" -- NOW() $3\n/* ILIKE $4 */ FROM t WHERE a = 'it''s $5' AND b = $6",
+			"SELECT 'costs $1 ILIKE NOW()', \"$2\" -- NOW() $3\n/* ILIKE $4 */ FROM t WHERE a = 'it''s $5' AND b = ?6",
+		},
+		{
+			"identifiers containing keywords",
+			"SELECT now_playing, is_ilike FROM t",
+			"SELECT now_playing, is_ilike FROM t",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, rewriteSQL(tt.query))
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package storagetest はリポジトリなどのテストを各DBバックエンドで実行するためのヘルパー
+package storagetest
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"backend/internal/config"
+	"backend/internal/migration"
+	"backend/internal/storage"
+	"backend/migrations"
+
+	_ "github.com/lib/pq"
+)
+
+// Backend はテスト対象のデータベース
+type Backend struct {
+	// Name は config.DriverPostgres または config.DriverSQLite（サブテスト名に使う）
+	Name string
+	DB   *sql.DB
+}
+
+// Backends はテストに使うデータベースを返す
+//   - sqlite:   一時ディレクトリに作成し、マイグレーションを適用したDB（常に使用）
+//   - postgres: TEST_DB_CONN で指定したDB（設定されている場合のみ。マイグレーション済みであること）
+//
+// 接続はテスト終了時に閉じる
+func Backends(t *testing.T) []Backend {
+	t.Helper()
+
+	backends := []Backend{{Name: config.DriverSQLite, DB: NewSQLite(t)}}
+
+	if conn := os.Getenv("TEST_DB_CONN"); conn != "" {
+		db, err := sql.Open("postgres", conn)
+		if err != nil {
+			t.Fatalf("failed to open TEST_DB_CONN: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		backends = append(backends, Backend{Name: config.DriverPostgres, DB: db})
+	} else {
+		t.Log("TEST_DB_CONN not set, skipping postgres backend")
+	}
+	return backends
+}
+
+// NewSQLite はマイグレーション済みの空の SQLite データベースを作成する
+func NewSQLite(t *testing.T) *sql.DB {
+	t.Helper()
+
+	cfg := config.Default().Database
+	cfg.Driver = config.DriverSQLite
+	cfg.Path = filepath.Join(t.TempDir(), "test.db")
+
+	store, err := storage.New(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to open sqlite database: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	m, err := migration.New(store.DB, config.DriverSQLite, migrations.SQLiteFS)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if err := m.Up(0); err != nil {
+		t.Fatalf("failed to migrate sqlite database: %v", err)
+	}
+	return store.DB
+}
+
+// Truncate はテーブルの全ての行を削除する（テストごとに空の状態から始めるため）
+func Truncate(t *testing.T, db *sql.DB, tables ...string) {
+	t.Helper()
+	for _, table := range tables {
+		if _, err := db.Exec("DELETE FROM " + table); err != nil {
+			t.Fatalf("failed to clean up %s: %v", table, err)
+		}
+	}
+}
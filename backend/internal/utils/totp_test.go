@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 6238 Appendix B のテストベクタ（SHA1、下6桁）
+func TestValidateTOTP_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		step, ok := ValidateTOTP(secret, tt.code, time.Unix(tt.unix, 0))
+		assert.True(t, ok, "time %d", tt.unix)
+		assert.Equal(t, tt.unix/30, step)
+	}
+}
+
+func TestValidateTOTP_Skew(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	assert.NoError(t, err)
+
+	key, _ := base32NoPadding.DecodeString(secret)
+	now := time.Unix(1700000000, 0)
+	code := totpCode(key, now.Unix()/30-1)
+
+	// 1ステップ前は許容、2ステップ後は拒否
+	_, ok := ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	_, ok = ValidateTOTP(secret, code, now.Add(60*time.Second))
+	assert.False(t, ok)
+
+	_, ok = ValidateTOTP(secret, "12345", now)
+	assert.False(t, ok)
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri := TOTPURI("JBSWY3DPEHPK3PXP", "alice")
+	assert.Contains(t, uri, "otpauth://totp/Retro%20Todo:alice?")
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Retro+Todo")
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	assert.NoError(t, err)
+	assert.Len(t, codes, 10)
+	assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, codes[0])
+
+	// 大文字・ハイフンの有無を無視して照合できる
+	assert.Equal(t, HashRecoveryCode(codes[0]), HashRecoveryCode(" "+strings.ToUpper(codes[0])))
+}
@@ -0,0 +1,138 @@
+package validation
+
+import (
+	"backend/internal/model"
+	"bufio"
+	_ "embed"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+//go:embed breached_passwords.txt
+var breachedPasswordList string
+
+// breachedPasswords は同梱の漏洩パスワードリスト（小文字）
+var breachedPasswords = parseBreachedPasswords(breachedPasswordList)
+
+// PasswordPolicy はパスワードの検証ルール
+type PasswordPolicy struct {
+	MinLength int
+	MaxLength int
+	// 同梱の漏洩パスワードリストに含まれるものを拒否する
+	RejectBreached bool
+	// ユーザー名との類似度（0〜1）がこの値以上なら拒否する。0なら無効
+	MaxUsernameSimilarity float64
+}
+
+// DefaultPasswordPolicy はデフォルトのパスワードポリシーを返す
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:             8,
+		MaxLength:             72, // bcryptが扱える上限
+		RejectBreached:        true,
+		MaxUsernameSimilarity: 0.7,
+	}
+}
+
+// Validate はパスワードがポリシーを満たすか検証し、違反をフィールドエラーとして返す
+func (p PasswordPolicy) Validate(password, username string) []model.FieldError {
+	var errs []model.FieldError
+
+	length := utf8.RuneCountInString(password)
+	if length < p.MinLength {
+		errs = append(errs, model.FieldError{
+			Field:   "password",
+			Code:    "too_short",
+			Message: fmt.Sprintf("Password must be at least %d characters", p.MinLength),
+		})
+	}
+	// bcryptはバイト数で制限されるのでバイト長で判定
+	if p.MaxLength > 0 && len(password) > p.MaxLength {
+		errs = append(errs, model.FieldError{
+			Field:   "password",
+			Code:    "too_long",
+			Message: fmt.Sprintf("Password must be at most %d bytes", p.MaxLength),
+		})
+	}
+
+	if p.RejectBreached && IsBreachedPassword(password) {
+		errs = append(errs, model.FieldError{
+			Field:   "password",
+			Code:    "breached",
+			Message: "Password is too common and appears in known breaches",
+		})
+	}
+
+	if p.MaxUsernameSimilarity > 0 && username != "" && similarity(password, username) >= p.MaxUsernameSimilarity {
+		errs = append(errs, model.FieldError{
+			Field:   "password",
+			Code:    "similar_to_username",
+			Message: "Password is too similar to the username",
+		})
+	}
+
+	return errs
+}
+
+// IsBreachedPassword は同梱リストに含まれるパスワードかどうかを返す
+func IsBreachedPassword(password string) bool {
+	_, ok := breachedPasswords[strings.ToLower(password)]
+	return ok
+}
+
+func parseBreachedPasswords(list string) map[string]struct{} {
+	passwords := make(map[string]struct{})
+	scanner := bufio.NewScanner(strings.NewReader(list))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords[strings.ToLower(line)] = struct{}{}
+	}
+	return passwords
+}
+
+// similarity は大文字小文字を無視した2つの文字列の類似度（0〜1）を返す
+// 3文字以上の一方が他方に含まれる場合は1とみなす
+func similarity(a, b string) float64 {
+	a = strings.ToLower(a)
+	b = strings.ToLower(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if (utf8.RuneCountInString(b) >= 3 && strings.Contains(a, b)) ||
+		(utf8.RuneCountInString(a) >= 3 && strings.Contains(b, a)) {
+		return 1
+	}
+
+	ra, rb := []rune(a), []rune(b)
+	longest := len(ra)
+	if len(rb) > longest {
+		longest = len(rb)
+	}
+	return 1 - float64(levenshtein(ra, rb))/float64(longest)
+}
+
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(b)]
+}
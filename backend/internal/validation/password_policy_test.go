@@ -0,0 +1,52 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	policy := DefaultPasswordPolicy()
+
+	tests := []struct {
+		name     string
+		password string
+		username string
+		want     []string
+	}{
+		{"valid", "correct horse battery", "alice", nil},
+		{"too short", "xY7!", "alice", []string{"too_short"}},
+		{"breached", "Password123", "alice", []string{"breached"}},
+		{"contains username", "alice-rocks-2025", "alice", []string{"similar_to_username"}},
+		{"near username", "johnsmitj1", "johnsmith1", []string{"similar_to_username"}},
+		{"too long", string(make([]byte, 73)) + "x", "alice", []string{"too_long"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range policy.Validate(tt.password, tt.username) {
+				assert.Equal(t, "password", e.Field)
+				got = append(got, e.Code)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestValidateUsername(t *testing.T) {
+	assert.Empty(t, ValidateUsername("alice_01"))
+	assert.Equal(t, "required", ValidateUsername("")[0].Code)
+	assert.Equal(t, "invalid_format", ValidateUsername("ab")[0].Code)
+	assert.Equal(t, "invalid_format", ValidateUsername("_alice")[0].Code)
+	assert.Equal(t, "invalid_format", ValidateUsername("alice bob")[0].Code)
+}
+
+func TestValidateEmail(t *testing.T) {
+	assert.Empty(t, ValidateEmail("alice@example.com"))
+	assert.Equal(t, "required", ValidateEmail("")[0].Code)
+	assert.Equal(t, "invalid_format", ValidateEmail("alice")[0].Code)
+	assert.Equal(t, "invalid_format", ValidateEmail("Alice <alice@example.com>")[0].Code)
+	assert.Equal(t, "invalid_format", ValidateEmail("alice@localhost")[0].Code)
+}
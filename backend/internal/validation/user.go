@@ -0,0 +1,46 @@
+package validation
+
+import (
+	"backend/internal/model"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+// usernamePattern は英数字で始まる3〜32文字の英数字・._-
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{2,31}$`)
+
+// ValidateUsername はユーザー名の形式を検証する
+func ValidateUsername(username string) []model.FieldError {
+	if username == "" {
+		return []model.FieldError{{Field: "username", Code: "required", Message: "Username is required"}}
+	}
+	if !usernamePattern.MatchString(username) {
+		return []model.FieldError{{
+			Field:   "username",
+			Code:    "invalid_format",
+			Message: "Username must be 3-32 characters of letters, digits, '.', '_' or '-' and start with a letter or digit",
+		}}
+	}
+	return nil
+}
+
+// ValidateEmail はメールアドレスの形式を検証する
+func ValidateEmail(email string) []model.FieldError {
+	if email == "" {
+		return []model.FieldError{{Field: "email", Code: "required", Message: "Email is required"}}
+	}
+
+	// 表示名付き（"Foo <foo@example.com>"）などは受け付けない
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > 255 {
+		return []model.FieldError{{Field: "email", Code: "invalid_format", Message: "Email address is invalid"}}
+	}
+
+	domain := email[strings.LastIndex(email, "@")+1:]
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return []model.FieldError{{Field: "email", Code: "invalid_format", Message: "Email address is invalid"}}
+	}
+
+	return nil
+}
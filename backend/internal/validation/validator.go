@@ -0,0 +1,216 @@
+package validation
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// colorPattern は Tailwind の背景色クラス（例: bg-purple-500）
+var colorPattern = regexp.MustCompile(`^bg-(slate|gray|zinc|neutral|stone|red|orange|amber|yellow|lime|green|emerald|teal|cyan|sky|blue|indigo|violet|purple|fuchsia|pink|rose)-(50|[1-9]00|950)$`)
+
+// ExistsFunc は別エンティティの存在を確認する（削除済みは存在しない扱い）
+type ExistsFunc func(ctx context.Context, id int) (bool, error)
+
+// Validator は構造体の validate タグで入力を検証する（echo.Validator を実装する）
+//
+// タグはカンマ区切りで、空の値（ゼロ値・nil）には required 以外のルールを適用しない。
+//
+//	required      空文字（空白のみを含む）・nil を拒否する
+//	min=N / max=N 文字数の下限・上限
+//	oneof=a b     列挙した値のいずれか
+//	color         Tailwind の背景色クラス
+//	username      ユーザー名の形式
+//	email         メールアドレスの形式
+//	password=F    パスワードポリシー（F は類似度を判定するフィールドのJSON名）
+//	exists=NAME   RegisterExists で登録した確認で、IDが存在するか
+//
+// 形式のエラーがないフィールドにだけ exists を実行し、すべてのエラーをまとめて返す。
+type Validator struct {
+	policy PasswordPolicy
+	exists map[string]ExistsFunc
+}
+
+// NewValidator は Validator を返す
+func NewValidator(policy PasswordPolicy) *Validator {
+	return &Validator{policy: policy, exists: make(map[string]ExistsFunc)}
+}
+
+// RegisterExists は exists=name で使う存在確認を登録する
+func (v *Validator) RegisterExists(name string, fn ExistsFunc) {
+	v.exists[name] = fn
+}
+
+// Validate は context.Background() で ValidateContext を呼び出す
+func (v *Validator) Validate(i interface{}) error {
+	return v.ValidateContext(context.Background(), i)
+}
+
+// ValidateContext は i（構造体へのポインタ）を検証し、違反があれば apperror.Validation を返す
+// 存在確認が失敗した場合はそのエラーを返す
+func (v *Validator) ValidateContext(ctx context.Context, i interface{}) error {
+	rv := reflect.Indirect(reflect.ValueOf(i))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var errs []model.FieldError
+	rt := rv.Type()
+	for n := 0; n < rt.NumField(); n++ {
+		sf := rt.Field(n)
+		tag := sf.Tag.Get("validate")
+		if tag == "" || !sf.IsExported() {
+			continue
+		}
+
+		fieldErrs, err := v.validateField(ctx, rv, jsonName(sf), rv.Field(n), strings.Split(tag, ","))
+		if err != nil {
+			return err
+		}
+		errs = append(errs, fieldErrs...)
+	}
+
+	if len(errs) > 0 {
+		return apperror.Validation(errs...)
+	}
+	return nil
+}
+
+func (v *Validator) validateField(ctx context.Context, parent reflect.Value, field string, value reflect.Value, rules []string) ([]model.FieldError, error) {
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value = reflect.Value{}
+		} else {
+			value = value.Elem()
+		}
+	}
+	label := fieldLabel(field)
+
+	if isEmpty(value) {
+		for _, rule := range rules {
+			if rule == "required" {
+				return []model.FieldError{{Field: field, Code: "required", Message: label + " is required"}}, nil
+			}
+		}
+		return nil, nil
+	}
+
+	var errs []model.FieldError
+	var exists []string
+	for _, rule := range rules {
+		name, param, _ := strings.Cut(rule, "=")
+		switch name {
+		case "required":
+		case "min":
+			if limit, _ := strconv.Atoi(param); utf8.RuneCountInString(value.String()) < limit {
+				errs = append(errs, model.FieldError{Field: field, Code: "too_short", Message: fmt.Sprintf("%s must be at least %d characters", label, limit)})
+			}
+		case "max":
+			if limit, _ := strconv.Atoi(param); utf8.RuneCountInString(value.String()) > limit {
+				errs = append(errs, model.FieldError{Field: field, Code: "too_long", Message: fmt.Sprintf("%s must be at most %d characters", label, limit)})
+			}
+		case "oneof":
+			allowed := strings.Fields(param)
+			if !contains(allowed, value.String()) {
+				errs = append(errs, model.FieldError{Field: field, Code: "invalid_value", Message: fmt.Sprintf("%s must be one of: %s", label, strings.Join(allowed, ", "))})
+			}
+		case "color":
+			if !colorPattern.MatchString(value.String()) {
+				errs = append(errs, model.FieldError{Field: field, Code: "invalid_format", Message: label + " must be a Tailwind background class such as bg-purple-500"})
+			}
+		case "username":
+			errs = append(errs, withField(ValidateUsername(value.String()), field)...)
+		case "email":
+			errs = append(errs, withField(ValidateEmail(value.String()), field)...)
+		case "password":
+			errs = append(errs, withField(v.policy.Validate(value.String(), stringField(parent, param)), field)...)
+		case "exists":
+			exists = append(exists, param)
+		default:
+			return nil, fmt.Errorf("validation: unknown rule %q on %s", rule, field)
+		}
+	}
+	if len(errs) > 0 {
+		return errs, nil
+	}
+
+	for _, name := range exists {
+		fn, ok := v.exists[name]
+		if !ok {
+			return nil, fmt.Errorf("validation: exists check %q is not registered", name)
+		}
+		found, err := fn(ctx, int(value.Int()))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			errs = append(errs, model.FieldError{Field: field, Code: "not_found", Message: fieldLabel(name) + " not found"})
+		}
+	}
+	return errs, nil
+}
+
+// jsonName はフィールドのJSON名を返す（タグがなければフィールド名）
+func jsonName(sf reflect.StructField) string {
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return sf.Name
+}
+
+// fieldLabel はメッセージ用にJSON名を整形する（sprint_id → Sprint ID）
+func fieldLabel(name string) string {
+	words := strings.Split(name, "_")
+	for i, w := range words {
+		switch {
+		case w == "id":
+			words[i] = "ID"
+		case i == 0 && w != "":
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// stringField は JSON名が name の文字列フィールドの値を返す
+func stringField(parent reflect.Value, name string) string {
+	rt := parent.Type()
+	for n := 0; n < rt.NumField(); n++ {
+		if jsonName(rt.Field(n)) == name && parent.Field(n).Kind() == reflect.String {
+			return parent.Field(n).String()
+		}
+	}
+	return ""
+}
+
+func isEmpty(value reflect.Value) bool {
+	if !value.IsValid() {
+		return true
+	}
+	if value.Kind() == reflect.String {
+		return strings.TrimSpace(value.String()) == ""
+	}
+	return value.IsZero()
+}
+
+func withField(errs []model.FieldError, field string) []model.FieldError {
+	for i := range errs {
+		errs[i].Field = field
+	}
+	return errs
+}
+
+func contains(values []string, v string) bool {
+	for _, value := range values {
+		if value == v {
+			return true
+		}
+	}
+	return false
+}
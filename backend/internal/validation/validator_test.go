@@ -0,0 +1,112 @@
+package validation
+
+import (
+	"backend/internal/apperror"
+	"backend/internal/model"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRequest struct {
+	Name     string  `json:"name" validate:"required,max=5"`
+	Color    string  `json:"color" validate:"color"`
+	Role     *string `json:"role" validate:"oneof=user admin"`
+	SprintID *int    `json:"sprint_id" validate:"exists=sprint"`
+	Ignored  string  `json:"ignored"`
+}
+
+func newTestValidator(sprints ...int) *Validator {
+	v := NewValidator(DefaultPasswordPolicy())
+	v.RegisterExists("sprint", func(ctx context.Context, id int) (bool, error) {
+		for _, s := range sprints {
+			if s == id {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+	return v
+}
+
+// fieldCodes は検証エラーを「フィールド:コード」の一覧にする
+func fieldCodes(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var appErr *apperror.Error
+	require.ErrorAs(t, err, &appErr)
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+
+	var codes []string
+	for _, f := range appErr.Fields {
+		codes = append(codes, f.Field+":"+f.Code)
+	}
+	return codes
+}
+
+func TestValidator_Validate(t *testing.T) {
+	role := func(s string) *string { return &s }
+	id := func(i int) *int { return &i }
+
+	tests := []struct {
+		name string
+		req  testRequest
+		want []string
+	}{
+		{"valid", testRequest{Name: "todo", Color: "bg-purple-500", Role: role("admin"), SprintID: id(1)}, nil},
+		{"optional fields omitted", testRequest{Name: "todo"}, nil},
+		{"required", testRequest{Name: "   "}, []string{"name:required"}},
+		{"too long", testRequest{Name: "あいうえおか"}, []string{"name:too_long"}},
+		{"invalid color", testRequest{Name: "todo", Color: "purple"}, []string{"color:invalid_format"}},
+		{"unknown color", testRequest{Name: "todo", Color: "bg-brand-500"}, []string{"color:invalid_format"}},
+		{"invalid role", testRequest{Name: "todo", Role: role("owner")}, []string{"role:invalid_value"}},
+		{"unknown sprint", testRequest{Name: "todo", SprintID: id(2)}, []string{"sprint_id:not_found"}},
+		{
+			"aggregated",
+			testRequest{Color: "red", Role: role("root"), SprintID: id(2)},
+			[]string{"name:required", "color:invalid_format", "role:invalid_value", "sprint_id:not_found"},
+		},
+	}
+
+	v := newTestValidator(1)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fieldCodes(t, v.Validate(&tt.req)))
+		})
+	}
+}
+
+func TestValidator_Register(t *testing.T) {
+	v := newTestValidator()
+
+	err := v.Validate(&model.RegisterRequest{Username: "alice", Email: "alice", Password: "alice-rocks-2025"})
+	assert.Equal(t, []string{"email:invalid_format", "password:similar_to_username"}, fieldCodes(t, err))
+
+	err = v.Validate(&model.RegisterRequest{})
+	assert.Equal(t, []string{"username:required", "email:required", "password:required"}, fieldCodes(t, err))
+
+	assert.NoError(t, v.Validate(&model.RegisterRequest{Username: "alice", Email: "alice@example.com", Password: "correct horse battery"}))
+}
+
+func TestValidator_ExistsError(t *testing.T) {
+	dbErr := errors.New("connection refused")
+	v := NewValidator(DefaultPasswordPolicy())
+	v.RegisterExists("sprint", func(ctx context.Context, id int) (bool, error) {
+		return false, dbErr
+	})
+	sprintID := 1
+
+	// 存在確認の失敗は検証エラーではなくそのまま返す
+	err := v.Validate(&testRequest{Name: "todo", SprintID: &sprintID})
+	assert.ErrorIs(t, err, dbErr)
+	assert.NotErrorIs(t, err, apperror.ErrValidation)
+
+	// 未登録の存在確認は設定の誤り
+	err = NewValidator(DefaultPasswordPolicy()).Validate(&testRequest{Name: "todo", SprintID: &sprintID})
+	assert.ErrorContains(t, err, `exists check "sprint" is not registered`)
+}
@@ -0,0 +1,2 @@
+ALTER TABLE sprints DROP COLUMN IF EXISTS version;
+ALTER TABLE todos DROP COLUMN IF EXISTS version;
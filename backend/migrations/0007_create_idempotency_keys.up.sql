@@ -0,0 +1,13 @@
+-- Idempotency-Key で受け付けたリクエストの結果（複数インスタンスで共有する）
+-- key はユーザー・エンドポイント・クライアントのキーの SHA-256、status_code が NULL の行は処理中
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key VARCHAR(64) PRIMARY KEY,
+    fingerprint VARCHAR(64) NOT NULL,
+    status_code INTEGER,
+    headers TEXT,
+    body BYTEA,
+    expires_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_idempotency_keys_expires_at ON idempotency_keys(expires_at);
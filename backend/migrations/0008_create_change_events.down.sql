@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_change_events_created_at;
+DROP TABLE IF EXISTS change_events;
@@ -0,0 +1,12 @@
+-- TODO・スプリントの変更イベント（リアルタイム配信の再送と、LISTEN/NOTIFY による複数インスタンスへの配信に使う）
+-- id がイベントID（SSE の Last-Event-ID）。payload は変更後の TODO・スプリントの JSON（削除では NULL）
+CREATE TABLE IF NOT EXISTS change_events (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    payload TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_change_events_created_at ON change_events(created_at);
@@ -0,0 +1,2 @@
+-- 削除したサンプルデータは復元しない（必要なら `migrate seed dev` で投入する）
+SELECT 1;
@@ -0,0 +1,14 @@
+-- 0001 / 0002 が投入していたサンプルデータを削除する（サンプルデータは `migrate seed dev` で投入する）
+-- 適用済みのマイグレーションは checksum が変わるため編集せず、このマイグレーションで取り除く
+
+-- testuser はパスワードが投入時のまま（password123）の場合のみ削除する
+DELETE FROM users
+WHERE username = 'testuser'
+  AND password_hash = '$2a$10$uoZqVUeLQWgDuFO24r5Eo.5v63qs0gq0W03brjMccY9rT8kXTzGS2';
+
+-- サンプルのスプリントは名前と色が投入時のままで、TODO が登録されていない場合のみ削除する
+DELETE FROM sprints
+WHERE ((name = 'バックログ' AND color = 'bg-blue-500')
+    OR (name = '2510-4' AND color = 'bg-purple-500')
+    OR (name = 'Personal Sprint' AND color = 'bg-purple-500'))
+  AND NOT EXISTS (SELECT 1 FROM todos WHERE todos.sprint_id = sprints.id);
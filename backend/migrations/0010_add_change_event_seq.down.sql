@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_change_events_pending;
+DROP INDEX IF EXISTS idx_change_events_seq;
+ALTER TABLE change_events DROP COLUMN IF EXISTS seq;
+DROP SEQUENCE IF EXISTS change_events_seq;
@@ -0,0 +1,12 @@
+-- 変更イベントの配信順（SSE の Last-Event-ID）
+-- イベントは TODO・スプリントの変更と同じトランザクションで記録し（seq は NULL）、コミット後に Broker が seq を振る。
+-- id は記録した順で、コミットの順序とは一致しないため配信順には使わない
+CREATE SEQUENCE IF NOT EXISTS change_events_seq;
+ALTER TABLE change_events ADD COLUMN IF NOT EXISTS seq BIGINT;
+
+-- 配信済みのイベントは id をそのまま seq にし、これまでに振った id の続きから番号を振る
+UPDATE change_events SET seq = id WHERE seq IS NULL;
+SELECT setval('change_events_seq', last_value, is_called) FROM change_events_id_seq;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_change_events_seq ON change_events(seq);
+CREATE INDEX IF NOT EXISTS idx_change_events_pending ON change_events(id) WHERE seq IS NULL;
@@ -0,0 +1,39 @@
+// Package migrations はSQLマイグレーションファイルをバイナリに埋め込む
+//
+// PostgreSQL 用はこのディレクトリ、SQLite 用は sqlite/ に置く
+// 2つのセットは同じバージョン番号・名前で同じスキーマを作る（マイグレーションを追加する場合は両方に作成する）
+package migrations
+
+import (
+	"embed"
+	"io/fs"
+
+	"backend/internal/config"
+)
+
+// FS は埋め込まれた PostgreSQL 用マイグレーションファイル（NNNN_name.up.sql / .down.sql）
+//
+//go:embed *.sql
+var FS embed.FS
+
+//go:embed sqlite/*.sql
+var sqliteFS embed.FS
+
+// SQLiteFS は SQLite 用マイグレーションファイル
+var SQLiteFS fs.FS = mustSub(sqliteFS, "sqlite")
+
+// ForDriver はDBドライバに対応するマイグレーションファイルを返す
+func ForDriver(driver string) fs.FS {
+	if driver == config.DriverSQLite {
+		return SQLiteFS
+	}
+	return FS
+}
+
+func mustSub(fsys fs.FS, dir string) fs.FS {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
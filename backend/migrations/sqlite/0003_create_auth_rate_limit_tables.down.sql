@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS auth_audit_logs;
+DROP TABLE IF EXISTS rate_limits;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_users_role;
+ALTER TABLE users DROP COLUMN token_version;
+ALTER TABLE users DROP COLUMN role;
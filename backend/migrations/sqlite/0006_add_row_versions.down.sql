@@ -0,0 +1,2 @@
+ALTER TABLE sprints DROP COLUMN version;
+ALTER TABLE todos DROP COLUMN version;
@@ -0,0 +1,3 @@
+-- 楽観的排他制御（ETag / If-Match）のための行バージョン。更新・削除のたびにインクリメントする
+ALTER TABLE todos ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE sprints ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_change_events_pending;
+DROP INDEX IF EXISTS idx_change_events_seq;
+ALTER TABLE change_events DROP COLUMN seq;
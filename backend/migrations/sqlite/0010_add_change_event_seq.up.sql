@@ -0,0 +1,8 @@
+-- 変更イベントの配信順（SSE の Last-Event-ID）
+-- イベントは TODO・スプリントの変更と同じトランザクションで記録し（seq は NULL）、コミット後に Broker が seq を振る。
+-- SQLite は書き込みが直列化され id の順にコミットされるため、seq には id を使う
+ALTER TABLE change_events ADD COLUMN seq INTEGER;
+UPDATE change_events SET seq = id WHERE seq IS NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_change_events_seq ON change_events(seq);
+CREATE INDEX IF NOT EXISTS idx_change_events_pending ON change_events(id) WHERE seq IS NULL;
@@ -4,32 +4,25 @@
  */
 
 export interface paths {
-    "/login": {
+    "/.well-known/jwks.json": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * ユーザーログイン
-         * @description ユーザー名とパスワードでログインし、JWTトークンを返します
+         * JWT検証用の公開鍵を取得
+         * @description 他のサービスがトークンを検証するための公開鍵（JWK Set）を返します。ローテーション中は旧鍵も含まれます
          */
-        post: {
+        get: {
             parameters: {
                 query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            /** @description ログイン情報 */
-            requestBody: {
-                content: {
-                    "application/json": components["schemas"]["model.LoginRequest"];
-                };
-            };
+            requestBody?: never;
             responses: {
                 /** @description OK */
                 200: {
@@ -37,29 +30,55 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.LoginResponse"];
+                        "application/json": components["schemas"]["auth.JWKSet"];
                     };
                 };
-                /** @description Bad Request */
-                400: {
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/admin/stats": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * システム統計を取得（管理者）
+         * @description ユーザー数、TODO数などのシステム統計を返します
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.SystemStats"];
                     };
                 };
-                /** @description Unauthorized */
-                401: {
+                /** @description Forbidden */
+                403: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -68,20 +87,38 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
         };
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/register": {
+    "/admin/users/search": {
         parameters: {
             query?: never;
             header?: never;
@@ -91,8 +128,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * ユーザー登録
-         * @description 新しいユーザーを登録します
+         * ユーザーを検索（管理者）
+         * @description 検索条件に基づいてユーザーを検索します。無効化されたユーザーも含みます。条件を省略すると全件を返します
          */
         post: {
             parameters: {
@@ -101,20 +138,20 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description 登録情報 */
+            /** @description 検索条件 */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["model.RegisterRequest"];
+                    "application/json": components["schemas"]["model.UserSearchRequest"];
                 };
             };
             responses: {
-                /** @description Created */
-                201: {
+                /** @description OK */
+                200: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.LoginResponse"];
+                        "application/json": components["schemas"]["model.User"][];
                     };
                 };
                 /** @description Bad Request */
@@ -123,20 +160,25 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
-                /** @description Conflict */
-                409: {
+                /** @description Forbidden */
+                403: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -145,9 +187,25 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
@@ -158,22 +216,26 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/sprints": {
+    "/admin/users/{id}/deactivate": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
         /**
-         * スプリントリストを取得
-         * @description すべてのスプリントを取得します
+         * ユーザーを無効化（管理者）
+         * @description ユーザーを無効化し、発行済みのトークンを失効させます。自分自身は無効化できません
          */
-        get: {
+        put: {
             parameters: {
                 query?: never;
                 header?: never;
-                path?: never;
+                path: {
+                    /** @description ユーザー ID */
+                    id: number;
+                };
                 cookie?: never;
             };
             requestBody?: never;
@@ -184,59 +246,36 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.Sprint"][];
+                        "application/json": {
+                            [key: string]: string;
+                        };
                     };
                 };
-                /** @description Internal Server Error */
-                500: {
+                /** @description Bad Request */
+                400: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
-            };
-        };
-        put?: never;
-        /**
-         * スプリントを作成
-         * @description 新しいスプリントを作成します
-         */
-        post: {
-            parameters: {
-                query?: never;
-                header?: never;
-                path?: never;
-                cookie?: never;
-            };
-            /** @description スプリント情報 */
-            requestBody: {
-                content: {
-                    "application/json": components["schemas"]["model.Sprint"];
-                };
-            };
-            responses: {
-                /** @description Created */
-                201: {
+                /** @description Forbidden */
+                403: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.Sprint"];
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
-                /** @description Bad Request */
-                400: {
+                /** @description Not Found */
+                404: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -245,20 +284,37 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
         };
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/sprints/search": {
+    "/admin/users/{id}/logout": {
         parameters: {
             query?: never;
             header?: never;
@@ -268,22 +324,20 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * スプリントを検索
-         * @description 検索条件に基づいてスプリントを検索します
+         * ユーザーを強制ログアウト（管理者）
+         * @description ユーザーに発行済みのトークンをすべて失効させます
          */
         post: {
             parameters: {
                 query?: never;
                 header?: never;
-                path?: never;
-                cookie?: never;
-            };
-            /** @description 検索条件 */
-            requestBody: {
-                content: {
-                    "application/json": components["schemas"]["model.SprintSearchRequest"];
+                path: {
+                    /** @description ユーザー ID */
+                    id: number;
                 };
+                cookie?: never;
             };
+            requestBody?: never;
             responses: {
                 /** @description OK */
                 200: {
@@ -291,7 +345,9 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.Sprint"][];
+                        "application/json": {
+                            [key: string]: string;
+                        };
                     };
                 };
                 /** @description Bad Request */
@@ -300,9 +356,25 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -311,9 +383,25 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
@@ -324,7 +412,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/sprints/{id}": {
+    "/admin/users/{id}/mfa": {
         parameters: {
             query?: never;
             header?: never;
@@ -332,26 +420,23 @@ export interface paths {
             cookie?: never;
         };
         get?: never;
+        put?: never;
+        post?: never;
         /**
-         * スプリントを更新
-         * @description 指定されたIDのスプリントを更新します
+         * ユーザーのMFAをリセット（管理者）
+         * @description 認証アプリを紛失したユーザーのTOTP設定とリカバリーコードを削除し、発行済みのトークンを失効させます
          */
-        put: {
+        delete: {
             parameters: {
                 query?: never;
                 header?: never;
                 path: {
-                    /** @description スプリント ID */
+                    /** @description ユーザー ID */
                     id: number;
                 };
                 cookie?: never;
             };
-            /** @description 更新内容 */
-            requestBody: {
-                content: {
-                    "application/json": components["schemas"]["model.Sprint"];
-                };
-            };
+            requestBody?: never;
             responses: {
                 /** @description OK */
                 200: {
@@ -360,7 +445,7 @@ export interface paths {
                     };
                     content: {
                         "application/json": {
-                            [key: string]: unknown;
+                            [key: string]: string;
                         };
                     };
                 };
@@ -370,9 +455,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Not Found */
@@ -381,9 +473,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -392,24 +482,52 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
         };
-        post?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/admin/users/{id}/reactivate": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
         /**
-         * スプリントを削除
-         * @description 指定されたIDのスプリントを論理削除します
+         * ユーザーを再有効化（管理者）
+         * @description 無効化されたユーザーを再び有効にします
          */
-        delete: {
+        put: {
             parameters: {
                 query?: never;
                 header?: never;
                 path: {
-                    /** @description スプリント ID */
+                    /** @description ユーザー ID */
                     id: number;
                 };
                 cookie?: never;
@@ -433,13 +551,94 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
                 500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/admin/users/{id}/role": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        /**
+         * ユーザーのロールを変更（管理者）
+         * @description ユーザーのロール（user / admin）を変更します。自分自身のロールは変更できません
+         */
+        put: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path: {
+                    /** @description ユーザー ID */
+                    id: number;
+                };
+                cookie?: never;
+            };
+            /** @description ロール */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.UpdateRoleRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -449,25 +648,92 @@ export interface paths {
                         };
                     };
                 };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
             };
         };
+        post?: never;
+        delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/todos": {
+    "/events/tickets": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * TODOリストを取得
-         * @description すべてのTODOを取得します
+         * 変更イベントの購読用チケットを発行
+         * @description GET /events（SSE）と GET /events/ws（WebSocket）に ?ticket= で接続するための短命のトークンを発行します。ブラウザの EventSource / WebSocket は Authorization ヘッダーを設定できないため、アクセストークンの代わりに使います
          */
-        get: {
+        post: {
             parameters: {
                 query?: never;
                 header?: never;
@@ -482,26 +748,1890 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.Todo"][];
+                        "application/json": components["schemas"]["model.EventStreamTicketResponse"];
+                    };
+                };
+                /** @description Unauthorized */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/login": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * ユーザーログイン
+         * @description ユーザー名とパスワードでログインし、JWTトークンを返します。MFA登録済みの場合はトークンの代わりに mfa_token を返します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description ログイン情報 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.LoginRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.LoginResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unauthorized */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Too Many Requests */
+                429: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/login/mfa": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * MFAでログインを完了
+         * @description ログイン時に返された mfa_token と、TOTPコードまたはリカバリーコードでログインを完了し、JWTトークンを返します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description MFA情報 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.MFALoginRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.LoginResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unauthorized */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Too Many Requests */
+                429: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/mfa/recovery-codes": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * リカバリーコードを再発行
+         * @description 現在のコードで確認してリカバリーコードを再発行します。以前のコードは無効になります。コードの誤りはログインと同じく数え、続くと一時的に拒否します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description 確認コード */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.MFACodeRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.RecoveryCodesResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Too Many Requests */
+                429: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/mfa/totp": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        post?: never;
+        /**
+         * TOTPを無効化
+         * @description 現在のコードで確認してMFAを無効化します。MFA必須のワークスペースに所属している場合は無効化できません。コードの誤りはログインと同じく数え、続くと一時的に拒否します
+         */
+        delete: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description 確認コード */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.MFACodeRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": {
+                            [key: string]: string;
+                        };
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Too Many Requests */
+                429: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/mfa/totp/confirm": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * TOTPの登録を確認
+         * @description 認証アプリのコードで登録を確認してMFAを有効化し、リカバリーコードを返します。登録用トークンで呼び出した場合はJWTも返します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description 確認コード */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.MFACodeRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.MFAConfirmResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/mfa/totp/enroll": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * TOTPの登録を開始
+         * @description 新しいTOTPシークレットを発行し、認証アプリ登録用の otpauth URI を返します。確認するまで有効になりません
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.MFAEnrollResponse"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/register": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * ユーザー登録
+         * @description 新しいユーザーを登録します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description 登録情報 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.RegisterRequest"];
+                };
+            };
+            responses: {
+                /** @description Created */
+                201: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.LoginResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Too Many Requests */
+                429: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/sprints": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * スプリントリストを取得
+         * @description すべてのスプリントを取得します。If-None-Match が ETag と一致すれば 304 を返します
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description 前回のレスポンスの ETag */
+                    "If-None-Match"?: string;
+                };
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        /** @description 一覧の ETag */
+                        ETag?: string;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Sprint"][];
+                    };
+                };
+                /** @description Not Modified */
+                304: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        /**
+         * スプリントを作成
+         * @description 新しいスプリントを作成します。Idempotency-Key を指定すると、同じキーの再送では作成せずに最初のレスポンスを返します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description 再送で重複して作成しないためのキー（同じキーの再送には最初のレスポンスを返す） */
+                    "Idempotency-Key"?: string;
+                };
+                path?: never;
+                cookie?: never;
+            };
+            /** @description スプリント情報 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.Sprint"];
+                };
+            };
+            responses: {
+                /** @description Created */
+                201: {
+                    headers: {
+                        /** @description 作成したスプリントのバージョン */
+                        ETag?: string;
+                        /** @description 保存したレスポンスを返した場合は true */
+                        "Idempotent-Replayed"?: string;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Sprint"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/sprints/search": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * スプリントを検索
+         * @description 検索条件に基づいてスプリントを検索します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description 検索条件 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.SprintSearchRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Sprint"][];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/sprints/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        /**
+         * スプリントを更新
+         * @description 指定されたIDのスプリントを更新し、更新後のスプリントを返します。If-Match を指定すると、バージョンが一致する場合のみ更新します
+         */
+        put: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description スプリントのバージョン（取得時の version を引用符で囲んだ ETag） */
+                    "If-Match"?: string;
+                };
+                path: {
+                    /** @description スプリント ID */
+                    id: number;
+                };
+                cookie?: never;
+            };
+            /** @description 更新内容 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.Sprint"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        /** @description 更新後のバージョン */
+                        ETag?: string;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Sprint"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Precondition Failed */
+                412: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        post?: never;
+        /**
+         * スプリントを削除
+         * @description 指定されたIDのスプリントを論理削除します。If-Match を指定すると、バージョンが一致する場合のみ削除します
+         */
+        delete: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description スプリントのバージョン（取得時の version を引用符で囲んだ ETag） */
+                    "If-Match"?: string;
+                };
+                path: {
+                    /** @description スプリント ID */
+                    id: number;
+                };
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": {
+                            [key: string]: string;
+                        };
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Precondition Failed */
+                412: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/sprints/{id}/favorite": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        /**
+         * お気に入り状態を更新
+         * @description 指定されたIDのスプリントのお気に入り状態を切り替えます。If-Match を指定すると、バージョンが一致する場合のみ更新します
+         */
+        put: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description スプリントのバージョン（取得時の version を引用符で囲んだ ETag） */
+                    "If-Match"?: string;
+                };
+                path: {
+                    /** @description スプリント ID */
+                    id: number;
+                };
+                cookie?: never;
+            };
+            /** @description お気に入り状態 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.UpdateFavoriteRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        /** @description 更新後のバージョン */
+                        ETag?: string;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": {
+                            [key: string]: string;
+                        };
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Precondition Failed */
+                412: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/todos": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * TODOリストを取得
+         * @description すべてのTODOを取得します。If-None-Match が ETag と一致すれば 304 を返します
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description 前回のレスポンスの ETag */
+                    "If-None-Match"?: string;
+                };
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        /** @description 一覧の ETag */
+                        ETag?: string;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Todo"][];
+                    };
+                };
+                /** @description Not Modified */
+                304: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        /**
+         * TODOを作成
+         * @description 新しいTODOを作成します。Idempotency-Key を指定すると、同じキーの再送では作成せずに最初のレスポンスを返します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description 再送で重複して作成しないためのキー（同じキーの再送には最初のレスポンスを返す） */
+                    "Idempotency-Key"?: string;
+                };
+                path?: never;
+                cookie?: never;
+            };
+            /** @description TODO情報 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.Todo"];
+                };
+            };
+            responses: {
+                /** @description Created */
+                201: {
+                    headers: {
+                        /** @description 作成したTODOのバージョン */
+                        ETag?: string;
+                        /** @description 保存したレスポンスを返した場合は true */
+                        "Idempotent-Replayed"?: string;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Todo"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/todos/bulk": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * TODOを一括操作
+         * @description 複数のTODOの作成・更新・完了・スプリントへの移動・削除をまとめて実行します（最大100件）。
+         *     mode が atomic（デフォルト）なら全ての操作を1つのトランザクションで実行し、1件でも失敗すれば全て取り消してその操作のエラーを返します。
+         *     per_item なら操作ごとに実行し、成功した操作だけを反映して各件の結果を返します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description 再送で重複して実行しないためのキー（同じキーの再送には最初のレスポンスを返す） */
+                    "Idempotency-Key"?: string;
+                };
+                path?: never;
+                cookie?: never;
+            };
+            /** @description 操作の一覧 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.BulkTodoRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.BulkTodoResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Precondition Failed */
+                412: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/todos/search": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * TODOを検索
+         * @description 検索条件に基づいてTODOを検索します
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description 検索条件 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.TodoSearchRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Todo"][];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/todos/{id}": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        /**
+         * TODOを更新
+         * @description 指定されたIDのTODOを更新し、更新後のTODOを返します。If-Match を指定すると、バージョンが一致する場合のみ更新します
+         */
+        put: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description TODOのバージョン（取得時の version を引用符で囲んだ ETag） */
+                    "If-Match"?: string;
+                };
+                path: {
+                    /** @description TODO ID */
+                    id: number;
+                };
+                cookie?: never;
+            };
+            /** @description 更新内容 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.Todo"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        /** @description 更新後のバージョン */
+                        ETag?: string;
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Todo"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Precondition Failed */
+                412: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        post?: never;
+        /**
+         * TODOを削除
+         * @description 指定されたIDのTODOを論理削除します。If-Match を指定すると、バージョンが一致する場合のみ削除します
+         */
+        delete: {
+            parameters: {
+                query?: never;
+                header?: {
+                    /** @description TODOのバージョン（取得時の version を引用符で囲んだ ETag） */
+                    "If-Match"?: string;
+                };
+                path: {
+                    /** @description TODO ID */
+                    id: number;
+                };
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description No Content */
+                204: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content?: never;
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Precondition Failed */
+                412: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+            };
+        };
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/workspaces": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * ワークスペース一覧を取得
+         * @description ログインユーザーが所属するワークスペースを取得します
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Workspace"][];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
-                /** @description Internal Server Error */
-                500: {
+                /** @description Service Unavailable */
+                503: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
         };
         put?: never;
         /**
-         * TODOを作成
-         * @description 新しいTODOを作成します
+         * ワークスペースを作成
+         * @description 新しいワークスペースを作成し、作成者をオーナーとして登録します
          */
         post: {
             parameters: {
@@ -510,10 +2640,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description TODO情報 */
+            /** @description ワークスペース情報 */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["model.Todo"];
+                    "application/json": components["schemas"]["model.CreateWorkspaceRequest"];
                 };
             };
             responses: {
@@ -523,7 +2653,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.Todo"];
+                        "application/json": components["schemas"]["model.Workspace"];
                     };
                 };
                 /** @description Bad Request */
@@ -532,9 +2662,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -543,9 +2680,25 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
@@ -556,7 +2709,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/todos/search": {
+    "/workspaces/{id}/members": {
         parameters: {
             query?: never;
             header?: never;
@@ -566,20 +2719,23 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * TODOを検索
-         * @description 検索条件に基づいてTODOを検索します
+         * ワークスペースにメンバーを追加
+         * @description ユーザーをワークスペースに追加します（オーナー・管理者のみ。管理者として追加できるのはオーナーのみ）。既にメンバーの場合は 409 を返し、ロールは変更しません
          */
         post: {
             parameters: {
                 query?: never;
                 header?: never;
-                path?: never;
+                path: {
+                    /** @description ワークスペース ID */
+                    id: number;
+                };
                 cookie?: never;
             };
-            /** @description 検索条件 */
+            /** @description メンバー情報 */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["model.TodoSearchRequest"];
+                    "application/json": components["schemas"]["model.AddWorkspaceMemberRequest"];
                 };
             };
             responses: {
@@ -589,7 +2745,9 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["model.Todo"][];
+                        "application/json": {
+                            [key: string]: string;
+                        };
                     };
                 };
                 /** @description Bad Request */
@@ -598,9 +2756,43 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -609,9 +2801,25 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
@@ -622,7 +2830,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/todos/{id}": {
+    "/workspaces/{id}/members/{username}/role": {
         parameters: {
             query?: never;
             header?: never;
@@ -631,23 +2839,25 @@ export interface paths {
         };
         get?: never;
         /**
-         * TODOを更新
-         * @description 指定されたIDのTODOを更新します
+         * ワークスペースのメンバーのロールを変更
+         * @description メンバーを管理者にする、または管理者をメンバーに戻します（オーナーのみ）。オーナーのロールは変更できません
          */
         put: {
             parameters: {
                 query?: never;
                 header?: never;
                 path: {
-                    /** @description TODO ID */
+                    /** @description ワークスペース ID */
                     id: number;
+                    /** @description メンバーのユーザー名 */
+                    username: string;
                 };
                 cookie?: never;
             };
-            /** @description 更新内容 */
+            /** @description ロール */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["model.Todo"];
+                    "application/json": components["schemas"]["model.UpdateWorkspaceMemberRoleRequest"];
                 };
             };
             responses: {
@@ -658,7 +2868,7 @@ export interface paths {
                     };
                     content: {
                         "application/json": {
-                            [key: string]: unknown;
+                            [key: string]: string;
                         };
                     };
                 };
@@ -668,9 +2878,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Not Found */
@@ -679,9 +2896,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Unprocessable Entity */
+                422: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -690,36 +2914,75 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
         };
         post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/workspaces/{id}/mfa": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
         /**
-         * TODOを削除
-         * @description 指定されたIDのTODOを論理削除します
+         * ワークスペースのMFA必須設定を更新
+         * @description メンバー全員にMFAを必須にするかを設定します（オーナー・管理者のみ）。有効化するには操作者自身がMFAを有効にしている必要があります
          */
-        delete: {
+        put: {
             parameters: {
                 query?: never;
                 header?: never;
                 path: {
-                    /** @description TODO ID */
+                    /** @description ワークスペース ID */
                     id: number;
                 };
                 cookie?: never;
             };
-            requestBody?: never;
+            /** @description MFA必須設定 */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["model.UpdateMFARequirementRequest"];
+                };
+            };
             responses: {
-                /** @description No Content */
-                204: {
+                /** @description OK */
+                200: {
                     headers: {
                         [name: string]: unknown;
                     };
-                    content?: never;
+                    content: {
+                        "application/json": {
+                            [key: string]: string;
+                        };
+                    };
                 };
                 /** @description Bad Request */
                 400: {
@@ -727,9 +2990,34 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -738,13 +3026,31 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": {
-                            [key: string]: string;
-                        };
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Service Unavailable */
+                503: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
+                    };
+                };
+                /** @description Gateway Timeout */
+                504: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["model.Problem"];
                     };
                 };
             };
         };
+        post?: never;
+        delete?: never;
         options?: never;
         head?: never;
         patch?: never;
@@ -754,38 +3060,176 @@ export interface paths {
 export type webhooks = Record<string, never>;
 export interface components {
     schemas: {
-        "model.LoginRequest": {
-            password: string;
+        "auth.JWK": {
+            alg?: string;
+            /** @description OKP（Ed25519） */
+            crv?: string;
+            e?: string;
+            kid?: string;
+            kty?: string;
+            /** @description RSA */
+            n?: string;
+            use?: string;
+            x?: string;
+        };
+        "auth.JWKSet": {
+            keys?: components["schemas"]["auth.JWK"][];
+        };
+        "model.AddWorkspaceMemberRequest": {
+            /**
+             * @description 省略時は member
+             * @enum {string}
+             */
+            role?: "admin" | "member";
             username: string;
         };
+        "model.BulkTodoOperation": {
+            completed?: boolean;
+            description?: string;
+            /** @example 1 */
+            id?: number;
+            /**
+             * @example complete
+             * @enum {string}
+             */
+            op: "create" | "update" | "complete" | "move" | "delete";
+            sprint_id?: number | null;
+            title?: string;
+            /** @description 0 ならバージョンを確認しない（If-Match を省略した場合と同じ） */
+            version?: number;
+        };
+        "model.BulkTodoRequest": {
+            /**
+             * @description 省略時は atomic
+             * @example atomic
+             * @enum {string}
+             */
+            mode?: "atomic" | "per_item";
+            operations: components["schemas"]["model.BulkTodoOperation"][];
+        };
+        "model.BulkTodoResponse": {
+            failed?: number;
+            mode?: string;
+            results?: components["schemas"]["model.BulkTodoResult"][];
+            succeeded?: number;
+        };
+        "model.BulkTodoResult": {
+            /** @description 失敗した場合 */
+            error?: components["schemas"]["model.Problem"];
+            index?: number;
+            op?: string;
+            /**
+             * @description Status は同じ操作を個別のAPIで実行した場合のステータスコード
+             * @example 200
+             */
+            status?: number;
+            /** @description 成功した create / update / complete / move の結果 */
+            todo?: components["schemas"]["model.Todo"];
+        };
+        "model.CreateWorkspaceRequest": {
+            name: string;
+        };
+        "model.EventStreamTicketResponse": {
+            /** @description ExpiresIn はチケットの有効期限（秒）。接続した後は期限が過ぎても購読を続けられる */
+            expires_in?: number;
+            ticket?: string;
+        };
+        "model.FieldError": {
+            code?: string;
+            field?: string;
+            message?: string;
+        };
+        "model.LoginRequest": {
+            password?: string;
+            username?: string;
+        };
         "model.LoginResponse": {
+            mfa_enrollment_required?: boolean;
+            /** @description MFAが必要な場合は Token の代わりに短命の MFAToken を返す */
+            mfa_required?: boolean;
+            mfa_token?: string;
+            token?: string;
+            user?: components["schemas"]["model.User"];
+        };
+        "model.MFACodeRequest": {
+            code?: string;
+        };
+        "model.MFAConfirmResponse": {
+            recovery_codes?: string[];
+            /** @description 登録用トークンで確認した場合のみ、最終的なJWTを返す */
             token?: string;
             user?: components["schemas"]["model.User"];
         };
+        "model.MFAEnrollResponse": {
+            /** @description OTPAuthURI は認証アプリに登録するためのURI（QRコードのペイロード） */
+            otpauth_uri?: string;
+            secret?: string;
+        };
+        "model.MFALoginRequest": {
+            code?: string;
+            mfa_token?: string;
+            recovery_code?: string;
+        };
+        "model.Problem": {
+            /** @example todo_not_found */
+            code?: string;
+            /** @example Todo not found */
+            detail?: string;
+            errors?: components["schemas"]["model.FieldError"][];
+            /** @example /todos/1 */
+            instance?: string;
+            /** @example 3f2b9c1e8a7d4e6f */
+            request_id?: string;
+            /** @example 404 */
+            status?: number;
+            /** @example Not Found */
+            title?: string;
+            /** @example about:blank */
+            type?: string;
+        };
+        "model.RecoveryCodesResponse": {
+            recovery_codes?: string[];
+        };
         "model.RegisterRequest": {
             email: string;
             password: string;
             username: string;
         };
         "model.Sprint": {
-            completed?: boolean;
+            color?: string;
             created_at?: string;
             id?: number;
-            title?: string;
+            is_favorite?: boolean;
+            name: string;
             updated_at?: string;
+            /** @description 更新のたびに増える行バージョン（ETag） */
+            version?: number;
         };
         "model.SprintSearchRequest": {
-            completed?: boolean;
-            title?: string;
+            is_favorite?: boolean;
+            name?: string;
+        };
+        "model.SystemStats": {
+            active_users?: number;
+            admins?: number;
+            completed_todos?: number;
+            failed_logins_24h?: number;
+            mfa_enabled?: number;
+            sprints?: number;
+            todos?: number;
+            users?: number;
+            workspaces?: number;
         };
         "model.Todo": {
             completed?: boolean;
             created_at?: string;
             description?: string;
             id?: number;
-            sprint_id?: number;
-            title?: string;
+            sprint_id?: number | null;
+            title: string;
             updated_at?: string;
+            /** @description 更新のたびに増える行バージョン（ETag） */
+            version?: number;
         };
         "model.TodoSearchRequest": {
             /** @description 完了状態でフィルタ（任意） */
@@ -797,6 +3241,20 @@ export interface components {
             /** @description 部分一致検索（任意） */
             title?: string;
         };
+        "model.UpdateFavoriteRequest": {
+            is_favorite?: boolean;
+        };
+        "model.UpdateMFARequirementRequest": {
+            mfa_required?: boolean;
+        };
+        "model.UpdateRoleRequest": {
+            /** @enum {string} */
+            role: "user" | "admin";
+        };
+        "model.UpdateWorkspaceMemberRoleRequest": {
+            /** @enum {string} */
+            role: "admin" | "member";
+        };
         "model.User": {
             created_at?: string;
             email?: string;
@@ -804,9 +3262,32 @@ export interface components {
             id?: number;
             is_active?: boolean;
             provider?: string;
+            role?: string;
             updated_at?: string;
             username?: string;
         };
+        "model.UserSearchRequest": {
+            /** @description 有効・無効でフィルタ（任意） */
+            is_active?: boolean;
+            limit?: number;
+            offset?: number;
+            /** @description ユーザー名・メールアドレスの部分一致（任意） */
+            query?: string;
+            /**
+             * @description ロールでフィルタ（任意）
+             * @enum {string}
+             */
+            role?: "user" | "admin";
+        };
+        "model.Workspace": {
+            created_at?: string;
+            id?: number;
+            mfa_required?: boolean;
+            name?: string;
+            /** @description 取得したユーザーのロール */
+            role?: string;
+            updated_at?: string;
+        };
     };
     responses: never;
     parameters: never;